@@ -13,6 +13,7 @@ import (
 	"github.com/pomerium/pomerium/internal/frontend"
 	"github.com/pomerium/pomerium/internal/identity"
 	"github.com/pomerium/pomerium/internal/identity/oauth"
+	"github.com/pomerium/pomerium/internal/identity/saml"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
@@ -37,9 +38,14 @@ func ValidateOptions(o *config.Options) error {
 	if o.ClientID == "" {
 		return errors.New("authenticate: 'IDP_CLIENT_ID' is required")
 	}
-	if o.ClientSecret == "" {
+	if o.ClientSecret == "" && o.Provider != saml.Name {
 		return errors.New("authenticate: 'IDP_CLIENT_SECRET' is required")
 	}
+	for _, idp := range o.IdentityProviders {
+		if idp.ClientSecret == "" && idp.Provider != saml.Name {
+			return fmt.Errorf("authenticate: identity provider %s: client_secret is required", idp.Name)
+		}
+	}
 	if o.AuthenticateCallbackPath == "" {
 		return errors.New("authenticate: 'AUTHENTICATE_CALLBACK_PATH' is required")
 	}
@@ -52,16 +58,19 @@ type Authenticate struct {
 
 	options  *config.AtomicOptions
 	provider *identity.AtomicAuthenticator
-	state    *atomicAuthenticateState
+	// identityProviders are the authenticators of the named identity providers
+	identityProviders *atomicIdentityProviders
+	state             *atomicAuthenticateState
 }
 
 // New validates and creates a new authenticate service from a set of Options.
 func New(cfg *config.Config) (*Authenticate, error) {
 	a := &Authenticate{
-		templates: template.Must(frontend.NewTemplates()),
-		options:   config.NewAtomicOptions(),
-		provider:  identity.NewAtomicAuthenticator(),
-		state:     newAtomicAuthenticateState(newAuthenticateState()),
+		templates:         template.Must(frontend.NewTemplates()),
+		options:           config.NewAtomicOptions(),
+		provider:          identity.NewAtomicAuthenticator(),
+		identityProviders: newAtomicIdentityProviders(),
+		state:             newAtomicAuthenticateState(newAuthenticateState()),
 	}
 
 	state, err := newAuthenticateStateFromConfig(cfg)
@@ -115,12 +124,27 @@ func (a *Authenticate) updateProvider(cfg *config.Config) error {
 			Scopes:          cfg.Options.Scopes,
 			ServiceAccount:  cfg.Options.ServiceAccount,
 			AuthCodeOptions: cfg.Options.RequestParams,
+			DisablePKCE:     cfg.Options.DisablePKCE,
 		})
 	if err != nil {
 		return err
 	}
 	a.provider.Store(provider)
 
+	// configure the named identity providers
+	identityProviders := make(map[string]identity.Authenticator, len(cfg.Options.IdentityProviders))
+	for _, idp := range cfg.Options.IdentityProviders {
+		oauthOptions, err := cfg.Options.GetIdentityProviderOauthOptions(idp.Name)
+		if err != nil {
+			return err
+		}
+		identityProviders[idp.Name], err = identity.NewAuthenticator(oauthOptions)
+		if err != nil {
+			return fmt.Errorf("identity provider %s: %w", idp.Name, err)
+		}
+	}
+	a.identityProviders.Store(identityProviders)
+
 	return nil
 }
 
@@ -45,6 +45,9 @@ func TestOptions_Validate(t *testing.T) {
 	badAuthenticateURL.AuthenticateURLString = "BAD_URL"
 	badCallbackPath := newTestOptions(t)
 	badCallbackPath.AuthenticateCallbackPath = ""
+	samlNoClientSecret := newTestOptions(t)
+	samlNoClientSecret.Provider = "saml"
+	samlNoClientSecret.ClientSecret = ""
 
 	tests := []struct {
 		name    string
@@ -59,6 +62,7 @@ func TestOptions_Validate(t *testing.T) {
 		{"no shared secret", badSharedKey, true},
 		{"no client id", emptyClientID, true},
 		{"no client secret", emptyClientSecret, true},
+		{"saml without client secret", samlNoClientSecret, false},
 		{"empty callback path", badCallbackPath, true},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,73 @@
+package authenticate
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+// versionedDataBrokerServiceClient stores records in memory and supports version-checked writes and structured
+// queries.
+type versionedDataBrokerServiceClient struct {
+	databroker.DataBrokerServiceClient
+
+	mu      sync.Mutex
+	version uint64
+	records map[string]*databroker.Record
+}
+
+func (c *versionedDataBrokerServiceClient) Get(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	record, ok := c.records[in.GetType()+"/"+in.GetId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "record not found")
+	}
+	return &databroker.GetResponse{Record: proto.Clone(record).(*databroker.Record)}, nil
+}
+
+func (c *versionedDataBrokerServiceClient) Put(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := in.GetRecord().GetType() + "/" + in.GetRecord().GetId()
+	if in.GetCheckVersion() && in.GetRecord().GetVersion() != c.records[key].GetVersion() {
+		return nil, status.Error(codes.FailedPrecondition, "record version conflict")
+	}
+
+	record := proto.Clone(in.GetRecord()).(*databroker.Record)
+	if record.GetDeletedAt() != nil {
+		delete(c.records, key)
+	} else {
+		c.version++
+		record.Version = c.version
+		c.records[key] = record
+	}
+	return &databroker.PutResponse{Record: record}, nil
+}
+
+func (c *versionedDataBrokerServiceClient) Query(ctx context.Context, in *databroker.QueryRequest, opts ...grpc.CallOption) (*databroker.QueryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	q, err := storage.ParseQuery(in.GetFilter(), in.GetOrderBy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var records []*databroker.Record
+	for _, record := range c.records {
+		if record.GetType() == in.GetType() {
+			records = append(records, proto.Clone(record).(*databroker.Record))
+		}
+	}
+	records = q.Execute(ctx, records)
+	return &databroker.QueryResponse{Records: records, TotalCount: int64(len(records))}, nil
+}
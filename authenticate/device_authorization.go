@@ -0,0 +1,349 @@
+package authenticate
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pomerium/csrf"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/deviceauth"
+)
+
+// OAuth 2.0 Device Authorization Grant
+//
+// https://datatracker.ietf.org/doc/html/rfc8628
+const (
+	deviceAuthorizationPath = "/oauth2/device_authorization"
+	deviceTokenPath         = "/oauth2/token"
+	deviceVerificationPath  = "/.pomerium/activate"
+
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	deviceAuthorizationExpiry   = 10 * time.Minute
+	deviceAuthorizationInterval = 5 * time.Second
+
+	// device authorization requests are unauthenticated, so the number of pending requests is limited to keep them
+	// from filling the databroker
+	maxPendingDeviceAuthorizations = 1000
+
+	// user codes use consonants only, so they are easy to type and don't spell words
+	//
+	// https://datatracker.ietf.org/doc/html/rfc8628#section-6.1
+	userCodeCharacters = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength     = 8
+)
+
+// deviceAuthorization starts a device authorization request. The device shows the user code and verification URI
+// to the user, then polls the token endpoint with the device code until the user approves or denies the request.
+// Only the configured client ids can start a request.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+func (a *Authenticate) deviceAuthorization(w http.ResponseWriter, r *http.Request) error {
+	ctx, span := trace.StartSpan(r.Context(), "authenticate.deviceAuthorization")
+	defer span.End()
+
+	state := a.state.Load()
+
+	clientID := r.FormValue("client_id")
+	if clientID == "" {
+		renderOAuthError(w, "invalid_request", "client_id is required")
+		return nil
+	}
+	if !containsString(a.options.Load().DeviceAuthorizationClientIDs, clientID) {
+		renderOAuthError(w, "invalid_client", "unknown client_id")
+		return nil
+	}
+
+	pending, err := deviceauth.Count(ctx, state.dataBrokerClient)
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError,
+			fmt.Errorf("authenticate: error counting device authorizations: %w", err))
+	}
+	if pending >= maxPendingDeviceAuthorizations {
+		return httputil.NewError(http.StatusServiceUnavailable,
+			errors.New("authenticate: too many pending device authorizations"))
+	}
+
+	userCode, err := newUserCode()
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+	// the device code starts with the user code, so the device authorization can be found when polling
+	deviceCode := userCode + "." + base64.RawURLEncoding.EncodeToString(cryptutil.NewKey())
+
+	err = deviceauth.Put(ctx, state.dataBrokerClient, &deviceauth.DeviceAuthorization{
+		Id:             userCode,
+		DeviceCodeHash: hashDeviceCode(deviceCode),
+		ClientId:       clientID,
+		ExpiresAt:      timestamppb.New(time.Now().Add(deviceAuthorizationExpiry)),
+	}, 0)
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError,
+			fmt.Errorf("authenticate: error saving device authorization: %w", err))
+	}
+
+	verificationURL := state.redirectURL.ResolveReference(&url.URL{Path: deviceVerificationPath})
+	verificationURLComplete := *verificationURL
+	verificationURLComplete.RawQuery = url.Values{"user_code": {formatUserCode(userCode)}}.Encode()
+
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.RenderJSON(w, http.StatusOK, struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int64  `json:"expires_in"`
+		Interval                int64  `json:"interval"`
+	}{
+		DeviceCode:              deviceCode,
+		UserCode:                formatUserCode(userCode),
+		VerificationURI:         verificationURL.String(),
+		VerificationURIComplete: verificationURLComplete.String(),
+		ExpiresIn:               int64(deviceAuthorizationExpiry.Seconds()),
+		Interval:                int64(deviceAuthorizationInterval.Seconds()),
+	})
+	return nil
+}
+
+// deviceAccessToken exchanges the device code of an approved device authorization request for a Pomerium session
+// JWT. The access token is sent as an `Authorization: Bearer Pomerium-<JWT>` header.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+func (a *Authenticate) deviceAccessToken(w http.ResponseWriter, r *http.Request) error {
+	ctx, span := trace.StartSpan(r.Context(), "authenticate.deviceAccessToken")
+	defer span.End()
+
+	state := a.state.Load()
+
+	if r.FormValue("grant_type") != deviceCodeGrantType {
+		renderOAuthError(w, "unsupported_grant_type", "only the device_code grant type is supported")
+		return nil
+	}
+
+	deviceCode := r.FormValue("device_code")
+	userCode := strings.SplitN(deviceCode, ".", 2)[0]
+	if userCode == "" {
+		renderOAuthError(w, "invalid_request", "device_code is required")
+		return nil
+	}
+
+	da, version, err := deviceauth.Get(ctx, state.dataBrokerClient, userCode)
+	if status.Code(err) == codes.NotFound {
+		renderOAuthError(w, "invalid_grant", "unknown device_code")
+		return nil
+	} else if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+
+	switch {
+	case subtle.ConstantTimeCompare(da.GetDeviceCodeHash(), hashDeviceCode(deviceCode)) != 1:
+		renderOAuthError(w, "invalid_grant", "unknown device_code")
+		return nil
+	case da.GetClientId() != r.FormValue("client_id"):
+		renderOAuthError(w, "invalid_grant", "device_code was issued to another client")
+		return nil
+	case da.GetExpiresAt().AsTime().Before(time.Now()):
+		renderOAuthError(w, "expired_token", "device_code has expired")
+		return nil
+	case !da.GetDenied() && da.GetSessionJwt() == "":
+		renderOAuthError(w, "authorization_pending", "")
+		return nil
+	}
+
+	// the device code can only be used once
+	err = deviceauth.Delete(ctx, state.dataBrokerClient, da.GetId(), version)
+	if status.Code(err) == codes.FailedPrecondition {
+		renderOAuthError(w, "invalid_grant", "device_code has already been used")
+		return nil
+	} else if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+
+	if da.GetDenied() {
+		renderOAuthError(w, "access_denied", "")
+		return nil
+	}
+
+	var s sessions.State
+	if err := state.sharedEncoder.Unmarshal([]byte(da.GetSessionJwt()), &s); err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+	var expiresIn int64
+	if s.Expiry != nil {
+		expiresIn = int64(time.Until(s.Expiry.Time()).Seconds())
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.RenderJSON(w, http.StatusOK, struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in,omitempty"`
+	}{
+		AccessToken: httputil.AuthorizationTypePomerium + "-" + da.GetSessionJwt(),
+		TokenType:   "Bearer",
+		ExpiresIn:   expiresIn,
+	})
+	return nil
+}
+
+// deviceVerification lets a signed in user approve or deny a device authorization request.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.3
+func (a *Authenticate) deviceVerification(w http.ResponseWriter, r *http.Request) error {
+	ctx, span := trace.StartSpan(r.Context(), "authenticate.deviceVerification")
+	defer span.End()
+
+	state := a.state.Load()
+
+	data := map[string]interface{}{
+		"Action":    deviceVerificationPath,
+		"csrfField": csrf.TemplateField(r),
+	}
+	render := func() error {
+		return a.templates.ExecuteTemplate(w, "device-authorization.html", data)
+	}
+
+	userCode := normalizeUserCode(r.FormValue("user_code"))
+	if userCode == "" {
+		return render()
+	}
+	data["UserCode"] = formatUserCode(userCode)
+
+	da, version, err := deviceauth.Get(ctx, state.dataBrokerClient, userCode)
+	if status.Code(err) == codes.NotFound {
+		data["Error"] = "The code is invalid or has expired."
+		return render()
+	} else if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+	if da.GetExpiresAt().AsTime().Before(time.Now()) || da.GetDenied() || da.GetSessionJwt() != "" {
+		data["Error"] = "The code is invalid or has expired."
+		return render()
+	}
+
+	// the user should know which client they're signing in
+	//
+	// https://datatracker.ietf.org/doc/html/rfc8628#section-5.4
+	data["ClientID"] = da.GetClientId()
+
+	if r.Method != http.MethodPost {
+		data["Pending"] = true
+		return render()
+	}
+
+	switch r.FormValue("action") {
+	case "approve":
+		da.SessionJwt, err = a.newDeviceSessionJWT(r)
+		if err != nil {
+			return err
+		}
+		data["Approved"] = true
+	case "deny":
+		da.Denied = true
+		data["Denied"] = true
+	default:
+		return httputil.NewError(http.StatusBadRequest, errors.New("action must be approve or deny"))
+	}
+
+	err = deviceauth.Put(ctx, state.dataBrokerClient, da, version)
+	if status.Code(err) == codes.FailedPrecondition {
+		return httputil.NewError(http.StatusConflict, errors.New("device authorization was already changed"))
+	} else if err != nil {
+		return httputil.NewError(http.StatusInternalServerError,
+			fmt.Errorf("authenticate: error saving device authorization: %w", err))
+	}
+	return render()
+}
+
+// newDeviceSessionJWT returns a programmatic session JWT for the current session, like the one returned by the
+// programmatic login flow.
+func (a *Authenticate) newDeviceSessionJWT(r *http.Request) (string, error) {
+	state := a.state.Load()
+
+	s, err := a.getSessionFromCtx(r.Context())
+	if err != nil {
+		return "", err
+	}
+
+	newSession := sessions.NewSession(s, state.redirectURL.Host, []string{state.redirectURL.Host})
+	newSession.Programmatic = true
+
+	signedJWT, err := state.sharedEncoder.Marshal(newSession)
+	if err != nil {
+		return "", httputil.NewError(http.StatusInternalServerError, err)
+	}
+	return string(signedJWT), nil
+}
+
+// renderOAuthError renders an OAuth 2.0 error response.
+//
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+func renderOAuthError(w http.ResponseWriter, code, description string) {
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.RenderJSON(w, http.StatusBadRequest, struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description,omitempty"`
+	}{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
+
+func newUserCode() (string, error) {
+	var sb strings.Builder
+	for i := 0; i < userCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(userCodeCharacters))))
+		if err != nil {
+			return "", fmt.Errorf("authenticate: error generating user code: %w", err)
+		}
+		sb.WriteByte(userCodeCharacters[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// normalizeUserCode uppercases the user code and removes any characters which can't be part of it, like the dash
+// in a formatted user code.
+func normalizeUserCode(userCode string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(userCodeCharacters, r) {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(userCode))
+}
+
+// formatUserCode formats a normalized user code as two groups separated by a dash, e.g. WDJB-MJHT.
+func formatUserCode(userCode string) string {
+	if len(userCode) != userCodeLength {
+		return userCode
+	}
+	return userCode[:userCodeLength/2] + "-" + userCode[userCodeLength/2:]
+}
+
+func hashDeviceCode(deviceCode string) []byte {
+	return cryptutil.Hash("device authorization device code", []byte(deviceCode))
+}
+
+func containsString(elements []string, value string) bool {
+	for _, element := range elements {
+		if element == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/deviceauth"
+)
+
+func TestAuthenticate_DeviceAuthorization(t *testing.T) {
+	signer, err := jws.NewHS256Signer(nil)
+	require.NoError(t, err)
+
+	a := testAuthenticate()
+	a.options.Load().DeviceAuthorizationClientIDs = []string{"pomerium-cli"}
+	state := a.state.Load()
+	state.redirectURL = mustParseURL("https://authenticate.example.com")
+	state.sharedEncoder = signer
+	client := &versionedDataBrokerServiceClient{records: map[string]*databroker.Record{}}
+	state.dataBrokerClient = client
+
+	post := func(h httputil.HandlerFunc, values url.Values) (int, map[string]interface{}) {
+		r := httptest.NewRequest(http.MethodPost, "https://authenticate.example.com", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+		return w.Code, res
+	}
+	authorize := func(t *testing.T) (deviceCode, userCode string) {
+		code, res := post(a.deviceAuthorization, url.Values{"client_id": {"pomerium-cli"}})
+		require.Equal(t, http.StatusOK, code, res)
+		userCode = res["user_code"].(string)
+		assert.Regexp(t, `^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`, userCode)
+		assert.Equal(t, "https://authenticate.example.com/.pomerium/activate", res["verification_uri"])
+		assert.Equal(t, "https://authenticate.example.com/.pomerium/activate?user_code="+userCode, res["verification_uri_complete"])
+		assert.Equal(t, float64(600), res["expires_in"])
+		assert.Equal(t, float64(5), res["interval"])
+		return res["device_code"].(string), userCode
+	}
+	token := func(deviceCode, clientID string) (int, map[string]interface{}) {
+		return post(a.deviceAccessToken, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {deviceCode},
+			"client_id":   {clientID},
+		})
+	}
+	verify := func(t *testing.T, method string, values url.Values) string {
+		sessionJWT, err := signer.Marshal(&sessions.State{ID: "SESSION_ID", Subject: "USER_ID"})
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(method, "https://authenticate.example.com/.pomerium/activate?"+values.Encode(), nil)
+		r = r.WithContext(sessions.NewContext(r.Context(), string(sessionJWT), nil))
+		w := httptest.NewRecorder()
+		httputil.HandlerFunc(a.deviceVerification).ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w.Body.String()
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		deviceCode, userCode := authorize(t)
+
+		code, res := token(deviceCode, "pomerium-cli")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "authorization_pending", res["error"])
+
+		body := verify(t, http.MethodGet, url.Values{"user_code": {strings.ToLower(userCode)}})
+		assert.Contains(t, body, "showing the code "+userCode)
+		assert.Contains(t, body, "requested by the client pomerium-cli")
+
+		body = verify(t, http.MethodPost, url.Values{"user_code": {userCode}, "action": {"approve"}})
+		assert.Contains(t, body, "The device was signed in.")
+
+		code, res = token(deviceCode, "other-client")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_grant", res["error"])
+
+		code, res = token(deviceCode, "pomerium-cli")
+		require.Equal(t, http.StatusOK, code, res)
+		assert.Equal(t, "Bearer", res["token_type"])
+		accessToken := res["access_token"].(string)
+		require.True(t, strings.HasPrefix(accessToken, "Pomerium-"))
+
+		var s sessions.State
+		require.NoError(t, signer.Unmarshal([]byte(strings.TrimPrefix(accessToken, "Pomerium-")), &s))
+		assert.Equal(t, "SESSION_ID", s.ID)
+		assert.Equal(t, "USER_ID", s.Subject)
+		assert.True(t, s.Programmatic)
+
+		code, res = token(deviceCode, "pomerium-cli")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_grant", res["error"], "device codes should only be used once")
+
+		body = verify(t, http.MethodGet, url.Values{"user_code": {userCode}})
+		assert.Contains(t, body, "The code is invalid or has expired.")
+	})
+	t.Run("deny", func(t *testing.T) {
+		deviceCode, userCode := authorize(t)
+
+		body := verify(t, http.MethodPost, url.Values{"user_code": {userCode}, "action": {"deny"}})
+		assert.Contains(t, body, "The device sign in was denied.")
+
+		code, res := token(deviceCode, "pomerium-cli")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "access_denied", res["error"])
+	})
+	t.Run("invalid device code", func(t *testing.T) {
+		_, userCode := authorize(t)
+
+		code, res := token(normalizeUserCode(userCode)+".WRONG", "pomerium-cli")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_grant", res["error"])
+	})
+	t.Run("handler", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "https://authenticate.example.com/oauth2/device_authorization",
+			strings.NewReader(url.Values{"client_id": {"pomerium-cli"}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		a.Handler().ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code, "devices should not need a csrf token")
+	})
+	t.Run("missing client id", func(t *testing.T) {
+		code, res := post(a.deviceAuthorization, url.Values{})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_request", res["error"])
+	})
+	t.Run("unknown client id", func(t *testing.T) {
+		code, res := post(a.deviceAuthorization, url.Values{"client_id": {"other-client"}})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_client", res["error"])
+	})
+	t.Run("too many pending", func(t *testing.T) {
+		for i := 0; i < maxPendingDeviceAuthorizations; i++ {
+			require.NoError(t, deviceauth.Put(context.Background(), client, &deviceauth.DeviceAuthorization{
+				Id:       fmt.Sprintf("PENDING%d", i),
+				ClientId: "pomerium-cli",
+			}, 0))
+		}
+
+		r := httptest.NewRequest(http.MethodPost, "https://authenticate.example.com",
+			strings.NewReader(url.Values{"client_id": {"pomerium-cli"}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		err := a.deviceAuthorization(httptest.NewRecorder(), r)
+		var httpErr *httputil.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.Status)
+	})
+	t.Run("unsupported grant type", func(t *testing.T) {
+		code, res := post(a.deviceAccessToken, url.Values{"grant_type": {"authorization_code"}})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "unsupported_grant_type", res["error"])
+	})
+}
+
+func TestUserCode(t *testing.T) {
+	userCode, err := newUserCode()
+	require.NoError(t, err)
+	assert.Len(t, userCode, userCodeLength)
+	assert.Equal(t, userCode, normalizeUserCode(userCode))
+
+	assert.Equal(t, "WDJBMJHT", normalizeUserCode("wdjb-mjht"))
+	assert.Equal(t, "WDJBMJHT", normalizeUserCode(" WDJB MJHT "))
+	assert.Equal(t, "WDJB-MJHT", formatUserCode("WDJBMJHT"))
+	assert.Equal(t, "WDJ", formatUserCode("WDJ"))
+}
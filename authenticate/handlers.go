@@ -24,6 +24,7 @@ import (
 	"github.com/pomerium/pomerium/internal/identity"
 	"github.com/pomerium/pomerium/internal/identity/manager"
 	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/internal/identity/saml"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/middleware"
 	"github.com/pomerium/pomerium/internal/sessions"
@@ -35,6 +36,10 @@ import (
 	"github.com/pomerium/pomerium/pkg/grpc/user"
 )
 
+// relayStateField is the form field used to re-submit the RelayState handle
+// of a SAML response from our origin.
+const relayStateField = "relay_state"
+
 // Handler returns the authenticate service's handler chain.
 func (a *Authenticate) Handler() http.Handler {
 	r := httputil.NewRouter()
@@ -46,6 +51,8 @@ func (a *Authenticate) Handler() http.Handler {
 func (a *Authenticate) Mount(r *mux.Router) {
 	r.StrictSlash(true)
 	r.Use(middleware.SetHeaders(httputil.HeadersContentSecurityPolicy))
+	r.Use(a.resubmitFormPostCallback)
+	r.Use(skipCSRFCheck(deviceAuthorizationPath, deviceTokenPath, backChannelLogoutPath))
 	r.Use(func(h http.Handler) http.Handler {
 		options := a.options.Load()
 		state := a.state.Load()
@@ -72,7 +79,13 @@ func (a *Authenticate) Mount(r *mux.Router) {
 
 	r.Path("/robots.txt").HandlerFunc(a.RobotsTxt).Methods(http.MethodGet)
 	// Identity Provider (IdP) endpoints
-	r.Path("/oauth2/callback").Handler(httputil.HandlerFunc(a.OAuthCallback)).Methods(http.MethodGet)
+	r.Path("/oauth2/callback").Handler(httputil.HandlerFunc(a.OAuthCallback)).Methods(http.MethodGet, http.MethodPost)
+	// OAuth 2.0 Device Authorization Grant endpoints
+	r.Path(deviceAuthorizationPath).Handler(httputil.HandlerFunc(a.deviceAuthorization)).Methods(http.MethodPost)
+	r.Path(deviceTokenPath).Handler(httputil.HandlerFunc(a.deviceAccessToken)).Methods(http.MethodPost)
+	// OpenID Connect logout endpoints
+	r.Path(backChannelLogoutPath).Handler(httputil.HandlerFunc(a.backChannelLogout)).Methods(http.MethodPost)
+	r.Path(frontChannelLogoutPath).Handler(a.RetrieveSession(httputil.HandlerFunc(a.frontChannelLogout))).Methods(http.MethodGet)
 
 	a.mountDashboard(r)
 	a.mountWellKnown(r)
@@ -100,6 +113,7 @@ func (a *Authenticate) mountDashboard(r *mux.Router) {
 	sr.Path("/sign_out").Handler(a.requireValidSignature(a.SignOut))
 	sr.Path("/webauthn").Handler(webauthn.New(a.getWebauthnState))
 	sr.Path("/device-enrolled").Handler(handlers.DeviceEnrolled())
+	sr.Path("/activate").Handler(httputil.HandlerFunc(a.deviceVerification)).Methods(http.MethodGet, http.MethodPost)
 
 	cr := sr.PathPrefix("/callback").Subrouter()
 	cr.Use(func(h http.Handler) http.Handler {
@@ -111,6 +125,7 @@ func (a *Authenticate) mountDashboard(r *mux.Router) {
 func (a *Authenticate) mountWellKnown(r *mux.Router) {
 	wk := r.PathPrefix("/.well-known/pomerium").Subrouter()
 	wk.Path("/jwks.json").Handler(httputil.HandlerFunc(a.jwks)).Methods(http.MethodGet)
+	wk.Path("/saml/metadata.xml").Handler(httputil.HandlerFunc(a.samlMetadata)).Methods(http.MethodGet)
 	wk.Path("/").Handler(httputil.HandlerFunc(a.wellKnown)).Methods(http.MethodGet)
 }
 
@@ -123,10 +138,20 @@ func (a *Authenticate) wellKnown(w http.ResponseWriter, r *http.Request) error {
 		OAuth2Callback        string `json:"authentication_callback_endpoint"` // RFC6749
 		JSONWebKeySetURL      string `json:"jwks_uri"`                         // RFC7517
 		FrontchannelLogoutURI string `json:"frontchannel_logout_uri"`          // https://openid.net/specs/openid-connect-frontchannel-1_0.html
+		BackchannelLogoutURI  string `json:"backchannel_logout_uri"`           // https://openid.net/specs/openid-connect-backchannel-1_0.html
+		SAMLMetadataURI       string `json:"saml_metadata_uri,omitempty"`      // SAML 2.0 service provider metadata
+		DeviceAuthorization   string `json:"device_authorization_endpoint"`    // RFC8628
+		Token                 string `json:"token_endpoint"`                   // RFC8628
 	}{
-		state.redirectURL.ResolveReference(&url.URL{Path: "/oauth2/callback"}).String(),
-		state.redirectURL.ResolveReference(&url.URL{Path: "/.well-known/pomerium/jwks.json"}).String(),
-		state.redirectURL.ResolveReference(&url.URL{Path: "/.pomerium/sign_out"}).String(),
+		OAuth2Callback:        state.redirectURL.ResolveReference(&url.URL{Path: "/oauth2/callback"}).String(),
+		JSONWebKeySetURL:      state.redirectURL.ResolveReference(&url.URL{Path: "/.well-known/pomerium/jwks.json"}).String(),
+		FrontchannelLogoutURI: state.redirectURL.ResolveReference(&url.URL{Path: frontChannelLogoutPath}).String(),
+		BackchannelLogoutURI:  state.redirectURL.ResolveReference(&url.URL{Path: backChannelLogoutPath}).String(),
+		DeviceAuthorization:   state.redirectURL.ResolveReference(&url.URL{Path: deviceAuthorizationPath}).String(),
+		Token:                 state.redirectURL.ResolveReference(&url.URL{Path: deviceTokenPath}).String(),
+	}
+	if a.options.Load().Provider == saml.Name {
+		wellKnownURLS.SAMLMetadataURI = state.redirectURL.ResolveReference(&url.URL{Path: saml.MetadataPath}).String()
 	}
 	w.Header().Set("X-CSRF-Token", csrf.Token(r))
 	httputil.RenderJSON(w, http.StatusOK, wellKnownURLS)
@@ -142,6 +167,106 @@ func (a *Authenticate) jwks(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// samlMetadata returns the SAML 2.0 service provider metadata, if the identity
+// provider is SAML. Named identity providers are selected with the idp query
+// parameter.
+func (a *Authenticate) samlMetadata(w http.ResponseWriter, r *http.Request) error {
+	provider, err := a.getProvider(r.FormValue("idp"))
+	if err != nil {
+		return httputil.NewError(http.StatusNotFound, err)
+	}
+	metadata, ok, err := identity.ServiceProviderMetadata(provider)
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	} else if !ok {
+		return httputil.NewError(http.StatusNotFound, errors.New("identity provider has no service provider metadata"))
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(metadata)
+	return nil
+}
+
+// resubmitFormPostCallback re-submits callbacks from identity providers which
+// use the HTTP-POST binding, like SAML. The identity provider posts the
+// response from its own origin so the SameSite cookies aren't sent. The
+// response is posted again from our origin, where the RelayState handle is
+// exchanged for the state kept in the relay state cookie, so the csrf nonce
+// in the state is validated as usual.
+func (a *Authenticate) resubmitFormPostCallback(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth2/callback" || r.PostFormValue("state") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if relayState := r.PostFormValue("RelayState"); relayState != "" {
+			err := a.templates.ExecuteTemplate(w, "form-post.html", map[string]interface{}{
+				"Action": r.URL.Path,
+				"Values": map[string]string{
+					"SAMLResponse":  r.PostFormValue("SAMLResponse"),
+					relayStateField: relayState,
+				},
+			})
+			if err != nil {
+				log.FromRequest(r).Error().Err(err).Msg("authenticate: failed to render form post")
+			}
+			return
+		}
+
+		if handle := r.PostFormValue(relayStateField); handle != "" {
+			state := a.popRelayState(w, r, handle)
+			r.Form.Set("state", state)
+			r.PostForm.Set("state", state)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setRelayState keeps the state in a short lived cookie and returns an
+// opaque handle for it. The RelayState is limited to 80 bytes, which is too
+// short for the state.
+//
+// http://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf (3.4.3)
+func (a *Authenticate) setRelayState(w http.ResponseWriter, state string) string {
+	options := a.options.Load()
+	handle := base64.RawURLEncoding.EncodeToString(cryptutil.NewKey())
+	http.SetCookie(w, &http.Cookie{
+		Name:     relayStateCookieName(options.CookieName, handle),
+		Value:    state,
+		Path:     "/oauth2/callback",
+		MaxAge:   int(cryptutil.DefaultLeeway.Seconds()),
+		Secure:   options.CookieSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return handle
+}
+
+// popRelayState returns the state for the RelayState handle and clears its
+// cookie. An empty string is returned if there is no state for the handle.
+func (a *Authenticate) popRelayState(w http.ResponseWriter, r *http.Request, handle string) string {
+	options := a.options.Load()
+	name := relayStateCookieName(options.CookieName, handle)
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Path:     "/oauth2/callback",
+		MaxAge:   -1,
+		Secure:   options.CookieSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return cookie.Value
+}
+
+func relayStateCookieName(cookieName, handle string) string {
+	return fmt.Sprintf("%s_relay_state_%s", cookieName, handle)
+}
+
 // RetrieveSession is the middleware used retrieve session by the sessionLoaders
 func (a *Authenticate) RetrieveSession(next http.Handler) http.Handler {
 	return sessions.RetrieveSession(a.state.Load().sessionLoaders...)(next)
@@ -165,11 +290,19 @@ func (a *Authenticate) VerifySession(next http.Handler) http.Handler {
 		if state.dataBrokerClient == nil {
 			return errors.New("authenticate: databroker client cannot be nil")
 		}
-		if _, err = session.Get(ctx, state.dataBrokerClient, sessionState.ID); err != nil {
+		s, err := session.Get(ctx, state.dataBrokerClient, sessionState.ID)
+		if err != nil {
 			log.FromRequest(r).Info().Err(err).Str("id", sessionState.ID).Msg("authenticate: session not found in databroker")
 			return a.reauthenticateOrFail(w, r, err)
 		}
 
+		// the route may require signing in with another identity provider
+		if !isAcceptableIdentityProvider(r, s.GetIdentityProvider()) {
+			log.FromRequest(r).Info().Str("id", sessionState.ID).Str("idp", s.GetIdentityProvider()).
+				Msg("authenticate: session identity provider not acceptable")
+			return a.reauthenticateOrFail(w, r, errors.New("identity provider not acceptable"))
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 		return nil
 	})
@@ -256,7 +389,7 @@ func (a *Authenticate) SignOut(w http.ResponseWriter, r *http.Request) error {
 	ctx, span := trace.StartSpan(r.Context(), "authenticate.SignOut")
 	defer span.End()
 
-	rawIDToken := a.revokeSession(ctx, w, r)
+	rawIDToken, identityProvider := a.revokeSession(ctx, w, r)
 
 	redirectString := ""
 	signOutURL, err := a.options.Load().GetSignOutRedirectURL()
@@ -270,7 +403,11 @@ func (a *Authenticate) SignOut(w http.ResponseWriter, r *http.Request) error {
 		redirectString = uri
 	}
 
-	endSessionURL, err := a.provider.Load().LogOut()
+	var endSessionURL *url.URL
+	provider, err := a.getProvider(identityProvider)
+	if err == nil {
+		endSessionURL, err = provider.LogOut()
+	}
 	if err == nil && redirectString != "" {
 		params := url.Values{}
 		params.Add("id_token_hint", rawIDToken)
@@ -290,7 +427,8 @@ func (a *Authenticate) SignOut(w http.ResponseWriter, r *http.Request) error {
 // reauthenticateOrFail starts the authenticate process by redirecting the
 // user to their respective identity provider. This function also builds the
 // 'state' parameter which is encrypted and includes authenticating data
-// for validation.
+// for validation. If the user can sign in with more than one identity
+// provider, a page to choose one is rendered instead.
 // If the request is a `xhr/ajax` request (e.g the `X-Requested-With` header)
 // is set do not redirect but instead return 401 unauthorized.
 //
@@ -305,14 +443,33 @@ func (a *Authenticate) reauthenticateOrFail(w http.ResponseWriter, r *http.Reque
 		return httputil.NewError(http.StatusUnauthorized, err)
 	}
 	state.sessionStore.ClearSession(w, r)
+
+	idps := a.getAcceptableIdentityProviders(r)
+	switch len(idps) {
+	case 0:
+		return httputil.NewError(http.StatusBadRequest, errors.New("no acceptable identity provider"))
+	case 1:
+	default:
+		return a.selectIdentityProvider(w, r, idps)
+	}
+	provider, err := a.getProvider(idps[0].Name)
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+
 	redirectURL := state.redirectURL.ResolveReference(r.URL)
 	nonce := csrf.Token(r)
 	now := time.Now().Unix()
-	b := []byte(fmt.Sprintf("%s|%d|", nonce, now))
-	enc := cryptutil.Encrypt(state.cookieCipher, []byte(redirectURL.String()), b)
+	// the code verifier is kept secret in the encrypted part of the state until the code is exchanged
+	codeVerifier := base64.RawURLEncoding.EncodeToString(cryptutil.NewKey())
+	b := []byte(fmt.Sprintf("%s|%d|%s|", nonce, now, idps[0].Name))
+	enc := cryptutil.Encrypt(state.cookieCipher, []byte(codeVerifier+"|"+redirectURL.String()), b)
 	b = append(b, enc...)
 	encodedState := base64.URLEncoding.EncodeToString(b)
-	signinURL, err := a.provider.Load().GetSignInURL(encodedState)
+	if provider.Name() == saml.Name {
+		encodedState = a.setRelayState(w, encodedState)
+	}
+	signinURL, err := provider.GetSignInURL(encodedState, codeVerifier)
 	if err != nil {
 		return httputil.NewError(http.StatusInternalServerError,
 			fmt.Errorf("failed to get sign in url: %w", err))
@@ -355,31 +512,26 @@ func (a *Authenticate) getOAuthCallback(w http.ResponseWriter, r *http.Request)
 	if idpError := r.FormValue("error"); idpError != "" {
 		return nil, httputil.NewError(a.statusForErrorCode(idpError), fmt.Errorf("identity provider: %v", idpError))
 	}
-	// fail if no session redemption code is returned
+	// fail if no session redemption code is returned, identity providers which
+	// use the HTTP-POST binding return a SAMLResponse instead
 	code := r.FormValue("code")
 	if code == "" {
-		return nil, httputil.NewError(http.StatusBadRequest, fmt.Errorf("identity provider returned empty code"))
+		code = r.PostFormValue("SAMLResponse")
 	}
-
-	// Successful Authentication Response: rfc6749#section-4.1.2 & OIDC#3.1.2.5
-	//
-	// Exchange the supplied Authorization Code for a valid user session.
-	var claims identity.SessionClaims
-	accessToken, err := a.provider.Load().Authenticate(ctx, code, &claims)
-	if err != nil {
-		return nil, fmt.Errorf("error redeeming authenticate code: %w", err)
+	if code == "" {
+		return nil, httputil.NewError(http.StatusBadRequest, fmt.Errorf("identity provider returned empty code"))
 	}
 
-	// state includes a csrf nonce (validated by middleware) and redirect uri
+	// state includes a csrf nonce (validated by middleware), the identity provider and redirect uri
 	bytes, err := base64.URLEncoding.DecodeString(r.FormValue("state"))
 	if err != nil {
 		return nil, httputil.NewError(http.StatusBadRequest, fmt.Errorf("bad bytes: %w", err))
 	}
 
 	// split state into concat'd components
-	// (nonce|timestamp|redirect_url|encrypted_data(redirect_url)+mac(nonce,ts))
-	statePayload := strings.SplitN(string(bytes), "|", 3)
-	if len(statePayload) != 3 {
+	// (nonce|timestamp|identity_provider|encrypted_data(code_verifier|redirect_url)+mac(nonce,ts,identity_provider))
+	statePayload := strings.SplitN(string(bytes), "|", 4)
+	if len(statePayload) != 4 {
 		return nil, httputil.NewError(http.StatusBadRequest, fmt.Errorf("state malformed, size: %d", len(statePayload)))
 	}
 
@@ -389,19 +541,39 @@ func (a *Authenticate) getOAuthCallback(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Use our AEAD construct to enforce secrecy and authenticity:
-	// mac: to validate the nonce again, and above timestamp
-	// decrypt: to prevent leaking 'redirect_uri' to IdP or logs
-	b := []byte(fmt.Sprint(statePayload[0], "|", statePayload[1], "|"))
-	redirectString, err := cryptutil.Decrypt(state.cookieCipher, []byte(statePayload[2]), b)
+	// mac: to validate the nonce again, and above timestamp and identity provider
+	// decrypt: to prevent leaking 'code_verifier' and 'redirect_uri' to IdP or logs
+	b := []byte(fmt.Sprint(statePayload[0], "|", statePayload[1], "|", statePayload[2], "|"))
+	decrypted, err := cryptutil.Decrypt(state.cookieCipher, []byte(statePayload[3]), b)
+	if err != nil {
+		return nil, httputil.NewError(http.StatusBadRequest, err)
+	}
+	encryptedPayload := strings.SplitN(string(decrypted), "|", 2)
+	if len(encryptedPayload) != 2 {
+		return nil, httputil.NewError(http.StatusBadRequest, fmt.Errorf("state malformed, size: %d", len(encryptedPayload)))
+	}
+	codeVerifier := encryptedPayload[0]
+
+	redirectURL, err := urlutil.ParseAndValidateURL(encryptedPayload[1])
 	if err != nil {
 		return nil, httputil.NewError(http.StatusBadRequest, err)
 	}
 
-	redirectURL, err := urlutil.ParseAndValidateURL(string(redirectString))
+	identityProvider := statePayload[2]
+	provider, err := a.getProvider(identityProvider)
 	if err != nil {
 		return nil, httputil.NewError(http.StatusBadRequest, err)
 	}
 
+	// Successful Authentication Response: rfc6749#section-4.1.2 & OIDC#3.1.2.5
+	//
+	// Exchange the supplied Authorization Code for a valid user session.
+	var claims identity.SessionClaims
+	accessToken, err := provider.Authenticate(ctx, code, codeVerifier, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("error redeeming authenticate code: %w", err)
+	}
+
 	s := sessions.State{ID: uuid.New().String()}
 	err = claims.Claims.Claims(&s)
 	if err != nil {
@@ -418,7 +590,7 @@ func (a *Authenticate) getOAuthCallback(w http.ResponseWriter, r *http.Request)
 	}
 
 	// save the session and access token to the databroker
-	err = a.saveSessionToDataBroker(ctx, &newState, claims, accessToken)
+	err = a.saveSessionToDataBroker(ctx, identityProvider, provider, &newState, claims, accessToken)
 	if err != nil {
 		return nil, httputil.NewError(http.StatusInternalServerError, err)
 	}
@@ -550,6 +722,8 @@ func (a *Authenticate) userInfo(w http.ResponseWriter, r *http.Request) error {
 
 func (a *Authenticate) saveSessionToDataBroker(
 	ctx context.Context,
+	identityProvider string,
+	provider identity.Authenticator,
 	sessionState *sessions.State,
 	claims identity.SessionClaims,
 	accessToken *oauth2.Token,
@@ -563,7 +737,7 @@ func (a *Authenticate) saveSessionToDataBroker(
 
 	s := &session.Session{
 		Id:        sessionState.ID,
-		UserId:    sessionState.UserID(a.provider.Load().Name()),
+		UserId:    getUserID(identityProvider, provider, sessionState),
 		IssuedAt:  timestamppb.Now(),
 		ExpiresAt: sessionExpiry,
 		IdToken: &session.IDToken{
@@ -572,8 +746,9 @@ func (a *Authenticate) saveSessionToDataBroker(
 			ExpiresAt: sessionExpiry,
 			IssuedAt:  idTokenIssuedAt,
 		},
-		OauthToken: manager.ToOAuthToken(accessToken),
-		Audience:   sessionState.Audience,
+		OauthToken:       manager.ToOAuthToken(accessToken),
+		Audience:         sessionState.Audience,
+		IdentityProvider: identityProvider,
 	}
 	s.SetRawIDToken(claims.RawIDToken)
 	s.AddClaims(claims.Flatten())
@@ -586,7 +761,7 @@ func (a *Authenticate) saveSessionToDataBroker(
 			Id: s.GetUserId(),
 		}
 	}
-	err := a.provider.Load().UpdateUserInfo(ctx, accessToken, &managerUser)
+	err := provider.UpdateUserInfo(ctx, accessToken, &managerUser)
 	if err != nil {
 		return fmt.Errorf("authenticate: error retrieving user info: %w", err)
 	}
@@ -601,6 +776,11 @@ func (a *Authenticate) saveSessionToDataBroker(
 	}
 	sessionState.Version = sessions.Version(fmt.Sprint(res.GetServerVersion()))
 
+	// the directory only contains the users of the default identity provider
+	if !isDefaultIdentityProvider(identityProvider) {
+		return nil
+	}
+
 	_, err = state.directoryClient.RefreshUser(ctx, &directory.RefreshUserRequest{
 		UserId:      s.UserId,
 		AccessToken: accessToken.AccessToken,
@@ -613,22 +793,24 @@ func (a *Authenticate) saveSessionToDataBroker(
 }
 
 // revokeSession always clears the local session and tries to revoke the associated session stored in the
-// databroker. If successful, it returns the original `id_token` of the session, if failed, returns
-// and empty string.
-func (a *Authenticate) revokeSession(ctx context.Context, w http.ResponseWriter, r *http.Request) string {
+// databroker. If successful, it returns the original `id_token` of the session and the identity provider the
+// session was created with, if failed, returns empty strings.
+func (a *Authenticate) revokeSession(ctx context.Context, w http.ResponseWriter, r *http.Request) (rawIDToken, identityProvider string) {
 	state := a.state.Load()
 	// clear the user's local session no matter what
 	defer state.sessionStore.ClearSession(w, r)
 
-	var rawIDToken string
 	sessionState, err := a.getSessionFromCtx(ctx)
 	if err != nil {
-		return rawIDToken
+		return rawIDToken, identityProvider
 	}
 
 	if s, _ := session.Get(ctx, state.dataBrokerClient, sessionState.ID); s != nil && s.OauthToken != nil {
 		rawIDToken = s.GetIdToken().GetRaw()
-		if err := a.provider.Load().Revoke(ctx, manager.FromOAuthToken(s.OauthToken)); err != nil {
+		identityProvider = s.GetIdentityProvider()
+		if provider, err := a.getProvider(identityProvider); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("authenticate: failed to revoke access token")
+		} else if err := provider.Revoke(ctx, manager.FromOAuthToken(s.OauthToken)); err != nil {
 			log.Ctx(ctx).Warn().Err(err).Msg("authenticate: failed to revoke access token")
 		}
 	}
@@ -636,7 +818,7 @@ func (a *Authenticate) revokeSession(ctx context.Context, w http.ResponseWriter,
 		log.Ctx(ctx).Warn().Err(err).Msg("authenticate: failed to delete session from session store")
 	}
 
-	return rawIDToken
+	return rawIDToken, identityProvider
 }
 
 func (a *Authenticate) getCurrentSession(ctx context.Context) (s *session.Session, isImpersonated bool, err error) {
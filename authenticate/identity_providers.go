@@ -0,0 +1,128 @@
+package authenticate
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/middleware"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+// atomicIdentityProviders stores the authenticators of the named identity providers.
+type atomicIdentityProviders struct {
+	atomic.Value
+}
+
+func newAtomicIdentityProviders() *atomicIdentityProviders {
+	aip := new(atomicIdentityProviders)
+	aip.Store(map[string]identity.Authenticator{})
+	return aip
+}
+
+func (aip *atomicIdentityProviders) Load() map[string]identity.Authenticator {
+	if aip == nil {
+		return nil
+	}
+	return aip.Value.Load().(map[string]identity.Authenticator)
+}
+
+func (aip *atomicIdentityProviders) Store(providers map[string]identity.Authenticator) {
+	aip.Value.Store(providers)
+}
+
+// getProvider returns the authenticator for the named identity provider. An empty name is the default identity
+// provider.
+func (a *Authenticate) getProvider(name string) (identity.Authenticator, error) {
+	if isDefaultIdentityProvider(name) {
+		return a.provider.Load(), nil
+	}
+	provider, ok := a.identityProviders.Load()[name]
+	if !ok {
+		return nil, fmt.Errorf("authenticate: unknown identity provider: %s", name)
+	}
+	return provider, nil
+}
+
+func isDefaultIdentityProvider(name string) bool {
+	return name == "" || name == config.DefaultIdentityProviderName
+}
+
+// getUserID returns the id of the user signed in with the named identity provider. Different identity providers may
+// use the same subjects for different users, so the ids of users of named identity providers are prefixed with the
+// name of the identity provider, e.g. `contractors/USER_ID`.
+func getUserID(name string, provider identity.Authenticator, s *sessions.State) string {
+	userID := s.UserID(provider.Name())
+	if isDefaultIdentityProvider(name) {
+		return userID
+	}
+	return name + "/" + userID
+}
+
+// getAcceptableIdentityProviders returns the identity providers the user can sign in with. Routes which restrict
+// identity providers list the acceptable ones in the sign in URL.
+func (a *Authenticate) getAcceptableIdentityProviders(r *http.Request) []config.IdentityProvider {
+	var idps []config.IdentityProvider
+	for _, idp := range a.options.Load().GetIdentityProviders() {
+		if isAcceptableIdentityProvider(r, idp.Name) {
+			idps = append(idps, idp)
+		}
+	}
+	return idps
+}
+
+// isAcceptableIdentityProvider returns true if a session created with the named identity provider can be used for
+// the request.
+func isAcceptableIdentityProvider(r *http.Request, name string) bool {
+	acceptable := r.URL.Query()[urlutil.QueryIdentityProvider]
+	if len(acceptable) == 0 {
+		return true
+	}
+	if name == "" {
+		name = config.DefaultIdentityProviderName
+	}
+	for _, idp := range acceptable {
+		if idp == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selectIdentityProvider renders a page which lets the user choose one of the identity providers to sign in with.
+// Each choice links back to the current URL restricted to that identity provider. The links are only signed if the
+// current URL was.
+func (a *Authenticate) selectIdentityProvider(w http.ResponseWriter, r *http.Request, idps []config.IdentityProvider) error {
+	state := a.state.Load()
+
+	isSigned := middleware.ValidateRequestURL(a.getExternalRequest(r), state.sharedKey) == nil
+
+	type identityProviderInfo struct {
+		DisplayName string
+		URL         string
+	}
+	var infos []identityProviderInfo
+	for _, idp := range idps {
+		u := state.redirectURL.ResolveReference(r.URL)
+		q := u.Query()
+		q.Del(urlutil.QueryHmacExpiry)
+		q.Del(urlutil.QueryHmacIssued)
+		q.Del(urlutil.QueryHmacSignature)
+		q.Set(urlutil.QueryIdentityProvider, idp.Name)
+		u.RawQuery = q.Encode()
+		if isSigned {
+			u = urlutil.NewSignedURL(state.sharedKey, u).Sign()
+		}
+		infos = append(infos, identityProviderInfo{
+			DisplayName: idp.GetDisplayName(),
+			URL:         u.String(),
+		})
+	}
+
+	return a.templates.ExecuteTemplate(w, "identity-providers.html", map[string]interface{}{
+		"IdentityProviders": infos,
+	})
+}
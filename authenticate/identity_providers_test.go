@@ -0,0 +1,196 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/sessions"
+	mstore "github.com/pomerium/pomerium/internal/sessions/mock"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+)
+
+func testAuthenticateWithIdentityProviders(t *testing.T) *Authenticate {
+	aead, err := chacha20poly1305.NewX(cryptutil.NewKey())
+	require.NoError(t, err)
+	signer, err := jws.NewHS256Signer(nil)
+	require.NoError(t, err)
+
+	auth := testAuthenticate()
+	state := auth.state.Load()
+	state.redirectURL = mustParseURL("https://authenticate.example.com")
+	state.sharedKey = cryptutil.NewKey()
+	state.cookieCipher = aead
+	state.encryptedEncoder = signer
+	state.sessionStore = &mstore.Store{}
+	auth.options.Store(&config.Options{
+		Provider: "okta",
+		IdentityProviders: []config.IdentityProvider{
+			{Name: "contractors", DisplayName: "Contractors", Provider: "github"},
+		},
+	})
+	auth.provider = identity.NewAtomicAuthenticator()
+	auth.provider.Store(identity.MockProvider{
+		GetSignInURLResponse: "https://okta.example.com/authorize",
+		AuthenticateError:    errors.New("wrong identity provider"),
+	})
+	auth.identityProviders = newAtomicIdentityProviders()
+	auth.identityProviders.Store(map[string]identity.Authenticator{
+		"contractors": identity.MockProvider{
+			GetSignInURLResponse: "https://github.com/login/oauth/authorize",
+		},
+	})
+	return auth
+}
+
+func TestAuthenticate_reauthenticateOrFail_identityProviders(t *testing.T) {
+	auth := testAuthenticateWithIdentityProviders(t)
+	sharedKey := auth.state.Load().sharedKey
+
+	signInURL := func(idps ...string) string {
+		u := mustParseURL("https://authenticate.example.com/.pomerium/sign_in")
+		q := u.Query()
+		q.Set(urlutil.QueryRedirectURI, "https://from.example.com")
+		for _, idp := range idps {
+			q.Add(urlutil.QueryIdentityProvider, idp)
+		}
+		u.RawQuery = q.Encode()
+		return urlutil.NewSignedURL(sharedKey, u).String()
+	}
+	reauthenticate := func(rawURL string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, rawURL, nil)
+		w := httptest.NewRecorder()
+		_ = auth.reauthenticateOrFail(w, r, errors.New("no session"))
+		return w
+	}
+
+	t.Run("select", func(t *testing.T) {
+		w := reauthenticate(signInURL())
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), ">okta</a>")
+		assert.Contains(t, w.Body.String(), ">Contractors</a>")
+
+		links := regexp.MustCompile(`class="button" href="([^"]*)"`).FindAllStringSubmatch(w.Body.String(), -1)
+		require.Len(t, links, 2)
+		for i, idp := range []string{config.DefaultIdentityProviderName, "contractors"} {
+			link, err := url.Parse(html.UnescapeString(links[i][1]))
+			require.NoError(t, err)
+			assert.Equal(t, []string{idp}, link.Query()[urlutil.QueryIdentityProvider])
+			assert.NoError(t, urlutil.NewSignedURL(sharedKey, link).Validate(), "links should be signed")
+		}
+	})
+	t.Run("restricted", func(t *testing.T) {
+		w := reauthenticate(signInURL("contractors"))
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://github.com/login/oauth/authorize", w.Header().Get("Location"))
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		w := reauthenticate("https://authenticate.example.com/.pomerium/")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), urlutil.QueryHmacSignature, "links should not be signed")
+	})
+}
+
+func TestAuthenticate_OAuthCallback_identityProvider(t *testing.T) {
+	auth := testAuthenticateWithIdentityProviders(t)
+
+	var saved *session.Session
+	auth.state.Load().directoryClient = new(mockDirectoryServiceClient)
+	auth.state.Load().dataBrokerClient = mockDataBrokerServiceClient{
+		get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		put: func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
+			var s session.Session
+			if in.GetRecord().GetData().MessageIs(&s) {
+				require.NoError(t, in.GetRecord().GetData().UnmarshalTo(&s))
+				saved = &s
+			}
+			return nil, nil
+		},
+	}
+
+	callback := func(identityProvider string) *httptest.ResponseRecorder {
+		b := []byte(fmt.Sprintf("%s|%d|%s|", cryptutil.NewBase64Key(), time.Now().Unix(), identityProvider))
+		b = append(b, cryptutil.Encrypt(auth.state.Load().cookieCipher, []byte("CODE_VERIFIER|https://from.example.com"), b)...)
+		q := url.Values{
+			"code":  {"CODE"},
+			"state": {base64.URLEncoding.EncodeToString(b)},
+		}
+		r := httptest.NewRequest(http.MethodGet, "/oauth2/callback?"+q.Encode(), nil)
+		w := httptest.NewRecorder()
+		httputil.HandlerFunc(auth.OAuthCallback).ServeHTTP(w, r)
+		return w
+	}
+
+	w := callback("contractors")
+	assert.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	require.NotNil(t, saved)
+	assert.Equal(t, "contractors", saved.GetIdentityProvider())
+
+	w = callback(config.DefaultIdentityProviderName)
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "the default identity provider should be used")
+
+	w = callback("unknown")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAuthenticate_saveSessionToDataBroker_identityProviders(t *testing.T) {
+	auth := testAuthenticateWithIdentityProviders(t)
+	client := &versionedDataBrokerServiceClient{records: map[string]*databroker.Record{}}
+	var refreshed []string
+	auth.state.Load().dataBrokerClient = client
+	auth.state.Load().directoryClient = mockDirectoryServiceClient{
+		refreshUser: func(ctx context.Context, in *directory.RefreshUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+			refreshed = append(refreshed, in.GetUserId())
+			return new(empty.Empty), nil
+		},
+	}
+
+	// both identity providers return the same subject for different users
+	for _, tc := range []struct {
+		identityProvider, sessionID, expectUserID string
+	}{
+		{config.DefaultIdentityProviderName, "SESSION1", "USER_ID"},
+		{"contractors", "SESSION2", "contractors/USER_ID"},
+	} {
+		provider, err := auth.getProvider(tc.identityProvider)
+		require.NoError(t, err)
+		err = auth.saveSessionToDataBroker(context.Background(), tc.identityProvider, provider,
+			&sessions.State{ID: tc.sessionID, Subject: "USER_ID"}, identity.SessionClaims{}, new(oauth2.Token))
+		require.NoError(t, err)
+
+		s, err := session.Get(context.Background(), client, tc.sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectUserID, s.GetUserId())
+
+		u, err := user.Get(context.Background(), client, tc.expectUserID)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectUserID, u.GetId())
+	}
+	assert.Equal(t, []string{"USER_ID"}, refreshed, "only users of the default identity provider are in the directory")
+}
@@ -0,0 +1,157 @@
+package authenticate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+// OpenID Connect logout initiated by the identity provider
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html
+// https://openid.net/specs/openid-connect-frontchannel-1_0.html
+const (
+	backChannelLogoutPath  = "/oauth2/backchannel_logout"
+	frontChannelLogoutPath = "/oauth2/frontchannel_logout"
+)
+
+// backChannelLogout handles a logout token posted by the identity provider, and deletes the matching sessions.
+// Named identity providers are selected with the idp query parameter.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRequest
+func (a *Authenticate) backChannelLogout(w http.ResponseWriter, r *http.Request) error {
+	ctx, span := trace.StartSpan(r.Context(), "authenticate.backChannelLogout")
+	defer span.End()
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	provider, err := a.getProvider(r.FormValue("idp"))
+	if err != nil {
+		return httputil.NewError(http.StatusNotFound, err)
+	}
+
+	rawLogoutToken := r.PostFormValue("logout_token")
+	if rawLogoutToken == "" {
+		renderOAuthError(w, "invalid_request", "logout_token is required")
+		return nil
+	}
+
+	token, ok, err := identity.VerifyLogoutToken(ctx, provider, rawLogoutToken)
+	if !ok {
+		return httputil.NewError(http.StatusNotFound, errors.New("identity provider does not support back-channel logout"))
+	} else if err != nil {
+		log.FromRequest(r).Info().Err(err).Msg("authenticate: invalid logout token")
+		renderOAuthError(w, "invalid_request", err.Error())
+		return nil
+	}
+
+	err = a.deleteLogoutSessions(ctx, token.Issuer, token.Subject, token.SessionID)
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// frontChannelLogout is rendered by the identity provider in an iframe when the user signs out. The request isn't
+// authenticated, so only the browser's own session is deleted, and only if it matches the issuer and session id.
+//
+// https://openid.net/specs/openid-connect-frontchannel-1_0.html#RPLogout
+func (a *Authenticate) frontChannelLogout(w http.ResponseWriter, r *http.Request) error {
+	ctx, span := trace.StartSpan(r.Context(), "authenticate.frontChannelLogout")
+	defer span.End()
+
+	state := a.state.Load()
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	issuer, sessionID := r.FormValue("iss"), r.FormValue("sid")
+	if issuer == "" || sessionID == "" {
+		return httputil.NewError(http.StatusBadRequest, errors.New("iss and sid are required"))
+	}
+
+	s, err := a.getSessionFromCtx(ctx)
+	if err != nil {
+		return a.templates.ExecuteTemplate(w, "frontchannel-logout.html", nil)
+	}
+
+	pbSession, err := session.Get(ctx, state.dataBrokerClient, s.ID)
+	if status.Code(err) == codes.NotFound {
+		return a.templates.ExecuteTemplate(w, "frontchannel-logout.html", nil)
+	} else if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+
+	if isLogoutSession(pbSession, issuer, sessionID) {
+		err = session.Delete(ctx, state.dataBrokerClient, pbSession.GetId())
+		if err != nil {
+			return httputil.NewError(http.StatusInternalServerError, err)
+		}
+		state.sessionStore.ClearSession(w, r)
+
+		log.Info(ctx).
+			Str("iss", issuer).
+			Str("sid", sessionID).
+			Msg("authenticate: logged out session")
+	}
+
+	return a.templates.ExecuteTemplate(w, "frontchannel-logout.html", nil)
+}
+
+// isLogoutSession returns true if the session was issued by the identity provider for its session id.
+func isLogoutSession(s *session.Session, issuer, sessionID string) bool {
+	if s.GetIdToken().GetIssuer() != issuer {
+		return false
+	}
+	for _, v := range s.GetClaims()["sid"].GetValues() {
+		if v.GetStringValue() == sessionID {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteLogoutSessions deletes the sessions issued by the identity provider for the subject and the identity
+// provider's session id. Either may be empty, but not both.
+func (a *Authenticate) deleteLogoutSessions(ctx context.Context, issuer, subject, sessionID string) error {
+	state := a.state.Load()
+
+	filter := "id_token.issuer = " + strconv.Quote(issuer)
+	if subject != "" {
+		filter += " and id_token.subject = " + strconv.Quote(subject)
+	}
+	if sessionID != "" {
+		filter += " and claims.sid = " + strconv.Quote(sessionID)
+	}
+
+	ss, err := session.Query(ctx, state.dataBrokerClient, filter)
+	if err != nil {
+		return fmt.Errorf("authenticate: error querying sessions for logout: %w", err)
+	}
+
+	for _, s := range ss {
+		if err := session.Delete(ctx, state.dataBrokerClient, s.GetId()); err != nil {
+			return fmt.Errorf("authenticate: error deleting session for logout: %w", err)
+		}
+	}
+
+	log.Info(ctx).
+		Str("iss", issuer).
+		Str("sub", subject).
+		Str("sid", sessionID).
+		Int("sessions", len(ss)).
+		Msg("authenticate: logged out sessions")
+	return nil
+}
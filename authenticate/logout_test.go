@@ -0,0 +1,185 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/internal/sessions"
+	mstore "github.com/pomerium/pomerium/internal/sessions/mock"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+// logoutTokenProvider verifies every logout token as the same token.
+type logoutTokenProvider struct {
+	identity.MockProvider
+	token *oidc.LogoutToken
+	err   error
+}
+
+func (p logoutTokenProvider) VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (*oidc.LogoutToken, error) {
+	return p.token, p.err
+}
+
+func TestAuthenticate_Logout(t *testing.T) {
+	signer, err := jws.NewHS256Signer(nil)
+	require.NoError(t, err)
+
+	a := testAuthenticate()
+	state := a.state.Load()
+	state.sharedEncoder = signer
+	client := &versionedDataBrokerServiceClient{records: map[string]*databroker.Record{}}
+	state.dataBrokerClient = client
+	a.provider = identity.NewAtomicAuthenticator()
+
+	putSessions := func(t *testing.T) {
+		for _, s := range []struct{ id, iss, sub, sid string }{
+			{"S1", "https://idp.example.com", "USER1", "IDP_SESSION1"},
+			{"S2", "https://idp.example.com", "USER1", "IDP_SESSION2"},
+			{"S3", "https://idp.example.com", "USER2", "IDP_SESSION3"},
+			{"S4", "https://other-idp.example.com", "USER1", "IDP_SESSION1"},
+		} {
+			pbSession := &session.Session{
+				Id:      s.id,
+				IdToken: &session.IDToken{Issuer: s.iss, Subject: s.sub},
+			}
+			pbSession.AddClaims(identity.Claims{"sid": s.sid}.Flatten())
+			_, err := session.Put(context.Background(), client, pbSession)
+			require.NoError(t, err)
+		}
+	}
+	sessionIDs := func() []string {
+		var ids []string
+		for _, record := range client.records {
+			ids = append(ids, record.GetId())
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	backChannelLogout := func(values url.Values) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "https://authenticate.example.com"+backChannelLogoutPath,
+			strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		httputil.HandlerFunc(a.backChannelLogout).ServeHTTP(w, r)
+		return w
+	}
+	frontChannelLogout := func(values url.Values, sessionID string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "https://authenticate.example.com"+frontChannelLogoutPath+"?"+values.Encode(), nil)
+		sessionJWT, err := signer.Marshal(&sessions.State{ID: sessionID})
+		require.NoError(t, err)
+		r = r.WithContext(sessions.NewContext(r.Context(), string(sessionJWT), nil))
+		w := httptest.NewRecorder()
+		httputil.HandlerFunc(a.frontChannelLogout).ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("back-channel session id", func(t *testing.T) {
+		putSessions(t)
+		a.provider.Store(logoutTokenProvider{token: &oidc.LogoutToken{
+			Issuer:    "https://idp.example.com",
+			Subject:   "USER1",
+			SessionID: "IDP_SESSION1",
+		}})
+
+		w := backChannelLogout(url.Values{"logout_token": {"LOGOUT_TOKEN"}})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.Equal(t, []string{"S2", "S3", "S4"}, sessionIDs())
+	})
+	t.Run("back-channel subject", func(t *testing.T) {
+		putSessions(t)
+		a.provider.Store(logoutTokenProvider{token: &oidc.LogoutToken{
+			Issuer:  "https://idp.example.com",
+			Subject: "USER1",
+		}})
+
+		w := backChannelLogout(url.Values{"logout_token": {"LOGOUT_TOKEN"}})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []string{"S3", "S4"}, sessionIDs())
+	})
+	t.Run("back-channel invalid logout token", func(t *testing.T) {
+		putSessions(t)
+		a.provider.Store(logoutTokenProvider{err: oidc.ErrInvalidLogoutToken})
+
+		w := backChannelLogout(url.Values{"logout_token": {"LOGOUT_TOKEN"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "invalid_request", res["error"])
+		assert.Equal(t, []string{"S1", "S2", "S3", "S4"}, sessionIDs())
+
+		w = backChannelLogout(url.Values{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("back-channel not supported", func(t *testing.T) {
+		a.provider.Store(identity.MockProvider{})
+
+		w := backChannelLogout(url.Values{"logout_token": {"LOGOUT_TOKEN"}})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("front-channel", func(t *testing.T) {
+		putSessions(t)
+		store := &mstore.Store{ResponseSession: "SESSION"}
+		state.sessionStore = store
+
+		w := frontChannelLogout(url.Values{"iss": {"https://idp.example.com"}, "sid": {"IDP_SESSION1"}}, "S2")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "You have been signed out")
+		assert.Equal(t, []string{"S1", "S2", "S3", "S4"}, sessionIDs(), "other sessions should not be deleted")
+		assert.Equal(t, "SESSION", store.ResponseSession)
+
+		w = frontChannelLogout(url.Values{"iss": {"https://other-idp.example.com"}, "sid": {"IDP_SESSION2"}}, "S2")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []string{"S1", "S2", "S3", "S4"}, sessionIDs(), "the issuer should match")
+
+		w = frontChannelLogout(url.Values{"iss": {"https://idp.example.com"}, "sid": {"IDP_SESSION2"}}, "S2")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []string{"S1", "S3", "S4"}, sessionIDs())
+		assert.Empty(t, store.ResponseSession, "the browser session should be cleared")
+
+		w = frontChannelLogout(url.Values{"iss": {"https://idp.example.com"}}, "S3")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{"S1", "S3", "S4"}, sessionIDs())
+	})
+	t.Run("front-channel without a session", func(t *testing.T) {
+		putSessions(t)
+
+		r := httptest.NewRequest(http.MethodGet, "https://authenticate.example.com"+frontChannelLogoutPath+"?"+
+			url.Values{"iss": {"https://idp.example.com"}, "sid": {"IDP_SESSION1"}}.Encode(), nil)
+		w := httptest.NewRecorder()
+		httputil.HandlerFunc(a.frontChannelLogout).ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "You have been signed out")
+		assert.Equal(t, []string{"S1", "S2", "S3", "S4"}, sessionIDs())
+
+		w = frontChannelLogout(url.Values{"iss": {"https://idp.example.com"}, "sid": {"IDP_SESSION1"}}, "UNKNOWN")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []string{"S1", "S2", "S3", "S4"}, sessionIDs())
+	})
+	t.Run("handler", func(t *testing.T) {
+		a.provider.Store(logoutTokenProvider{err: errors.New("invalid")})
+
+		r := httptest.NewRequest(http.MethodPost, "https://authenticate.example.com"+backChannelLogoutPath,
+			strings.NewReader(url.Values{"logout_token": {"LOGOUT_TOKEN"}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		a.Handler().ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "identity providers should not need a csrf token")
+		assert.Contains(t, w.Body.String(), "invalid_request")
+	})
+}
@@ -3,6 +3,8 @@ package authenticate
 import (
 	"net/http"
 
+	"github.com/pomerium/csrf"
+
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/middleware"
 	"github.com/pomerium/pomerium/internal/urlutil"
@@ -61,3 +63,19 @@ func (a *Authenticate) getExternalRequest(r *http.Request) *http.Request {
 	}
 	return er
 }
+
+// skipCSRFCheck skips the csrf check for endpoints which are called by devices or identity providers rather than
+// browsers, like the device authorization endpoints.
+func skipCSRFCheck(paths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range paths {
+				if r.URL.Path == path {
+					r = csrf.UnsafeSkipCheck(r)
+					break
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
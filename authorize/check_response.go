@@ -2,6 +2,7 @@ package authorize
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -65,7 +66,7 @@ func (a *Authorize) handleResultDenied(
 		denyStatusText = httputil.DetailsText(httputil.StatusInvalidClientCertificate)
 	}
 
-	return a.deniedResponse(ctx, in, denyStatusCode, denyStatusText, nil)
+	return a.explainedDeniedResponse(ctx, in, denyStatusCode, denyStatusText, getExplanationJSON(ctx, result))
 }
 
 func (a *Authorize) okResponse(headers http.Header) *envoy_service_auth_v3.CheckResponse {
@@ -91,6 +92,23 @@ func (a *Authorize) deniedResponse(
 	ctx context.Context,
 	in *envoy_service_auth_v3.CheckRequest,
 	code int32, reason string, headers map[string]string,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	return a.errorResponse(ctx, in, code, reason, headers, nil)
+}
+
+// explainedDeniedResponse is a denied response with an explanation of the policy decision on the error page.
+func (a *Authorize) explainedDeniedResponse(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	code int32, reason string, explanation json.RawMessage,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	return a.errorResponse(ctx, in, code, reason, nil, explanation)
+}
+
+func (a *Authorize) errorResponse(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	code int32, reason string, headers map[string]string, explanation json.RawMessage,
 ) (*envoy_service_auth_v3.CheckResponse, error) {
 	// create a http response writer recorder
 	w := httptest.NewRecorder()
@@ -101,10 +119,11 @@ func (a *Authorize) deniedResponse(
 
 	// run the request through our go error handler
 	httpErr := httputil.HTTPError{
-		Status:    int(code),
-		Err:       errors.New(reason),
-		DebugURL:  debugEndpoint,
-		RequestID: requestid.FromContext(ctx),
+		Status:      int(code),
+		Err:         errors.New(reason),
+		DebugURL:    debugEndpoint,
+		RequestID:   requestid.FromContext(ctx),
+		Explanation: explanation,
 	}
 	httpErr.ErrorResponse(w, r)
 
@@ -164,6 +183,12 @@ func (a *Authorize) requireLoginResponse(
 	checkRequestURL.Scheme = "https"
 
 	q.Set(urlutil.QueryRedirectURI, checkRequestURL.String())
+	if policy := a.getMatchingPolicy(checkRequestURL); policy != nil {
+		// let the authenticate service know which identity providers are acceptable for the route
+		for _, idp := range policy.AllowedIdentityProviders {
+			q.Add(urlutil.QueryIdentityProvider, idp)
+		}
+	}
 	signinURL.RawQuery = q.Encode()
 	redirectTo := urlutil.NewSignedURL(state.sharedKey, signinURL).String()
 
@@ -21,6 +21,7 @@ import (
 	"github.com/pomerium/pomerium/internal/encoding/jws"
 	"github.com/pomerium/pomerium/internal/frontend"
 	"github.com/pomerium/pomerium/internal/testutil"
+	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/grpc/session"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
 )
@@ -211,3 +212,43 @@ func TestRequireLogin(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, int(res.GetDeniedResponse().GetStatus().GetCode()))
 	})
 }
+
+func TestRequireLogin_identityProviders(t *testing.T) {
+	opt := config.NewDefaultOptions()
+	opt.AuthenticateURLString = "https://authenticate.example.com"
+	opt.DataBrokerURLString = "https://databroker.example.com"
+	opt.SharedKey = "E8wWIMnihUx+AUfRegAQDNs8eRb3UrB5G3zlJW9XJDM="
+	opt.Policies = []config.Policy{{
+		From:                     "https://from.example.com",
+		To:                       mustParseWeightedURLs(t, "https://to.example.com"),
+		AllowedIdentityProviders: []string{"default", "contractors"},
+	}}
+	require.NoError(t, opt.Policies[0].Validate())
+	a, err := New(&config.Config{Options: opt})
+	require.NoError(t, err)
+	a.currentOptions.Store(opt)
+
+	res, err := a.requireLoginResponse(context.Background(), &envoy_service_auth_v3.CheckRequest{
+		Attributes: &envoy_service_auth_v3.AttributeContext{
+			Request: &envoy_service_auth_v3.AttributeContext_Request{
+				Http: &envoy_service_auth_v3.AttributeContext_HttpRequest{
+					Scheme: "https",
+					Host:   "from.example.com",
+					Path:   "/",
+				},
+			},
+		},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, int(res.GetDeniedResponse().GetStatus().GetCode()))
+
+	var location *url.URL
+	for _, h := range res.GetDeniedResponse().GetHeaders() {
+		if h.GetHeader().GetKey() == "Location" {
+			location, err = url.Parse(h.GetHeader().GetValue())
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, location)
+	assert.Equal(t, []string{"default", "contractors"}, location.Query()[urlutil.QueryIdentityProvider])
+}
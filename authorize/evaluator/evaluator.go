@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-jose/go-jose/v3"
 	"github.com/open-policy-agent/opa/rego"
@@ -31,6 +32,10 @@ type Request struct {
 	Policy  *config.Policy
 	HTTP    RequestHTTP
 	Session RequestSession
+	// Now is the time of the request. If zero, the current time is used.
+	Now time.Time
+	// Explain indicates that the result should include an Explanation of the policy decision.
+	Explain bool
 }
 
 // RequestHTTP is the HTTP field in the request.
@@ -40,16 +45,24 @@ type RequestHTTP struct {
 	URL               string            `json:"url"`
 	Headers           map[string]string `json:"headers"`
 	ClientCertificate string            `json:"client_certificate"`
+	IP                string            `json:"ip"`
 }
 
 // NewRequestHTTP creates a new RequestHTTP.
-func NewRequestHTTP(method string, requestURL url.URL, headers map[string]string, rawClientCertificate string) RequestHTTP {
+func NewRequestHTTP(
+	method string,
+	requestURL url.URL,
+	headers map[string]string,
+	rawClientCertificate string,
+	ip string,
+) RequestHTTP {
 	return RequestHTTP{
 		Method:            method,
 		Path:              requestURL.Path,
 		URL:               requestURL.String(),
 		Headers:           headers,
 		ClientCertificate: rawClientCertificate,
+		IP:                ip,
 	}
 }
 
@@ -63,16 +76,21 @@ type Result struct {
 	Allow   RuleResult
 	Deny    RuleResult
 	Headers http.Header
+	// Explanation is only set if requested.
+	Explanation *Explanation
+	// Shadow is the result of the route's shadow policy. It is only set if the route has a shadow policy.
+	Shadow *PolicyResponse
 
 	DataBrokerServerVersion, DataBrokerRecordVersion uint64
 }
 
 // An Evaluator evaluates policies.
 type Evaluator struct {
-	store             *Store
-	policyEvaluators  map[uint64]*PolicyEvaluator
-	headersEvaluators *HeadersEvaluator
-	clientCA          []byte
+	store                  *Store
+	policyEvaluators       map[uint64]*PolicyEvaluator
+	shadowPolicyEvaluators map[uint64]*PolicyEvaluator
+	headersEvaluators      *HeadersEvaluator
+	clientCA               []byte
 }
 
 // New creates a new Evaluator.
@@ -92,6 +110,7 @@ func New(ctx context.Context, store *Store, options ...Option) (*Evaluator, erro
 	}
 
 	e.policyEvaluators = make(map[uint64]*PolicyEvaluator)
+	e.shadowPolicyEvaluators = make(map[uint64]*PolicyEvaluator)
 	for _, configPolicy := range cfg.policies {
 		id, err := configPolicy.RouteID()
 		if err != nil {
@@ -102,6 +121,17 @@ func New(ctx context.Context, store *Store, options ...Option) (*Evaluator, erro
 			return nil, err
 		}
 		e.policyEvaluators[id] = policyEvaluator
+
+		if configPolicy.ShadowPolicy != nil && configPolicy.ShadowPolicy.Policy != nil {
+			// the shadow policy is evaluated with the rest of the route's settings
+			shadowPolicy := configPolicy
+			shadowPolicy.Policy, shadowPolicy.ShadowPolicy = configPolicy.ShadowPolicy, nil
+			shadowPolicyEvaluator, err := NewPolicyEvaluator(ctx, store, &shadowPolicy)
+			if err != nil {
+				return nil, fmt.Errorf("authorize: error creating shadow policy evaluator: %w", err)
+			}
+			e.shadowPolicyEvaluators[id] = shadowPolicyEvaluator
+		}
 	}
 
 	e.clientCA = cfg.clientCA
@@ -138,15 +168,37 @@ func (e *Evaluator) Evaluate(ctx context.Context, req *Request) (*Result, error)
 		return nil, fmt.Errorf("authorize: error validating client certificate: %w", err)
 	}
 
-	policyOutput, err := policyEvaluator.Evaluate(ctx, &PolicyRequest{
+	// an unparseable certificate is treated like no certificate, as it can't match any client_certificate criterion
+	clientCertificate, err := getClientCertificateInfo(req.HTTP.ClientCertificate)
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("authorize: error parsing client certificate")
+	}
+
+	policyReq := &PolicyRequest{
 		HTTP:                     req.HTTP,
 		Session:                  req.Session,
 		IsValidClientCertificate: isValidClientCertificate,
-	})
+		ClientCertificate:        clientCertificate,
+		Now:                      req.Now,
+		Explain:                  req.Explain,
+	}
+	policyOutput, err := policyEvaluator.Evaluate(ctx, policyReq)
 	if err != nil {
 		return nil, err
 	}
 
+	var shadowOutput *PolicyResponse
+	if shadowPolicyEvaluator, ok := e.shadowPolicyEvaluators[id]; ok {
+		shadowReq := *policyReq
+		shadowReq.Explain = false
+		shadowOutput, err = shadowPolicyEvaluator.Evaluate(ctx, &shadowReq)
+		if err != nil {
+			// the shadow policy is never enforced, so an error only means there is nothing to compare
+			log.Warn(ctx).Err(err).Msg("authorize: error evaluating shadow policy")
+			shadowOutput = nil
+		}
+	}
+
 	headersReq := NewHeadersRequestFromPolicy(req.Policy)
 	headersReq.Session = req.Session
 	headersOutput, err := e.headersEvaluators.Evaluate(ctx, headersReq)
@@ -157,9 +209,11 @@ func (e *Evaluator) Evaluate(ctx context.Context, req *Request) (*Result, error)
 	carryOverJWTAssertion(headersOutput.Headers, req.HTTP.Headers)
 
 	res := &Result{
-		Allow:   policyOutput.Allow,
-		Deny:    policyOutput.Deny,
-		Headers: headersOutput.Headers,
+		Allow:       policyOutput.Allow,
+		Deny:        policyOutput.Deny,
+		Headers:     headersOutput.Headers,
+		Explanation: policyOutput.Explanation,
+		Shadow:      shadowOutput,
 	}
 	res.DataBrokerServerVersion, res.DataBrokerRecordVersion = e.store.GetDataBrokerVersions()
 	return res, nil
@@ -117,6 +117,54 @@ func TestEvaluator(t *testing.T) {
 				},
 			},
 		},
+		{
+			To: config.WeightedURLs{{URL: *mustParseURL("https://to12.example.com")}},
+			Policy: &config.PPLPolicy{
+				Policy: &parser.Policy{
+					Rules: []parser.Rule{{
+						Action: parser.ActionAllow,
+						Or: []parser.Criterion{{
+							Name: "client_certificate", Data: parser.Object{
+								"san_dns": parser.Object{
+									"has": parser.String("example-subject"),
+								},
+							},
+						}},
+					}},
+				},
+			},
+		},
+		{
+			To: config.WeightedURLs{{URL: *mustParseURL("https://to13.example.com")}},
+			Policy: &config.PPLPolicy{
+				Policy: &parser.Policy{
+					Rules: []parser.Rule{{
+						Action: parser.ActionAllow,
+						Or: []parser.Criterion{{
+							Name: "http_method", Data: parser.Object{
+								"is": parser.String("GET"),
+							},
+						}},
+					}},
+				},
+			},
+			ShadowPolicy: &config.PPLPolicy{
+				Policy: &parser.Policy{
+					Rules: []parser.Rule{{
+						Action: parser.ActionAllow,
+						And: []parser.Criterion{{
+							Name: "http_method", Data: parser.Object{
+								"is": parser.String("GET"),
+							},
+						}, {
+							Name: "http_path", Data: parser.Object{
+								"is": parser.String("/test"),
+							},
+						}},
+					}},
+				},
+			},
+		},
 	}
 	options := []Option{
 		WithAuthenticateURL("https://authn.example.com"),
@@ -481,6 +529,7 @@ func TestEvaluator(t *testing.T) {
 				*mustParseURL("https://from.example.com/"),
 				nil,
 				testValidCert,
+				"",
 			),
 		})
 		require.NoError(t, err)
@@ -494,11 +543,77 @@ func TestEvaluator(t *testing.T) {
 				*mustParseURL("https://from.example.com/test"),
 				nil,
 				testValidCert,
+				"",
 			),
 		})
 		require.NoError(t, err)
 		assert.True(t, res.Allow.Value)
 	})
+	t.Run("client certificate attributes", func(t *testing.T) {
+		res, err := eval(t, options, []proto.Message{}, &Request{
+			Policy: &policies[11],
+			HTTP: NewRequestHTTP(
+				"GET",
+				*mustParseURL("https://from.example.com/"),
+				nil,
+				testValidCert,
+				"",
+			),
+		})
+		require.NoError(t, err)
+		assert.True(t, res.Allow.Value)
+
+		res, err = eval(t, options, []proto.Message{}, &Request{
+			Policy: &policies[11],
+			HTTP: NewRequestHTTP(
+				"GET",
+				*mustParseURL("https://from.example.com/"),
+				nil,
+				testUnsignedCert,
+				"",
+			),
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Allow.Value, "should not allow a certificate which isn't signed by the CA")
+	})
+	t.Run("shadow policy", func(t *testing.T) {
+		for _, tc := range []struct {
+			path                string
+			expectShadowAllowed bool
+			expectShadowReasons []criteria.Reason
+		}{
+			{"/test", true, []criteria.Reason{criteria.ReasonHTTPMethodOK, criteria.ReasonHTTPPathOK}},
+			{"/other", false, []criteria.Reason{criteria.ReasonHTTPPathUnauthorized, criteria.ReasonNonPomeriumRoute}},
+		} {
+			res, err := eval(t, options, []proto.Message{}, &Request{
+				Policy: &policies[12],
+				HTTP: NewRequestHTTP(
+					"GET",
+					*mustParseURL("https://from.example.com" + tc.path),
+					nil,
+					testValidCert,
+					"",
+				),
+			})
+			require.NoError(t, err)
+			assert.True(t, res.Allow.Value, "the shadow policy should not be enforced")
+			require.NotNil(t, res.Shadow)
+			assert.Equal(t, NewRuleResult(tc.expectShadowAllowed, tc.expectShadowReasons...), res.Shadow.Allow)
+		}
+
+		res, err := eval(t, options, []proto.Message{}, &Request{
+			Policy: &policies[9],
+			HTTP: NewRequestHTTP(
+				"GET",
+				*mustParseURL("https://from.example.com/"),
+				nil,
+				testValidCert,
+				"",
+			),
+		})
+		require.NoError(t, err)
+		assert.Nil(t, res.Shadow, "routes without a shadow policy should have no shadow result")
+	})
 }
 
 func mustParseURL(str string) *url.URL {
@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"github.com/pomerium/pomerium/pkg/policy"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+// An Explanation describes how a policy decision was made: the result of every rule, logical operator and criterion
+// of the policy, as well as the result of any custom rego.
+type Explanation struct {
+	Allow RuleResult        `json:"allow"`
+	Deny  RuleResult        `json:"deny"`
+	Rules []RuleExplanation `json:"rules"`
+	Rego  []RegoExplanation `json:"rego,omitempty"`
+}
+
+// A RuleExplanation is the result of evaluating a policy rule. A rule matches if any of its operators match.
+type RuleExplanation struct {
+	Action parser.Action `json:"action"`
+	RuleResult
+	Operators []OperatorExplanation `json:"operators"`
+}
+
+// An OperatorExplanation is the result of evaluating a logical operator ("and", "or", "not" or "nor") of a policy
+// rule.
+type OperatorExplanation struct {
+	Operator string `json:"operator"`
+	RuleResult
+	Criteria []CriterionExplanation `json:"criteria"`
+}
+
+// A CriterionExplanation is the result of evaluating a criterion.
+type CriterionExplanation struct {
+	Criterion *parser.Criterion `json:"criterion"`
+	RuleResult
+}
+
+// A RegoExplanation is the result of evaluating a custom rego script.
+type RegoExplanation struct {
+	Checksum string     `json:"checksum"`
+	Allow    RuleResult `json:"allow"`
+	Deny     RuleResult `json:"deny"`
+}
+
+// Redacted returns a copy of the explanation without the data of each criterion, like the allowed users or domains,
+// so it can be shown to the users the policy applies to.
+func (e *Explanation) Redacted() *Explanation {
+	redacted := *e
+	redacted.Rules = make([]RuleExplanation, len(e.Rules))
+	for i, rule := range e.Rules {
+		rule.Operators = make([]OperatorExplanation, len(e.Rules[i].Operators))
+		for j, operator := range e.Rules[i].Operators {
+			operator.Criteria = make([]CriterionExplanation, len(e.Rules[i].Operators[j].Criteria))
+			for k, criterion := range e.Rules[i].Operators[j].Criteria {
+				criterion.Criterion = &parser.Criterion{
+					Name:    criterion.Criterion.Name,
+					SubPath: criterion.Criterion.SubPath,
+					Data:    parser.Null{},
+				}
+				operator.Criteria[k] = criterion
+			}
+			rule.Operators[j] = operator
+		}
+		redacted.Rules[i] = rule
+	}
+	return &redacted
+}
+
+// explainRules explains the rules of a policy using the results of every generated rego rule.
+func explainRules(tree *policy.Tree, results map[string]interface{}) []RuleExplanation {
+	explanations := make([]RuleExplanation, 0, len(tree.Rules))
+	for _, treeRule := range tree.Rules {
+		ruleExplanation := RuleExplanation{
+			Action:     treeRule.Action,
+			RuleResult: NewRuleResult(false),
+			Operators:  make([]OperatorExplanation, 0, len(treeRule.Operators)),
+		}
+		for _, treeOperator := range treeRule.Operators {
+			operatorExplanation := OperatorExplanation{
+				Operator:   treeOperator.Operator,
+				RuleResult: parseRuleResult(results[treeOperator.RuleName]),
+				Criteria:   make([]CriterionExplanation, 0, len(treeOperator.Criteria)),
+			}
+			for i := range treeOperator.Criteria {
+				operatorExplanation.Criteria = append(operatorExplanation.Criteria, CriterionExplanation{
+					Criterion:  &treeOperator.Criteria[i].Criterion,
+					RuleResult: parseRuleResult(results[treeOperator.Criteria[i].RuleName]),
+				})
+			}
+			ruleExplanation.RuleResult = MergeRuleResultsWithOr(ruleExplanation.RuleResult, operatorExplanation.RuleResult)
+			ruleExplanation.Operators = append(ruleExplanation.Operators, operatorExplanation)
+		}
+		explanations = append(explanations, ruleExplanation)
+	}
+	return explanations
+}
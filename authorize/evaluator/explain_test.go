@@ -0,0 +1,38 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+func TestExplanation_Redacted(t *testing.T) {
+	e := &Explanation{
+		Allow: NewRuleResult(false),
+		Rules: []RuleExplanation{{
+			Action:     parser.ActionAllow,
+			RuleResult: NewRuleResult(false),
+			Operators: []OperatorExplanation{{
+				Operator:   "or",
+				RuleResult: NewRuleResult(false),
+				Criteria: []CriterionExplanation{{
+					Criterion: &parser.Criterion{
+						Name:    "http_header",
+						SubPath: "X-Team",
+						Data:    parser.Object{"is": parser.String("admins")},
+					},
+					RuleResult: NewRuleResult(false),
+				}},
+			}},
+		}},
+	}
+
+	redacted := e.Redacted()
+	assert.Equal(t, &parser.Criterion{Name: "http_header", SubPath: "X-Team", Data: parser.Null{}},
+		redacted.Rules[0].Operators[0].Criteria[0].Criterion)
+	assert.Equal(t, "or", redacted.Rules[0].Operators[0].Operator)
+	assert.Equal(t, parser.Object{"is": parser.String("admins")}, e.Rules[0].Operators[0].Criteria[0].Criterion.Data,
+		"should not modify the explanation")
+}
@@ -2,7 +2,9 @@ package evaluator
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 
@@ -11,7 +13,10 @@ import (
 	"github.com/pomerium/pomerium/internal/log"
 )
 
-var isValidClientCertificateCache, _ = lru.New2Q(100)
+var (
+	isValidClientCertificateCache, _ = lru.New2Q(100)
+	clientCertificateInfoCache, _    = lru.New2Q(100)
+)
 
 func isValidClientCertificate(ca, cert string) (bool, error) {
 	// when ca is the empty string, client certificates are always accepted
@@ -54,6 +59,41 @@ func isValidClientCertificate(ca, cert string) (bool, error) {
 	return valid, nil
 }
 
+func getClientCertificateInfo(cert string) (ClientCertificateInfo, error) {
+	// when cert is the empty string, no client certificate was supplied
+	if cert == "" {
+		return ClientCertificateInfo{}, nil
+	}
+
+	value, ok := clientCertificateInfoCache.Get(cert)
+	if ok {
+		return value.(ClientCertificateInfo), nil
+	}
+
+	xcert, err := parseCertificate(cert)
+	if err != nil {
+		return ClientCertificateInfo{}, err
+	}
+
+	fingerprint := sha256.Sum256(xcert.Raw)
+	info := ClientCertificateInfo{
+		Presented:         true,
+		CommonName:        xcert.Subject.CommonName,
+		Issuer:            xcert.Issuer.String(),
+		SerialNumber:      xcert.SerialNumber.Text(16),
+		FingerprintSHA256: hex.EncodeToString(fingerprint[:]),
+		DNSNames:          xcert.DNSNames,
+		EmailAddresses:    xcert.EmailAddresses,
+	}
+	for _, u := range xcert.URIs {
+		info.URIs = append(info.URIs, u.String())
+	}
+
+	clientCertificateInfoCache.Add(cert, info)
+
+	return info, nil
+}
+
 func parseCertificate(pemStr string) (*x509.Certificate, error) {
 	block, _ := pem.Decode([]byte(pemStr))
 	if block == nil {
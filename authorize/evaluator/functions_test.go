@@ -120,3 +120,26 @@ func Test_isValidClientCertificate(t *testing.T) {
 		assert.False(t, valid, "should return false")
 	})
 }
+
+func Test_getClientCertificateInfo(t *testing.T) {
+	t.Run("no cert", func(t *testing.T) {
+		info, err := getClientCertificateInfo("")
+		assert.NoError(t, err, "should not return an error")
+		assert.False(t, info.Presented, "should not be presented")
+	})
+	t.Run("cert", func(t *testing.T) {
+		info, err := getClientCertificateInfo(testValidCert)
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, ClientCertificateInfo{
+			Presented:         true,
+			Issuer:            "CN=mkcert caleb@pop-os (Caleb Doxsey),OU=caleb@pop-os (Caleb Doxsey),O=mkcert development CA",
+			SerialNumber:      "1bf87d19f96920da8b2efe1375ef7e49",
+			FingerprintSHA256: "b88b1e6dfe21f2ce582a8b9e337eaf664d6d86f50f6b9340ce6dd9de7ed7e199",
+			DNSNames:          []string{"example-subject"},
+		}, info)
+	})
+	t.Run("not a cert", func(t *testing.T) {
+		_, err := getClientCertificateInfo("WHATEVER!")
+		assert.Error(t, err, "should return an error")
+	})
+}
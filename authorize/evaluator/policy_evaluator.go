@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/open-policy-agent/opa/rego"
 	octrace "go.opencensus.io/trace"
@@ -18,14 +19,39 @@ import (
 
 // PolicyRequest is the input to policy evaluation.
 type PolicyRequest struct {
-	HTTP                     RequestHTTP    `json:"http"`
-	Session                  RequestSession `json:"session"`
-	IsValidClientCertificate bool           `json:"is_valid_client_certificate"`
+	HTTP                     RequestHTTP           `json:"http"`
+	Session                  RequestSession        `json:"session"`
+	IsValidClientCertificate bool                  `json:"is_valid_client_certificate"`
+	ClientCertificate        ClientCertificateInfo `json:"client_certificate"`
+
+	// Now is the time the policy is evaluated at. If zero, the current time is used.
+	Now time.Time `json:"-"`
+	// Explain indicates that the response should include an Explanation of the policy decision.
+	Explain bool `json:"-"`
+}
+
+// ClientCertificateInfo contains the attributes of the client certificate used in policy evaluation.
+type ClientCertificateInfo struct {
+	// Presented is true if the client presented a certificate.
+	Presented bool `json:"presented"`
+	// CommonName is the common name of the subject.
+	CommonName string `json:"common_name"`
+	// Issuer is the distinguished name of the issuer, in RFC 2253 format.
+	Issuer string `json:"issuer"`
+	// SerialNumber is the serial number as lowercase hex.
+	SerialNumber string `json:"serial_number"`
+	// FingerprintSHA256 is the SHA-256 fingerprint of the DER encoded certificate as lowercase hex.
+	FingerprintSHA256 string   `json:"fingerprint_sha256"`
+	DNSNames          []string `json:"dns_names"`
+	EmailAddresses    []string `json:"email_addresses"`
+	URIs              []string `json:"uris"`
 }
 
 // PolicyResponse is the result of evaluating a policy.
 type PolicyResponse struct {
 	Allow, Deny RuleResult
+	// Explanation is only set if requested.
+	Explanation *Explanation
 }
 
 // NewPolicyResponse creates a new PolicyResponse.
@@ -38,9 +64,9 @@ func NewPolicyResponse() *PolicyResponse {
 
 // A RuleResult is the result of evaluating a rule.
 type RuleResult struct {
-	Value          bool
-	Reasons        criteria.Reasons
-	AdditionalData map[string]interface{}
+	Value          bool                   `json:"value"`
+	Reasons        criteria.Reasons       `json:"reasons"`
+	AdditionalData map[string]interface{} `json:"additional_data,omitempty"`
 }
 
 // NewRuleResult creates a new RuleResult.
@@ -89,6 +115,8 @@ func MergeRuleResultsWithOr(results ...RuleResult) RuleResult {
 type policyQuery struct {
 	rego.PreparedEvalQuery
 	checksum string
+	// tree is only set for the rego generated from PPL.
+	tree *policy.Tree
 }
 
 // A PolicyEvaluator evaluates policies.
@@ -102,7 +130,8 @@ func NewPolicyEvaluator(ctx context.Context, store *Store, configPolicy *config.
 
 	// generate the base rego script for the policy
 	ppl := configPolicy.ToPPL()
-	base, err := policy.GenerateRegoFromPolicy(ppl)
+	base, tree, err := policy.GenerateRegoAndTreeFromPolicy(ppl,
+		policy.WithNamedPolicies(configPolicy.GetNamedPolicies()))
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +150,7 @@ func NewPolicyEvaluator(ctx context.Context, store *Store, configPolicy *config.
 	}
 
 	// for each script, create a rego and prepare a query.
-	for _, script := range scripts {
+	for i, script := range scripts {
 		log.Debug(ctx).
 			Str("script", script).
 			Str("from", configPolicy.From).
@@ -152,10 +181,14 @@ func NewPolicyEvaluator(ctx context.Context, store *Store, configPolicy *config.
 			return nil, err
 		}
 
-		e.queries = append(e.queries, policyQuery{
+		pq := policyQuery{
 			PreparedEvalQuery: q,
 			checksum:          fmt.Sprintf("%x", cryptutil.Hash("script", []byte(script))),
-		})
+		}
+		if i == 0 {
+			pq.tree = tree
+		}
+		e.queries = append(e.queries, pq)
 	}
 
 	return e, nil
@@ -163,7 +196,17 @@ func NewPolicyEvaluator(ctx context.Context, store *Store, configPolicy *config.
 
 // Evaluate evaluates the policy rego scripts.
 func (e *PolicyEvaluator) Evaluate(ctx context.Context, req *PolicyRequest) (*PolicyResponse, error) {
+	// evaluate every query at the same time
+	if req.Now.IsZero() {
+		withNow := *req
+		withNow.Now = time.Now()
+		req = &withNow
+	}
+
 	res := NewPolicyResponse()
+	if req.Explain {
+		res.Explanation = new(Explanation)
+	}
 	// run each query and merge the results
 	for _, query := range e.queries {
 		o, err := e.evaluateQuery(ctx, req, query)
@@ -172,6 +215,13 @@ func (e *PolicyEvaluator) Evaluate(ctx context.Context, req *PolicyRequest) (*Po
 		}
 		res.Allow = MergeRuleResultsWithOr(res.Allow, o.Allow)
 		res.Deny = MergeRuleResultsWithOr(res.Deny, o.Deny)
+		if res.Explanation != nil {
+			res.Explanation.Rules = append(res.Explanation.Rules, o.Explanation.Rules...)
+			res.Explanation.Rego = append(res.Explanation.Rego, o.Explanation.Rego...)
+		}
+	}
+	if res.Explanation != nil {
+		res.Explanation.Allow, res.Explanation.Deny = res.Allow, res.Deny
 	}
 	return res, nil
 }
@@ -181,7 +231,7 @@ func (e *PolicyEvaluator) evaluateQuery(ctx context.Context, req *PolicyRequest,
 	defer span.End()
 	span.AddAttributes(octrace.StringAttribute("script_checksum", query.checksum))
 
-	rs, err := safeEval(ctx, query.PreparedEvalQuery, rego.EvalInput(req))
+	rs, err := safeEval(ctx, query.PreparedEvalQuery, rego.EvalInput(req), rego.EvalTime(req.Now))
 	if err != nil {
 		return nil, fmt.Errorf("authorize: error evaluating policy.rego: %w", err)
 	}
@@ -190,23 +240,31 @@ func (e *PolicyEvaluator) evaluateQuery(ctx context.Context, req *PolicyRequest,
 		return nil, fmt.Errorf("authorize: unexpected empty result from evaluating policy.rego")
 	}
 
+	m, _ := rs[0].Bindings["result"].(map[string]interface{})
 	res := &PolicyResponse{
-		Allow: e.getRuleResult("allow", rs[0].Bindings),
-		Deny:  e.getRuleResult("deny", rs[0].Bindings),
+		Allow: parseRuleResult(m["allow"]),
+		Deny:  parseRuleResult(m["deny"]),
+	}
+	if req.Explain {
+		res.Explanation = new(Explanation)
+		if query.tree != nil {
+			res.Explanation.Rules = explainRules(query.tree, m)
+		} else {
+			res.Explanation.Rego = []RegoExplanation{{
+				Checksum: query.checksum,
+				Allow:    res.Allow,
+				Deny:     res.Deny,
+			}}
+		}
 	}
 	return res, nil
 }
 
-// getRuleResult gets the rule result var. It expects a boolean, [boolean, []string] or [boolean, []string, object].
-func (e *PolicyEvaluator) getRuleResult(name string, vars rego.Vars) (result RuleResult) {
+// parseRuleResult parses the value of a rule. It expects a boolean, [boolean, []string] or [boolean, []string, object].
+func parseRuleResult(value interface{}) (result RuleResult) {
 	result = NewRuleResult(false)
 
-	m, ok := vars["result"].(map[string]interface{})
-	if !ok {
-		return result
-	}
-
-	switch t := m[name].(type) {
+	switch t := value.(type) {
 	case bool:
 		result.Value = t
 	case []interface{}:
@@ -5,6 +5,7 @@ import (
 	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,7 @@ import (
 	"github.com/pomerium/pomerium/pkg/grpc/user"
 	"github.com/pomerium/pomerium/pkg/policy"
 	"github.com/pomerium/pomerium/pkg/policy/criteria"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
 )
 
 func TestPolicyEvaluator(t *testing.T) {
@@ -195,5 +197,149 @@ func TestPolicyEvaluator(t *testing.T) {
 				Deny:  NewRuleResult(true, criteria.ReasonAccept, criteria.ReasonInvalidClientCertificate),
 			}, output)
 		})
+		t.Run("time", func(t *testing.T) {
+			rego, err := policy.GenerateRegoFromReader(strings.NewReader(`
+- allow:
+    and:
+      - date:
+          before: 2021-06-01T00:00:00Z
+      - day_of_week: mon-fri
+      - time_of_day:
+          timezone: America/New_York
+          between: ["9:00", "5:00PM"]
+`))
+			require.NoError(t, err)
+			p := &config.Policy{
+				From: "https://from.example.com",
+				To:   config.WeightedURLs{{URL: *mustParseURL("https://to.example.com")}},
+				SubPolicies: []config.SubPolicy{
+					{Rego: []string{rego}},
+				},
+			}
+			for _, tc := range []struct {
+				now    time.Time
+				expect RuleResult
+			}{
+				{
+					time.Date(2021, 5, 11, 14, 0, 0, 0, time.UTC),
+					NewRuleResult(true, criteria.ReasonDateOK, criteria.ReasonDayOfWeekOK, criteria.ReasonTimeOfDayOK),
+				},
+				{
+					time.Date(2021, 5, 11, 12, 0, 0, 0, time.UTC),
+					NewRuleResult(false, criteria.ReasonNonPomeriumRoute, criteria.ReasonTimeOfDayUnauthorized),
+				},
+				{
+					time.Date(2021, 5, 15, 14, 0, 0, 0, time.UTC),
+					NewRuleResult(false, criteria.ReasonDayOfWeekUnauthorized, criteria.ReasonNonPomeriumRoute),
+				},
+				{
+					time.Date(2021, 6, 1, 14, 0, 0, 0, time.UTC),
+					NewRuleResult(false, criteria.ReasonDateUnauthorized, criteria.ReasonNonPomeriumRoute),
+				},
+			} {
+				output, err := eval(t,
+					p,
+					[]proto.Message{s1, u1, s2, u2},
+					&PolicyRequest{
+						HTTP:    RequestHTTP{Method: "GET", URL: "https://from.example.com/path"},
+						Session: RequestSession{ID: "s1"},
+						Now:     tc.now,
+
+						IsValidClientCertificate: true,
+					})
+				require.NoError(t, err)
+				assert.Equal(t, tc.expect, output.Allow, "should evaluate the policy at %s", tc.now)
+			}
+		})
+		t.Run("policy ref", func(t *testing.T) {
+			users, err := parser.ParseYAML(strings.NewReader(`
+- allow:
+    or:
+      - email:
+          is: u1@example.com
+`))
+			require.NoError(t, err)
+			route, err := parser.ParseYAML(strings.NewReader(`
+- allow:
+    and:
+      - policy_ref: users
+`))
+			require.NoError(t, err)
+			p := &config.Policy{
+				From:   "https://from.example.com",
+				To:     config.WeightedURLs{{URL: *mustParseURL("https://to.example.com")}},
+				Policy: &config.PPLPolicy{Policy: route},
+			}
+			require.NoError(t, p.ResolvePolicyRefs(map[string]*config.PPLPolicy{
+				"users": {Policy: users},
+			}))
+			output, err := eval(t,
+				p,
+				[]proto.Message{s1, u1, s2, u2},
+				&PolicyRequest{
+					HTTP:    RequestHTTP{Method: "GET", URL: "https://from.example.com/path"},
+					Session: RequestSession{ID: "s1"},
+
+					IsValidClientCertificate: true,
+				})
+			require.NoError(t, err)
+			assert.Equal(t, NewRuleResult(true, criteria.ReasonEmailOK), output.Allow)
+		})
+	})
+	t.Run("explain", func(t *testing.T) {
+		p := &config.Policy{
+			From:         "https://from.example.com",
+			To:           config.WeightedURLs{{URL: *mustParseURL("https://to.example.com")}},
+			AllowedUsers: []string{"u1@example.com"},
+			SubPolicies: []config.SubPolicy{
+				{Rego: []string{`package pomerium.policy
+
+allow = [false, {"custom-rego"}]`}},
+			},
+		}
+		output, err := eval(t,
+			p,
+			[]proto.Message{s1, u1, s2, u2},
+			&PolicyRequest{
+				HTTP:    RequestHTTP{Method: "GET", URL: "https://from.example.com/path"},
+				Session: RequestSession{ID: "s2"},
+				Explain: true,
+
+				IsValidClientCertificate: true,
+			})
+		require.NoError(t, err)
+		require.NotNil(t, output.Explanation)
+		assert.Equal(t, output.Allow, output.Explanation.Allow)
+		assert.Equal(t, output.Deny, output.Explanation.Deny)
+
+		var emailCriteria []CriterionExplanation
+		for _, rule := range output.Explanation.Rules {
+			for _, operator := range rule.Operators {
+				for _, criterion := range operator.Criteria {
+					if criterion.Criterion.Name == "email" {
+						emailCriteria = append(emailCriteria, criterion)
+					}
+				}
+			}
+		}
+		if assert.Len(t, emailCriteria, 1) {
+			assert.Equal(t, NewRuleResult(false, criteria.ReasonEmailUnauthorized), emailCriteria[0].RuleResult)
+		}
+
+		if assert.Len(t, output.Explanation.Rego, 1) {
+			assert.Equal(t, NewRuleResult(false, "custom-rego"), output.Explanation.Rego[0].Allow)
+		}
+
+		output, err = eval(t,
+			p,
+			[]proto.Message{s1, u1, s2, u2},
+			&PolicyRequest{
+				HTTP:    RequestHTTP{Method: "GET", URL: "https://from.example.com/path"},
+				Session: RequestSession{ID: "s2"},
+
+				IsValidClientCertificate: true,
+			})
+		require.NoError(t, err)
+		assert.Nil(t, output.Explanation, "should only explain when requested")
 	})
 }
@@ -0,0 +1,112 @@
+package authorize
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/sessions"
+)
+
+// explainPath is the path of the endpoint which explains the policy decision for a request made by the current user.
+// The request to explain is given by the "method" and "path" query parameters, on the same host as the endpoint.
+const explainPath = "/.pomerium/explain"
+
+// explainResponse is the JSON returned by the explain endpoint.
+type explainResponse struct {
+	Method      string                 `json:"method"`
+	URL         string                 `json:"url"`
+	Allow       bool                   `json:"allow"`
+	Explanation *evaluator.Explanation `json:"explanation"`
+}
+
+func (a *Authorize) handleExplain(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	sessionState *sessions.State,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	// only the policy decisions of the current user are explained, so a session is required
+	if sessionState == nil {
+		return a.requireLoginResponse(ctx, in, false)
+	}
+
+	hreq := getHTTPRequestFromCheckRequest(in)
+	method := hreq.URL.Query().Get("method")
+	if method == "" {
+		method = http.MethodGet
+	}
+	requestURL := getCheckRequestURL(in)
+	requestURL.Path = hreq.URL.Query().Get("path")
+	if requestURL.Path == "" {
+		requestURL.Path = "/"
+	}
+	requestURL.RawPath, requestURL.RawQuery = "", ""
+
+	req, err := a.getEvaluatorRequestFromCheckRequest(in, sessionState)
+	if err != nil {
+		return nil, err
+	}
+	req.Policy = a.getMatchingPolicy(requestURL)
+	req.HTTP.Method = method
+	req.HTTP.Path = requestURL.Path
+	req.HTTP.URL = requestURL.String()
+	req.Explain = true
+
+	a.stateLock.RLock()
+	res, err := a.state.Load().evaluator.Evaluate(ctx, req)
+	a.stateLock.RUnlock()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("error during OPA evaluation")
+		return nil, err
+	}
+
+	body, err := json.Marshal(explainResponse{
+		Method:      method,
+		URL:         req.HTTP.URL,
+		Allow:       res.Allow.Value && !res.Deny.Value,
+		Explanation: res.Explanation.Redacted(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the explanation is returned directly to the client, so envoy treats it like a denied response
+	return &envoy_service_auth_v3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.PermissionDenied), Message: "Explain"},
+		HttpResponse: &envoy_service_auth_v3.CheckResponse_DeniedResponse{
+			DeniedResponse: &envoy_service_auth_v3.DeniedHttpResponse{
+				Status: &envoy_type_v3.HttpStatus{
+					Code: envoy_type_v3.StatusCode_OK,
+				},
+				Headers: toEnvoyHeaders(http.Header{
+					"Content-Type":                  {"application/json"},
+					httputil.HeaderPomeriumResponse: {"true"},
+				}),
+				Body: string(body),
+			},
+		},
+	}, nil
+}
+
+// getExplanationJSON returns the redacted explanation of a policy decision as indented JSON, for inclusion on the
+// error page.
+func getExplanationJSON(ctx context.Context, result *evaluator.Result) json.RawMessage {
+	if result == nil || result.Explanation == nil {
+		return nil
+	}
+
+	bs, err := json.MarshalIndent(result.Explanation.Redacted(), "", "  ")
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("authorize: error encoding policy explanation")
+		return nil
+	}
+	return bs
+}
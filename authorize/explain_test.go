@@ -0,0 +1,132 @@
+package authorize
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+func TestAuthorize_handleExplain(t *testing.T) {
+	opt := config.NewDefaultOptions()
+	opt.AuthenticateURLString = "https://authenticate.example.com"
+	opt.DataBrokerURLString = "https://databroker.example.com"
+	opt.SharedKey = "E8wWIMnihUx+AUfRegAQDNs8eRb3UrB5G3zlJW9XJDM="
+	opt.ExplainPolicyDecisions = true
+	opt.Policies = []config.Policy{{
+		From: "https://from.example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		Policy: &config.PPLPolicy{Policy: &parser.Policy{
+			Rules: []parser.Rule{{
+				Action: parser.ActionAllow,
+				And: []parser.Criterion{{
+					Name: "http_path",
+					Data: parser.Object{"starts_with": parser.String("/public")},
+				}},
+			}},
+		}},
+	}}
+	require.NoError(t, opt.Policies[0].Validate())
+	a, err := New(&config.Config{Options: opt})
+	require.NoError(t, err)
+	a.currentOptions.Store(opt)
+
+	newCheckRequest := func(path string) *envoy_service_auth_v3.CheckRequest {
+		return &envoy_service_auth_v3.CheckRequest{
+			Attributes: &envoy_service_auth_v3.AttributeContext{
+				Request: &envoy_service_auth_v3.AttributeContext_Request{
+					Http: &envoy_service_auth_v3.AttributeContext_HttpRequest{
+						Method: http.MethodGet,
+						Path:   path,
+						Host:   "from.example.com",
+						Scheme: "https",
+						Headers: map[string]string{
+							"accept": "text/html",
+						},
+					},
+				},
+			},
+		}
+	}
+	explain := func(t *testing.T, path string) map[string]interface{} {
+		res, err := a.handleExplain(context.Background(), newCheckRequest(path), &sessions.State{ID: "s1"})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, int(res.GetDeniedResponse().GetStatus().GetCode()))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(res.GetDeniedResponse().GetBody()), &body))
+		return body
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		body := explain(t, "/.pomerium/explain?path=/public/index.html")
+		assert.Equal(t, "https://from.example.com/public/index.html", body["url"])
+		assert.Equal(t, http.MethodGet, body["method"])
+		assert.Equal(t, true, body["allow"])
+	})
+	t.Run("denied", func(t *testing.T) {
+		body := explain(t, "/.pomerium/explain?method=POST&path=/private")
+		assert.Equal(t, "https://from.example.com/private", body["url"])
+		assert.Equal(t, http.MethodPost, body["method"])
+		assert.Equal(t, false, body["allow"])
+		assert.Contains(t, body["explanation"].(map[string]interface{})["rules"], map[string]interface{}{
+			"action":  "allow",
+			"value":   false,
+			"reasons": []interface{}{criteria.ReasonHTTPPathUnauthorized},
+			"operators": []interface{}{map[string]interface{}{
+				"operator": "and",
+				"value":    false,
+				"reasons":  []interface{}{criteria.ReasonHTTPPathUnauthorized},
+				"criteria": []interface{}{map[string]interface{}{
+					"criterion": map[string]interface{}{
+						"http_path": nil,
+					},
+					"value":   false,
+					"reasons": []interface{}{criteria.ReasonHTTPPathUnauthorized},
+				}},
+			}},
+		})
+	})
+	t.Run("unauthenticated", func(t *testing.T) {
+		res, err := a.handleExplain(context.Background(), newCheckRequest("/.pomerium/explain"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusFound, int(res.GetDeniedResponse().GetStatus().GetCode()),
+			"should redirect to sign in")
+	})
+	t.Run("error page", func(t *testing.T) {
+		res, err := a.handleResultDenied(context.Background(), newCheckRequest("/private"), &evaluator.Result{
+			Allow: evaluator.NewRuleResult(false, criteria.ReasonHTTPPathUnauthorized),
+			Deny:  evaluator.NewRuleResult(false),
+			Explanation: &evaluator.Explanation{
+				Rules: []evaluator.RuleExplanation{{
+					Action: parser.ActionAllow,
+					Operators: []evaluator.OperatorExplanation{{
+						Operator: "and",
+						Criteria: []evaluator.CriterionExplanation{{
+							Criterion: &parser.Criterion{
+								Name: "email",
+								Data: parser.Object{"is": parser.String("admin@example.com")},
+							},
+						}},
+					}},
+				}},
+			},
+		}, false, criteria.NewReasons(criteria.ReasonHTTPPathUnauthorized))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, int(res.GetDeniedResponse().GetStatus().GetCode()))
+		assert.True(t, strings.Contains(res.GetDeniedResponse().GetBody(), "Policy explanation"))
+		assert.False(t, strings.Contains(res.GetDeniedResponse().GetBody(), "admin@example.com"),
+			"should not show the criterion data to the user")
+	})
+}
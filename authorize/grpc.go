@@ -3,6 +3,7 @@ package authorize
 import (
 	"context"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
 )
 
 // Check implements the envoy auth server gRPC endpoint.
@@ -54,11 +56,16 @@ func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRe
 		sessionState = nil
 	}
 
+	if a.currentOptions.Load().ExplainPolicyDecisions && !isForwardAuth && hreq.URL.Path == explainPath {
+		return a.handleExplain(ctx, in, sessionState)
+	}
+
 	req, err := a.getEvaluatorRequestFromCheckRequest(in, sessionState)
 	if err != nil {
 		log.Warn(ctx).Err(err).Msg("error building evaluator request")
 		return nil, err
 	}
+	req.Explain = a.currentOptions.Load().ExplainPolicyDecisionsOnErrorPages
 
 	// take the state lock here so we don't update while evaluating
 	a.stateLock.RLock()
@@ -74,6 +81,11 @@ func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRe
 
 	isForwardAuthVerify := isForwardAuth && hreq.URL.Path == "/verify"
 
+	// if the route restricts identity providers and the user signed in with another one, they have to sign in again
+	if !isIdentityProviderAllowed(req.Policy, s) {
+		return a.requireLoginResponse(ctx, in, isForwardAuthVerify)
+	}
+
 	// if there's a deny, the result is denied using the deny reasons.
 	if res.Deny.Value {
 		return a.handleResultDenied(ctx, in, res, isForwardAuthVerify, res.Deny.Reasons)
@@ -126,6 +138,7 @@ func (a *Authorize) getEvaluatorRequestFromCheckRequest(
 	in *envoy_service_auth_v3.CheckRequest,
 	sessionState *sessions.State,
 ) (*evaluator.Request, error) {
+	options := a.currentOptions.Load()
 	requestURL := getCheckRequestURL(in)
 	req := &evaluator.Request{
 		HTTP: evaluator.NewRequestHTTP(
@@ -133,6 +146,7 @@ func (a *Authorize) getEvaluatorRequestFromCheckRequest(
 			requestURL,
 			getCheckRequestHeaders(in),
 			getPeerCertificate(in),
+			getClientIP(in, options.XffNumTrustedHops, options.SkipXffAppend),
 		),
 	}
 	if sessionState != nil {
@@ -156,6 +170,14 @@ func (a *Authorize) getMatchingPolicy(requestURL url.URL) *config.Policy {
 	return nil
 }
 
+func isIdentityProviderAllowed(policy *config.Policy, s sessionOrServiceAccount) bool {
+	pbSession, ok := s.(*session.Session)
+	if policy == nil || policy.AllowPublicUnauthenticatedAccess || !ok {
+		return true
+	}
+	return policy.IsIdentityProviderAllowed(pbSession.GetIdentityProvider())
+}
+
 func getHTTPRequestFromCheckRequest(req *envoy_service_auth_v3.CheckRequest) *http.Request {
 	hattrs := req.GetAttributes().GetRequest().GetHttp()
 	u := getCheckRequestURL(req)
@@ -206,3 +228,30 @@ func getPeerCertificate(in *envoy_service_auth_v3.CheckRequest) string {
 	cert, _ := url.QueryUnescape(in.GetAttributes().GetSource().GetCertificate())
 	return cert
 }
+
+// getClientIP returns the IP address of the client. As in envoy, when there are trusted hops the client address is
+// taken from the x-forwarded-for header, skipping the trusted hops from the right. Otherwise the address of the
+// downstream connection is used.
+func getClientIP(in *envoy_service_auth_v3.CheckRequest, xffNumTrustedHops uint32, skipXffAppend bool) string {
+	remoteIP := in.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress()
+	if xffNumTrustedHops == 0 {
+		return remoteIP
+	}
+
+	var addrs []string
+	for _, addr := range strings.Split(in.GetAttributes().GetRequest().GetHttp().GetHeaders()["x-forwarded-for"], ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	// unless disabled, envoy appends the remote address to the header before the request is authorized
+	if skipXffAppend && remoteIP != "" {
+		addrs = append(addrs, remoteIP)
+	}
+
+	idx := len(addrs) - 1 - int(xffNumTrustedHops)
+	if idx < 0 || net.ParseIP(addrs[idx]) == nil {
+		return remoteIP
+	}
+	return addrs[idx]
+}
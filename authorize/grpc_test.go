@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"testing"
 
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -19,6 +20,8 @@ import (
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
 )
 
 const certPEM = `
@@ -98,6 +101,7 @@ func Test_getEvaluatorRequest(t *testing.T) {
 				"X-Forwarded-Proto": "https",
 			},
 			certPEM,
+			"",
 		),
 	}
 	assert.Equal(t, expect, actual)
@@ -304,6 +308,7 @@ func Test_getEvaluatorRequestWithPortInHostHeader(t *testing.T) {
 				"X-Forwarded-Proto": "https",
 			},
 			certPEM,
+			"",
 		),
 	}
 	assert.Equal(t, expect, actual)
@@ -422,3 +427,73 @@ func mustParseURL(rawURL string) url.URL {
 	}
 	return *u
 }
+
+func Test_getClientIP(t *testing.T) {
+	newCheckRequest := func(remoteIP, xff string) *envoy_service_auth_v3.CheckRequest {
+		return &envoy_service_auth_v3.CheckRequest{
+			Attributes: &envoy_service_auth_v3.AttributeContext{
+				Source: &envoy_service_auth_v3.AttributeContext_Peer{
+					Address: &envoy_config_core_v3.Address{
+						Address: &envoy_config_core_v3.Address_SocketAddress{
+							SocketAddress: &envoy_config_core_v3.SocketAddress{
+								Address: remoteIP,
+							},
+						},
+					},
+				},
+				Request: &envoy_service_auth_v3.AttributeContext_Request{
+					Http: &envoy_service_auth_v3.AttributeContext_HttpRequest{
+						Headers: map[string]string{
+							"x-forwarded-for": xff,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name              string
+		in                *envoy_service_auth_v3.CheckRequest
+		xffNumTrustedHops uint32
+		skipXffAppend     bool
+		expect            string
+	}{
+		{"no trusted hops", newCheckRequest("203.0.113.128", "192.0.2.10, 203.0.113.128"), 0, false, "203.0.113.128"},
+		{"one trusted hop", newCheckRequest("203.0.113.128", "192.0.2.10, 203.0.113.1, 203.0.113.128"), 1, false, "203.0.113.1"},
+		{"two trusted hops", newCheckRequest("203.0.113.128", "192.0.2.10, 203.0.113.1, 203.0.113.128"), 2, false, "192.0.2.10"},
+		{"skip xff append", newCheckRequest("203.0.113.128", "192.0.2.10, 203.0.113.1"), 1, true, "203.0.113.1"},
+		{"ipv6", newCheckRequest("2001:db8::2", "2001:db8::1, 2001:db8::2"), 1, false, "2001:db8::1"},
+		{"too few addresses", newCheckRequest("203.0.113.128", "203.0.113.128"), 1, false, "203.0.113.128"},
+		{"invalid address", newCheckRequest("203.0.113.128", "unknown, 203.0.113.128"), 1, false, "203.0.113.128"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, getClientIP(tc.in, tc.xffNumTrustedHops, tc.skipXffAppend))
+		})
+	}
+}
+
+func Test_isIdentityProviderAllowed(t *testing.T) {
+	restricted := &config.Policy{AllowedIdentityProviders: []string{"contractors"}}
+	public := &config.Policy{AllowedIdentityProviders: []string{"contractors"}, AllowPublicUnauthenticatedAccess: true}
+
+	for _, tc := range []struct {
+		name   string
+		policy *config.Policy
+		s      sessionOrServiceAccount
+		expect bool
+	}{
+		{"no policy", nil, &session.Session{}, true},
+		{"no session", restricted, nil, true},
+		{"unrestricted", &config.Policy{}, &session.Session{}, true},
+		{"allowed", restricted, &session.Session{IdentityProvider: "contractors"}, true},
+		{"not allowed", restricted, &session.Session{IdentityProvider: "default"}, false},
+		{"legacy session", restricted, &session.Session{}, false},
+		{"service account", restricted, &user.ServiceAccount{}, true},
+		{"public", public, &session.Session{}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, isIdentityProviderAllowed(tc.policy, tc.s))
+		})
+	}
+}
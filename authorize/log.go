@@ -9,6 +9,7 @@ import (
 
 	"github.com/pomerium/pomerium/authorize/evaluator"
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
 	"github.com/pomerium/pomerium/internal/telemetry/requestid"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/pkg/grpc/audit"
@@ -58,6 +59,9 @@ func (a *Authorize) logAuthorizeCheck(
 		} else {
 			evt = evt.Strs("deny-why-false", res.Deny.Reasons.Strings())
 		}
+		if res.Shadow != nil {
+			evt = populateLogShadowDetails(ctx, evt, hattrs.GetHost(), res)
+		}
 		evt = evt.Str("user", u.GetId())
 		evt = evt.Str("email", u.GetEmail())
 		evt = evt.Uint64("databroker_server_version", res.DataBrokerServerVersion)
@@ -123,6 +127,32 @@ func (a *Authorize) populateLogSessionDetails(evt *zerolog.Event, s *session.Ses
 	return evt
 }
 
+// populateLogShadowDetails logs the result of the route's shadow policy, and records a metric if its decision differs
+// from the enforced decision.
+func populateLogShadowDetails(ctx context.Context, evt *zerolog.Event, host string, res *evaluator.Result) *zerolog.Event {
+	evt = evt.Bool("shadow-allow", res.Shadow.Allow.Value)
+	if res.Shadow.Allow.Value {
+		evt = evt.Strs("shadow-allow-why-true", res.Shadow.Allow.Reasons.Strings())
+	} else {
+		evt = evt.Strs("shadow-allow-why-false", res.Shadow.Allow.Reasons.Strings())
+	}
+	evt = evt.Bool("shadow-deny", res.Shadow.Deny.Value)
+	if res.Shadow.Deny.Value {
+		evt = evt.Strs("shadow-deny-why-true", res.Shadow.Deny.Reasons.Strings())
+	} else {
+		evt = evt.Strs("shadow-deny-why-false", res.Shadow.Deny.Reasons.Strings())
+	}
+
+	// a request is allowed if there's an allow and no deny, as in Check
+	allowed := res.Allow.Value && !res.Deny.Value
+	shadowAllowed := res.Shadow.Allow.Value && !res.Shadow.Deny.Value
+	evt = evt.Bool("shadow-disagrees", allowed != shadowAllowed)
+	if allowed != shadowAllowed {
+		metrics.RecordShadowPolicyDisagreement(ctx, host, allowed, shadowAllowed)
+	}
+	return evt
+}
+
 func stripQueryString(str string) string {
 	if idx := strings.Index(str, "?"); idx != -1 {
 		str = str[:idx]
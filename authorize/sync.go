@@ -15,6 +15,8 @@ import (
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
 	"github.com/pomerium/pomerium/pkg/grpc/session"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
 	"github.com/pomerium/pomerium/pkg/grpcutil"
@@ -38,7 +40,16 @@ func newDataBrokerSyncer(authorize *Authorize) *dataBrokerSyncer {
 	syncer := &dataBrokerSyncer{
 		authorize: authorize,
 	}
-	syncer.Syncer = databroker.NewSyncer("authorize", syncer)
+	// only the record types used by the authorize policies are sync'd
+	syncer.Syncer = databroker.NewSyncer("authorize", syncer, databroker.WithTypeURLs(
+		grpcutil.GetTypeURL(new(session.Session)),
+		grpcutil.GetTypeURL(new(user.User)),
+		grpcutil.GetTypeURL(new(user.ServiceAccount)),
+		grpcutil.GetTypeURL(new(directory.User)),
+		grpcutil.GetTypeURL(new(directory.Group)),
+		grpcutil.GetTypeURL(new(device.Credential)),
+		grpcutil.GetTypeURL(new(device.Enrollment)),
+	))
 	return syncer
 }
 
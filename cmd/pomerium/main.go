@@ -26,12 +26,20 @@ func main() {
 	}
 
 	ctx := context.Background()
-	if err := run(ctx); !errors.Is(err, context.Canceled) {
+	if err := run(ctx); err != nil && !errors.Is(err, context.Canceled) {
 		log.Fatal().Err(err).Msg("cmd/pomerium")
 	}
 	log.Info(ctx).Msg("cmd/pomerium: exiting")
 }
 
 func run(ctx context.Context) error {
-	return pomerium.Run(ctx, *configFile)
+	switch flag.Arg(0) {
+	case "":
+		return pomerium.Run(ctx, *configFile)
+	case "databroker":
+		return pomerium.RunDataBroker(ctx, *configFile, flag.Args()[1:])
+	case "policy":
+		return pomerium.RunPolicy(ctx, *configFile, flag.Args()[1:])
+	}
+	return fmt.Errorf("unknown command: %s", flag.Arg(0))
 }
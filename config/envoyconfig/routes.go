@@ -63,6 +63,14 @@ func (b *Builder) buildPomeriumHTTPRoutes(options *config.Options, domain string
 			return nil, err
 		}
 		routes = append(routes, r)
+		// enable ext_authz, the explanation is returned by the authorize service
+		if options.ExplainPolicyDecisions {
+			r, err = b.buildControlPlanePathRoute("/.pomerium/explain", true)
+			if err != nil {
+				return nil, err
+			}
+			routes = append(routes, r)
+		}
 
 		// disable ext_authz and passthrough to proxy handlers
 		r, err = b.buildControlPlanePathRoute("/ping", false)
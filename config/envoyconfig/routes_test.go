@@ -99,6 +99,33 @@ func Test_buildPomeriumHTTPRoutes(t *testing.T) {
 			`+routeString("prefix", "/", false)+`
 		]`, routes)
 	})
+	t.Run("explain policy decisions", func(t *testing.T) {
+		options := &config.Options{
+			Services:                 "all",
+			AuthenticateURLString:    "https://authenticate.example.com",
+			AuthenticateCallbackPath: "/oauth2/callback",
+			ExplainPolicyDecisions:   true,
+			Policies: []config.Policy{{
+				From:                             "https://from.example.com",
+				To:                               mustParseWeightedURLs(t, "https://to.example.com"),
+				AllowPublicUnauthenticatedAccess: true,
+			}},
+		}
+		_ = options.Policies[0].Validate()
+		routes, err := b.buildPomeriumHTTPRoutes(options, "from.example.com")
+		require.NoError(t, err)
+
+		testutil.AssertProtoJSONEqual(t, `[
+			`+routeString("path", "/.pomerium/jwt", true)+`,
+			`+routeString("path", "/.pomerium/explain", true)+`,
+			`+routeString("path", "/ping", false)+`,
+			`+routeString("path", "/healthz", false)+`,
+			`+routeString("path", "/.pomerium", false)+`,
+			`+routeString("prefix", "/.pomerium/", false)+`,
+			`+routeString("path", "/.well-known/pomerium", false)+`,
+			`+routeString("prefix", "/.well-known/pomerium/", false)+`
+		]`, routes)
+	})
 	t.Run("proxy fronting authenticate", func(t *testing.T) {
 		options := &config.Options{
 			Services:                 "proxy",
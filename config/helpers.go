@@ -17,6 +17,10 @@ const (
 	StorageRedisName = "redis"
 	// StorageInMemoryName is the name of the in-memory storage backend
 	StorageInMemoryName = "memory"
+	// StoragePostgresName is the name of the postgres storage backend
+	StoragePostgresName = "postgres"
+	// StorageFileName is the name of the single-file storage backend
+	StorageFileName = "file"
 )
 
 // IsValidService checks to see if a service is a valid service mode
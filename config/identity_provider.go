@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+)
+
+// DefaultIdentityProviderName is the name of the identity provider configured by the top-level `idp_*` settings.
+// Sessions created before multiple identity providers were supported have no identity provider and use it.
+const DefaultIdentityProviderName = "default"
+
+// An IdentityProvider is a named identity provider users can choose to sign in with, in addition to the default
+// identity provider.
+type IdentityProvider struct {
+	// Name identifies the identity provider in route `allowed_idps` settings and sessions.
+	Name string `mapstructure:"name" yaml:"name"`
+	// DisplayName is shown on the identity provider selection page. It defaults to the name.
+	DisplayName string `mapstructure:"display_name" yaml:"display_name,omitempty"`
+
+	Provider      string            `mapstructure:"provider" yaml:"provider"`
+	ProviderURL   string            `mapstructure:"provider_url" yaml:"provider_url,omitempty"`
+	ClientID      string            `mapstructure:"client_id" yaml:"client_id"`
+	ClientSecret  string            `mapstructure:"client_secret" yaml:"client_secret,omitempty"`
+	Scopes        []string          `mapstructure:"scopes" yaml:"scopes,omitempty"`
+	RequestParams map[string]string `mapstructure:"request_params" yaml:"request_params,omitempty"`
+	DisablePKCE   bool              `mapstructure:"disable_pkce" yaml:"disable_pkce,omitempty"`
+}
+
+// GetDisplayName returns the name to show users for the identity provider.
+func (idp *IdentityProvider) GetDisplayName() string {
+	if idp.DisplayName != "" {
+		return idp.DisplayName
+	}
+	return idp.Name
+}
+
+// GetIdentityProviders returns all the identity providers users can sign in with. The default identity provider is
+// always first.
+func (o *Options) GetIdentityProviders() []IdentityProvider {
+	idps := make([]IdentityProvider, 0, 1+len(o.IdentityProviders))
+	idps = append(idps, IdentityProvider{
+		Name:          DefaultIdentityProviderName,
+		DisplayName:   o.Provider,
+		Provider:      o.Provider,
+		ProviderURL:   o.ProviderURL,
+		ClientID:      o.ClientID,
+		ClientSecret:  o.ClientSecret,
+		Scopes:        o.Scopes,
+		RequestParams: o.RequestParams,
+		DisablePKCE:   o.DisablePKCE,
+	})
+	idps = append(idps, o.IdentityProviders...)
+	return idps
+}
+
+// GetIdentityProvider returns the identity provider with the given name. An empty name is the default identity
+// provider.
+func (o *Options) GetIdentityProvider(name string) (IdentityProvider, bool) {
+	if name == "" {
+		name = DefaultIdentityProviderName
+	}
+	for _, idp := range o.GetIdentityProviders() {
+		if idp.Name == name {
+			return idp, true
+		}
+	}
+	return IdentityProvider{}, false
+}
+
+// GetIdentityProviderOauthOptions gets the oauth.Options for the identity provider with the given name.
+func (o *Options) GetIdentityProviderOauthOptions(name string) (oauth.Options, error) {
+	idp, ok := o.GetIdentityProvider(name)
+	if !ok {
+		return oauth.Options{}, fmt.Errorf("config: unknown identity provider: %s", name)
+	}
+
+	options, err := o.GetOauthOptions()
+	if err != nil {
+		return oauth.Options{}, err
+	}
+	if idp.Name != DefaultIdentityProviderName {
+		// the service account is only used for the directory of the default identity provider
+		options.ServiceAccount = ""
+	}
+	options.ProviderName = idp.Provider
+	options.ProviderURL = idp.ProviderURL
+	options.ClientID = idp.ClientID
+	options.ClientSecret = idp.ClientSecret
+	options.Scopes = idp.Scopes
+	options.AuthCodeOptions = idp.RequestParams
+	options.DisablePKCE = idp.DisablePKCE
+	return options, nil
+}
+
+func (o *Options) validateIdentityProviders() error {
+	names := map[string]struct{}{
+		DefaultIdentityProviderName: {},
+	}
+	for _, idp := range o.IdentityProviders {
+		if idp.Name == "" {
+			return errors.New("config: identity provider name is required")
+		}
+		if url.PathEscape(idp.Name) != idp.Name {
+			return fmt.Errorf("config: invalid identity provider name: %s", idp.Name)
+		}
+		if _, ok := names[idp.Name]; ok {
+			return fmt.Errorf("config: duplicate identity provider: %s", idp.Name)
+		}
+		names[idp.Name] = struct{}{}
+
+		if idp.Provider == "" {
+			return fmt.Errorf("config: identity provider %s: provider is required", idp.Name)
+		}
+		if idp.ClientID == "" {
+			return fmt.Errorf("config: identity provider %s: client_id is required", idp.Name)
+		}
+	}
+
+	for _, p := range o.GetAllPolicies() {
+		for _, name := range p.AllowedIdentityProviders {
+			if _, ok := names[name]; !ok {
+				return fmt.Errorf("config: route %s: unknown identity provider in allowed_idps: %s", p.From, name)
+			}
+		}
+	}
+	return nil
+}
+
+// IsIdentityProviderAllowed returns true if users who signed in with the named identity provider can access the
+// route. An empty name is the default identity provider.
+func (p *Policy) IsIdentityProviderAllowed(name string) bool {
+	if len(p.AllowedIdentityProviders) == 0 {
+		return true
+	}
+	if name == "" {
+		name = DefaultIdentityProviderName
+	}
+	for _, allowed := range p.AllowedIdentityProviders {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
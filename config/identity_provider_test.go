@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityProviders(t *testing.T) {
+	load := func(t *testing.T, configBytes string) (*Options, error) {
+		tempFile, _ := os.CreateTemp("", "*.yaml")
+		defer tempFile.Close()
+		defer os.Remove(tempFile.Name())
+		tempFile.WriteString(configBytes)
+		return optionsFromViper(tempFile.Name())
+	}
+	const base = `
+autocert_dir: ""
+insecure_server: true
+authenticate_service_url: https://authenticate.example.com
+idp_provider: okta
+idp_provider_url: https://example.okta.com
+idp_client_id: OKTA_CLIENT_ID
+idp_client_secret: OKTA_CLIENT_SECRET
+idp_request_params:
+  prompt: login
+`
+
+	t.Run("valid", func(t *testing.T) {
+		o, err := load(t, base+`
+identity_providers:
+  - name: contractors
+    display_name: GitHub
+    provider: github
+    client_id: GITHUB_CLIENT_ID
+    client_secret: GITHUB_CLIENT_SECRET
+    scopes: [read:user]
+    disable_pkce: true
+routes:
+  - from: https://from.example.com
+    to: https://to.example.com
+    allowed_idps: [default, contractors]
+`)
+		require.NoError(t, err)
+
+		idps := o.GetIdentityProviders()
+		require.Len(t, idps, 2)
+		assert.Equal(t, DefaultIdentityProviderName, idps[0].Name)
+		assert.Equal(t, "okta", idps[0].GetDisplayName())
+		assert.Equal(t, "contractors", idps[1].Name)
+		assert.Equal(t, "GitHub", idps[1].GetDisplayName())
+
+		idp, ok := o.GetIdentityProvider("")
+		assert.True(t, ok)
+		assert.Equal(t, DefaultIdentityProviderName, idp.Name)
+		_, ok = o.GetIdentityProvider("unknown")
+		assert.False(t, ok)
+
+		oauthOptions, err := o.GetIdentityProviderOauthOptions(DefaultIdentityProviderName)
+		require.NoError(t, err)
+		assert.Equal(t, "okta", oauthOptions.ProviderName)
+		assert.Equal(t, "OKTA_CLIENT_ID", oauthOptions.ClientID)
+		assert.Equal(t, map[string]string{"prompt": "login"}, oauthOptions.AuthCodeOptions)
+		assert.False(t, oauthOptions.DisablePKCE)
+
+		oauthOptions, err = o.GetIdentityProviderOauthOptions("contractors")
+		require.NoError(t, err)
+		assert.Equal(t, "github", oauthOptions.ProviderName)
+		assert.Equal(t, "GITHUB_CLIENT_ID", oauthOptions.ClientID)
+		assert.Equal(t, "GITHUB_CLIENT_SECRET", oauthOptions.ClientSecret)
+		assert.Equal(t, []string{"read:user"}, oauthOptions.Scopes)
+		assert.True(t, oauthOptions.DisablePKCE)
+		assert.Equal(t, "https://authenticate.example.com/oauth2/callback", oauthOptions.RedirectURL.String())
+
+		_, err = o.GetIdentityProviderOauthOptions("unknown")
+		assert.Error(t, err)
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := load(t, base+`
+identity_providers:
+  - name: default
+    provider: github
+    client_id: GITHUB_CLIENT_ID
+`)
+		assert.Error(t, err)
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := load(t, base+`
+identity_providers:
+  - name: a|b
+    provider: github
+    client_id: GITHUB_CLIENT_ID
+`)
+		assert.Error(t, err)
+	})
+	t.Run("missing provider", func(t *testing.T) {
+		_, err := load(t, base+`
+identity_providers:
+  - name: contractors
+    client_id: GITHUB_CLIENT_ID
+`)
+		assert.Error(t, err)
+	})
+	t.Run("unknown allowed idp", func(t *testing.T) {
+		_, err := load(t, base+`
+routes:
+  - from: https://from.example.com
+    to: https://to.example.com
+    allowed_idps: [contractors]
+`)
+		assert.Error(t, err)
+	})
+}
+
+func TestPolicy_IsIdentityProviderAllowed(t *testing.T) {
+	p := &Policy{}
+	assert.True(t, p.IsIdentityProviderAllowed(""))
+	assert.True(t, p.IsIdentityProviderAllowed("contractors"))
+
+	p.AllowedIdentityProviders = []string{DefaultIdentityProviderName}
+	assert.True(t, p.IsIdentityProviderAllowed(""), "sessions without an identity provider use the default")
+	assert.True(t, p.IsIdentityProviderAllowed(DefaultIdentityProviderName))
+	assert.False(t, p.IsIdentityProviderAllowed("contractors"))
+}
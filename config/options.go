@@ -34,6 +34,8 @@ import (
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/policy"
+	"github.com/pomerium/pomerium/pkg/policy/linter"
 )
 
 // DisableHeaderKey is the key used to check whether to disable setting header
@@ -120,6 +122,9 @@ type Options struct {
 	// AdditionalPolicies are any additional policies added to the options.
 	AdditionalPolicies []Policy `yaml:"-"`
 
+	// NamedPolicies are PPL policies which can be shared across routes using the policy_ref criterion.
+	NamedPolicies map[string]*PPLPolicy `mapstructure:"policies" yaml:"policies,omitempty"`
+
 	// AuthenticateURL represents the externally accessible http endpoints
 	// used for authentication requests and callbacks
 	AuthenticateURLString         string `mapstructure:"authenticate_service_url" yaml:"authenticate_service_url,omitempty"`
@@ -162,6 +167,16 @@ type Options struct {
 	// https://openid.net/specs/openid-connect-basic-1_0.html#RequestParameters
 	RequestParams map[string]string `mapstructure:"idp_request_params" yaml:"idp_request_params,omitempty"`
 
+	// DisablePKCE disables PKCE and the nonce check in the OIDC sign in flow,
+	// for identity providers which reject them.
+	//
+	// https://datatracker.ietf.org/doc/html/rfc7636
+	DisablePKCE bool `mapstructure:"idp_disable_pkce" yaml:"idp_disable_pkce,omitempty"`
+
+	// IdentityProviders are additional identity providers users can choose to sign in with. Routes can restrict
+	// which identity providers are acceptable with `allowed_idps`.
+	IdentityProviders []IdentityProvider `mapstructure:"identity_providers" yaml:"identity_providers,omitempty"`
+
 	// AuthorizeURLString is the routable destination of the authorize service's
 	// gRPC endpoint. NOTE: As many load balancers do not support
 	// externally routed gRPC so this may be an internal location.
@@ -247,7 +262,7 @@ type Options struct {
 	DataBrokerURLStrings        []string `mapstructure:"databroker_service_urls" yaml:"databroker_service_urls,omitempty"`
 	DataBrokerInternalURLString string   `mapstructure:"databroker_internal_service_url" yaml:"databroker_internal_service_url,omitempty"`
 	// DataBrokerStorageType is the storage backend type that databroker will use.
-	// Supported type: memory, redis
+	// Supported type: memory, redis, postgres, file
 	DataBrokerStorageType string `mapstructure:"databroker_storage_type" yaml:"databroker_storage_type,omitempty"`
 	// DataBrokerStorageConnectionString is the data source name for storage backend.
 	DataBrokerStorageConnectionString string `mapstructure:"databroker_storage_connection_string" yaml:"databroker_storage_connection_string,omitempty"`
@@ -255,6 +270,19 @@ type Options struct {
 	DataBrokerStorageCertKeyFile      string `mapstructure:"databroker_storage_key_file" yaml:"databroker_storage_key_file,omitempty"`
 	DataBrokerStorageCAFile           string `mapstructure:"databroker_storage_ca_file" yaml:"databroker_storage_ca_file,omitempty"`
 	DataBrokerStorageCertSkipVerify   bool   `mapstructure:"databroker_storage_tls_skip_verify" yaml:"databroker_storage_tls_skip_verify,omitempty"`
+	// DataBrokerStorageDecryptionKeys are previous shared secrets which stored records may still be encrypted with.
+	DataBrokerStorageDecryptionKeys []string `mapstructure:"databroker_storage_decryption_keys" yaml:"databroker_storage_decryption_keys,omitempty"`
+	// DataBrokerReplicationPeers are the URLs of every databroker replica, including this one. When set, the
+	// in-memory storage is replicated between the replicas.
+	DataBrokerReplicationPeers []string `mapstructure:"databroker_replication_peers" yaml:"databroker_replication_peers,omitempty"`
+	// DataBrokerWebhookURL is the URL changes to databroker records are delivered to.
+	DataBrokerWebhookURL string `mapstructure:"databroker_webhook_url" yaml:"databroker_webhook_url,omitempty"`
+	// DataBrokerWebhookSecret is the secret used to sign the delivered changes.
+	DataBrokerWebhookSecret string `mapstructure:"databroker_webhook_secret" yaml:"databroker_webhook_secret,omitempty"`
+	// DataBrokerWebhookTypes restricts the delivered changes to records of the given types.
+	DataBrokerWebhookTypes []string `mapstructure:"databroker_webhook_types" yaml:"databroker_webhook_types,omitempty"`
+	// DataBrokerWebhookDataTypes are the record types whose data is included in the delivered changes.
+	DataBrokerWebhookDataTypes []string `mapstructure:"databroker_webhook_data_types" yaml:"databroker_webhook_data_types,omitempty"`
 
 	// ClientCA is the base64-encoded certificate authority to validate client mTLS certificates against.
 	ClientCA string `mapstructure:"client_ca" yaml:"client_ca,omitempty"`
@@ -269,6 +297,11 @@ type Options struct {
 	// If unset, the GCP metadata server will be used to query for identity tokens.
 	GoogleCloudServerlessAuthenticationServiceAccount string `mapstructure:"google_cloud_serverless_authentication_service_account" yaml:"google_cloud_serverless_authentication_service_account,omitempty"` //nolint
 
+	// ExplainPolicyDecisions enables the /.pomerium/explain endpoint.
+	ExplainPolicyDecisions bool `mapstructure:"explain_policy_decisions" yaml:"explain_policy_decisions,omitempty"`
+	// ExplainPolicyDecisionsOnErrorPages adds an explanation of the policy decision to error pages.
+	ExplainPolicyDecisionsOnErrorPages bool `mapstructure:"explain_policy_decisions_on_error_pages" yaml:"explain_policy_decisions_on_error_pages,omitempty"`
+
 	// UseProxyProtocol configures the HTTP listener to require the HAProxy proxy protocol (either v1 or v2) on incoming requests.
 	UseProxyProtocol bool `mapstructure:"use_proxy_protocol" yaml:"use_proxy_protocol,omitempty" json:"use_proxy_protocol,omitempty"`
 
@@ -290,6 +323,9 @@ type Options struct {
 	EnvoyBindConfigSourceAddress string    `mapstructure:"envoy_bind_config_source_address" yaml:"envoy_bind_config_source_address,omitempty"`
 	EnvoyBindConfigFreebind      null.Bool `mapstructure:"envoy_bind_config_freebind" yaml:"envoy_bind_config_freebind,omitempty"`
 
+	// DeviceAuthorizationClientIDs are the client ids allowed to start a device authorization request.
+	DeviceAuthorizationClientIDs []string `mapstructure:"device_authorization_client_ids" yaml:"device_authorization_client_ids,omitempty"`
+
 	// ProgrammaticRedirectDomainWhitelist restricts the allowed redirect URLs when using programmatic login.
 	ProgrammaticRedirectDomainWhitelist []string `mapstructure:"programmatic_redirect_domain_whitelist" yaml:"programmatic_redirect_domain_whitelist,omitempty" json:"programmatic_redirect_domain_whitelist,omitempty"` //nolint
 
@@ -343,6 +379,7 @@ var defaultOptions = Options{
 	EnvoyAdminProfilePath:               os.DevNull,
 	EnvoyAdminAddress:                   "127.0.0.1:9901",
 	ProgrammaticRedirectDomainWhitelist: []string{"localhost"},
+	DeviceAuthorizationClientIDs:        []string{"pomerium-cli"},
 }
 
 // NewDefaultOptions returns a copy the default options. It's the caller's
@@ -432,15 +469,25 @@ func (o *Options) parsePolicy() error {
 		o.Routes = routes
 	}
 
+	if err := o.validateNamedPolicies(); err != nil {
+		return err
+	}
+
 	// Finish initializing policies
 	for i := range o.Policies {
 		p := &o.Policies[i]
+		if err := p.ResolvePolicyRefs(o.NamedPolicies); err != nil {
+			return err
+		}
 		if err := p.Validate(); err != nil {
 			return err
 		}
 	}
 	for i := range o.Routes {
 		p := &o.Routes[i]
+		if err := p.ResolvePolicyRefs(o.NamedPolicies); err != nil {
+			return err
+		}
 		if err := p.Validate(); err != nil {
 			return err
 		}
@@ -454,6 +501,29 @@ func (o *Options) parsePolicy() error {
 	return nil
 }
 
+// validateNamedPolicies lints every named policy, including those which aren't referenced by any route.
+func (o *Options) validateNamedPolicies() error {
+	names := make([]string, 0, len(o.NamedPolicies))
+	for name, ppl := range o.NamedPolicies {
+		if ppl == nil || ppl.Policy == nil {
+			return fmt.Errorf("config: named policy %s is empty", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	l := policy.NewLinter(policy.WithNamedPolicies(getNamedPPLPolicies(o.NamedPolicies)))
+	for _, name := range names {
+		for _, problem := range l.Lint(o.NamedPolicies[name].Policy) {
+			if problem.Severity == linter.SeverityError {
+				return fmt.Errorf("config: invalid named policy %s: %s", name, problem.Message)
+			}
+			log.Warn(context.Background()).Str("policy", name).Msgf("config: named policy %s", problem)
+		}
+	}
+	return nil
+}
+
 func (o *Options) viperSet(key string, value interface{}) {
 	o.viper.Set(key, value)
 }
@@ -543,7 +613,7 @@ func (o *Options) Validate() error {
 
 	switch o.DataBrokerStorageType {
 	case StorageInMemoryName:
-	case StorageRedisName:
+	case StorageRedisName, StoragePostgresName, StorageFileName:
 		if o.DataBrokerStorageConnectionString == "" {
 			return errors.New("config: missing databroker storage backend dsn")
 		}
@@ -556,6 +626,36 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("config: invalid shared secret: %w", err)
 	}
 
+	_, err = o.GetDataBrokerStorageDecryptionKeys()
+	if err != nil {
+		return fmt.Errorf("config: invalid databroker storage decryption key: %w", err)
+	}
+
+	if len(o.DataBrokerReplicationPeers) > 0 {
+		if o.DataBrokerStorageType != StorageInMemoryName {
+			return errors.New("config: databroker replication is only supported with the in-memory storage backend")
+		}
+		if o.SharedKey == "" {
+			return errors.New("config: databroker replication requires a shared secret")
+		}
+		for _, rawURL := range o.DataBrokerReplicationPeers {
+			_, err := urlutil.ParseAndValidateURL(rawURL)
+			if err != nil {
+				return fmt.Errorf("config: bad databroker replication peer %s : %w", rawURL, err)
+			}
+		}
+	}
+
+	if o.DataBrokerWebhookURL != "" {
+		_, err := urlutil.ParseAndValidateURL(o.DataBrokerWebhookURL)
+		if err != nil {
+			return fmt.Errorf("config: bad databroker webhook url %s : %w", o.DataBrokerWebhookURL, err)
+		}
+		if o.DataBrokerWebhookSecret == "" {
+			return errors.New("config: databroker webhook requires a webhook secret")
+		}
+	}
+
 	if o.AuthenticateURLString != "" {
 		_, err := urlutil.ParseAndValidateURL(o.AuthenticateURLString)
 		if err != nil {
@@ -616,6 +716,10 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("config: failed to parse policy: %w", err)
 	}
 
+	if err := o.validateIdentityProviders(); err != nil {
+		return err
+	}
+
 	if err := o.parseHeaders(ctx); err != nil {
 		return fmt.Errorf("config: failed to parse headers: %w", err)
 	}
@@ -906,6 +1010,7 @@ func (o *Options) GetOauthOptions() (oauth.Options, error) {
 		ClientSecret:   o.ClientSecret,
 		Scopes:         o.Scopes,
 		ServiceAccount: o.ServiceAccount,
+		DisablePKCE:    o.DisablePKCE,
 	}, nil
 }
 
@@ -961,6 +1066,22 @@ func (o *Options) GetDataBrokerCertificate() (*tls.Certificate, error) {
 	return cryptutil.CertificateFromFile(o.DataBrokerStorageCertFile, o.DataBrokerStorageCertKeyFile)
 }
 
+// GetDataBrokerStorageDecryptionKeys gets the decoded databroker storage decryption keys.
+func (o *Options) GetDataBrokerStorageDecryptionKeys() ([][]byte, error) {
+	var keys [][]byte
+	for _, rawKey := range o.DataBrokerStorageDecryptionKeys {
+		key, err := base64.StdEncoding.DecodeString(rawKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) != cryptutil.DefaultKeySize {
+			return nil, fmt.Errorf("expected a %d byte key, got %d bytes", cryptutil.DefaultKeySize, len(key))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 // GetCertificates gets all the certificates from the options.
 func (o *Options) GetCertificates() ([]tls.Certificate, error) {
 	var certs []tls.Certificate
@@ -45,6 +45,10 @@ func Test_Validate(t *testing.T) {
 	invalidStorageType.DataBrokerStorageType = "foo"
 	missingStorageDSN := testOptions()
 	missingStorageDSN.DataBrokerStorageType = "redis"
+	missingPostgresStorageDSN := testOptions()
+	missingPostgresStorageDSN.DataBrokerStorageType = StoragePostgresName
+	missingFileStoragePath := testOptions()
+	missingFileStoragePath.DataBrokerStorageType = StorageFileName
 	badSignoutRedirectURL := testOptions()
 	badSignoutRedirectURL.SignOutRedirectURLString = "--"
 
@@ -53,6 +57,27 @@ func Test_Validate(t *testing.T) {
 	missingSharedSecretWithPersistence.DataBrokerStorageType = StorageRedisName
 	missingSharedSecretWithPersistence.DataBrokerStorageConnectionString = "redis://somehost:6379"
 
+	replication := testOptions()
+	replication.DataBrokerReplicationPeers = []string{"https://databroker-0.example.com", "https://databroker-1.example.com"}
+	replicationWithPersistence := testOptions()
+	replicationWithPersistence.DataBrokerReplicationPeers = replication.DataBrokerReplicationPeers
+	replicationWithPersistence.DataBrokerStorageType = StorageRedisName
+	replicationWithPersistence.DataBrokerStorageConnectionString = "redis://somehost:6379"
+	replicationWithoutSharedSecret := testOptions()
+	replicationWithoutSharedSecret.DataBrokerReplicationPeers = replication.DataBrokerReplicationPeers
+	replicationWithoutSharedSecret.SharedKey = ""
+	badReplicationPeer := testOptions()
+	badReplicationPeer.DataBrokerReplicationPeers = []string{"--"}
+
+	webhook := testOptions()
+	webhook.DataBrokerWebhookURL = "https://webhook.example.com"
+	webhook.DataBrokerWebhookSecret = "SECRET"
+	badWebhookURL := testOptions()
+	badWebhookURL.DataBrokerWebhookURL = "--"
+	badWebhookURL.DataBrokerWebhookSecret = "SECRET"
+	webhookWithoutSecret := testOptions()
+	webhookWithoutSecret.DataBrokerWebhookURL = "https://webhook.example.com"
+
 	tests := []struct {
 		name     string
 		testOpts *Options
@@ -65,8 +90,17 @@ func Test_Validate(t *testing.T) {
 		{"policy file specified", badPolicyFile, true},
 		{"invalid databroker storage type", invalidStorageType, true},
 		{"missing databroker storage dsn", missingStorageDSN, true},
+		{"missing databroker postgres storage dsn", missingPostgresStorageDSN, true},
+		{"missing databroker file storage path", missingFileStoragePath, true},
 		{"invalid signout redirect url", badSignoutRedirectURL, true},
 		{"no shared key with databroker persistence", missingSharedSecretWithPersistence, true},
+		{"databroker replication", replication, false},
+		{"databroker replication with persistence", replicationWithPersistence, true},
+		{"no shared key with databroker replication", replicationWithoutSharedSecret, true},
+		{"invalid databroker replication peer", badReplicationPeer, true},
+		{"databroker webhook", webhook, false},
+		{"invalid databroker webhook url", badWebhookURL, true},
+		{"no secret with databroker webhook", webhookWithoutSecret, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -279,7 +313,7 @@ func Test_Checksum(t *testing.T) {
 
 func TestOptionsFromViper(t *testing.T) {
 	opts := []cmp.Option{
-		cmpopts.IgnoreFields(Options{}, "CookieSecret", "GRPCInsecure", "GRPCAddr", "DataBrokerURLString", "DataBrokerURLStrings", "AuthorizeURLString", "AuthorizeURLStrings", "DefaultUpstreamTimeout", "CookieExpire", "Services", "Addr", "LogLevel", "KeyFile", "CertFile", "SharedKey", "ReadTimeout", "IdleTimeout", "GRPCClientTimeout", "GRPCClientDNSRoundRobin", "TracingSampleRate", "ProgrammaticRedirectDomainWhitelist"),
+		cmpopts.IgnoreFields(Options{}, "CookieSecret", "GRPCInsecure", "GRPCAddr", "DataBrokerURLString", "DataBrokerURLStrings", "AuthorizeURLString", "AuthorizeURLStrings", "DefaultUpstreamTimeout", "CookieExpire", "Services", "Addr", "LogLevel", "KeyFile", "CertFile", "SharedKey", "ReadTimeout", "IdleTimeout", "GRPCClientTimeout", "GRPCClientDNSRoundRobin", "TracingSampleRate", "ProgrammaticRedirectDomainWhitelist", "DeviceAuthorizationClientIDs"),
 		cmpopts.IgnoreFields(Policy{}, "Source", "EnvoyOpts"),
 		cmpOptIgnoreUnexported,
 	}
@@ -359,6 +393,86 @@ func TestOptionsFromViper(t *testing.T) {
 	}
 }
 
+func TestNamedPolicies(t *testing.T) {
+	load := func(t *testing.T, configBytes string) (*Options, error) {
+		tempFile, _ := os.CreateTemp("", "*.yaml")
+		defer tempFile.Close()
+		defer os.Remove(tempFile.Name())
+		tempFile.WriteString(configBytes)
+		return optionsFromViper(tempFile.Name())
+	}
+	const route = `
+autocert_dir: ""
+insecure_server: true
+routes:
+  - from: https://from.example
+    to: https://to.example
+    policy:
+      allow:
+        and:
+          - policy_ref: admins
+`
+
+	t.Run("resolved", func(t *testing.T) {
+		o1, err := load(t, route+`
+policies:
+  admins:
+    allow:
+      or:
+        - policy_ref: users
+  users:
+    allow:
+      or:
+        - email:
+            is: user1@example.com
+  unused:
+    allow:
+      or:
+        - accept: true
+`)
+		require.NoError(t, err)
+		require.Len(t, o1.Routes, 1)
+		assert.Len(t, o1.NamedPolicies, 3)
+		assert.Len(t, o1.Routes[0].PolicyRefs, 2)
+		assert.Contains(t, o1.Routes[0].PolicyRefs, "admins")
+		assert.Contains(t, o1.Routes[0].PolicyRefs, "users")
+
+		o2, err := load(t, route+`
+policies:
+  admins:
+    allow:
+      or:
+        - policy_ref: users
+  users:
+    allow:
+      or:
+        - email:
+            is: user2@example.com
+`)
+		require.NoError(t, err)
+		assert.NotEqual(t, o1.Routes[0].Checksum(), o2.Routes[0].Checksum(),
+			"changing a named policy should change the checksum of the routes which reference it")
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, err := load(t, route)
+		assert.Error(t, err)
+	})
+	t.Run("cycle", func(t *testing.T) {
+		_, err := load(t, route+`
+policies:
+  admins:
+    allow:
+      or:
+        - policy_ref: users
+  users:
+    allow:
+      or:
+        - policy_ref: admins
+`)
+		assert.Error(t, err)
+	})
+}
+
 func Test_NewOptionsFromConfigEnvVar(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/pomerium/pomerium/internal/hashutil"
@@ -21,6 +22,9 @@ import (
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/policy"
+	"github.com/pomerium/pomerium/pkg/policy/linter"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
 )
 
 // Policy contains route specific configuration and access settings.
@@ -40,6 +44,8 @@ type Policy struct {
 	AllowedGroups    []string                 `mapstructure:"allowed_groups" yaml:"allowed_groups,omitempty" json:"allowed_groups,omitempty"`
 	AllowedDomains   []string                 `mapstructure:"allowed_domains" yaml:"allowed_domains,omitempty" json:"allowed_domains,omitempty"`
 	AllowedIDPClaims identity.FlattenedClaims `mapstructure:"allowed_idp_claims" yaml:"allowed_idp_claims,omitempty" json:"allowed_idp_claims,omitempty"`
+	// AllowedIdentityProviders restricts the identity providers users can sign in with to access the route.
+	AllowedIdentityProviders []string `mapstructure:"allowed_idps" yaml:"allowed_idps,omitempty" json:"allowed_idps,omitempty"`
 
 	Source *StringURL `yaml:",omitempty" json:"source,omitempty" hash:"ignore"`
 
@@ -163,6 +169,14 @@ type Policy struct {
 	SetResponseHeaders map[string]string `mapstructure:"set_response_headers" yaml:"set_response_headers,omitempty"`
 
 	Policy *PPLPolicy `mapstructure:"policy" yaml:"policy,omitempty" json:"policy,omitempty"`
+
+	// ShadowPolicy is evaluated alongside the route's policy, replacing it, but its decision is only logged and
+	// counted in metrics, so the impact of a policy change can be observed before it is enforced.
+	ShadowPolicy *PPLPolicy `mapstructure:"shadow_policy" yaml:"shadow_policy,omitempty" json:"shadow_policy,omitempty"`
+
+	// PolicyRefs are the named policies referenced by Policy, directly or indirectly, so that changing a named
+	// policy changes the checksum of every route which uses it.
+	PolicyRefs map[string]*PPLPolicy `mapstructure:"-" yaml:"-" json:"-"`
 }
 
 // RewriteHeader is a policy configuration option to rewrite an HTTP header.
@@ -195,8 +209,9 @@ type PolicyRedirect struct {
 	StripQuery     *bool   `mapstructure:"strip_query" yaml:"strip_query,omitempty" json:"strip_query,omitempty"`
 }
 
-// NewPolicyFromProto creates a new Policy from a protobuf policy config route.
-func NewPolicyFromProto(pb *configpb.Route) (*Policy, error) {
+// NewPolicyFromProto creates a new Policy from a protobuf policy config route. References to named policies are
+// resolved against namedPolicies before the policy is validated.
+func NewPolicyFromProto(pb *configpb.Route, namedPolicies map[string]*PPLPolicy) (*Policy, error) {
 	var timeout *time.Duration
 	if pb.GetTimeout() != nil {
 		t := pb.GetTimeout().AsDuration()
@@ -297,6 +312,17 @@ func NewPolicyFromProto(pb *configpb.Route) (*Policy, error) {
 			Rego:             sp.GetRego(),
 		})
 	}
+
+	if pb.GetPpl() != "" {
+		ppl, err := parser.ParseYAML(strings.NewReader(pb.GetPpl()))
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid ppl policy for %s: %w", p.From, err)
+		}
+		p.Policy = &PPLPolicy{Policy: ppl}
+	}
+	if err := p.ResolvePolicyRefs(namedPolicies); err != nil {
+		return nil, err
+	}
 	return p, p.Validate()
 }
 
@@ -363,6 +389,13 @@ func (p *Policy) ToProto() (*configpb.Route, error) {
 		Policies:                         sps,
 		SetResponseHeaders:               p.SetResponseHeaders,
 	}
+	if p.Policy != nil && p.Policy.Policy != nil {
+		bs, err := p.Policy.Policy.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		pb.Ppl = proto.String(string(bs))
+	}
 	if p.Redirect != nil {
 		pb.Redirect = &configpb.RouteRedirect{
 			HttpsRedirect:  p.Redirect.HTTPSRedirect,
@@ -508,6 +541,25 @@ func (p *Policy) Validate() error {
 		p.compiledRegex, _ = regexp.Compile(rawRE)
 	}
 
+	l := policy.NewLinter(policy.WithNamedPolicies(p.GetNamedPolicies()))
+	for _, ppl := range []struct {
+		name   string
+		policy *PPLPolicy
+	}{
+		{"policy", p.Policy},
+		{"shadow policy", p.ShadowPolicy},
+	} {
+		if ppl.policy == nil || ppl.policy.Policy == nil {
+			continue
+		}
+		for _, problem := range l.Lint(ppl.policy.Policy) {
+			if problem.Severity == linter.SeverityError {
+				return fmt.Errorf("config: invalid %s for %s: %s", ppl.name, p.From, problem.Message)
+			}
+			log.Warn(context.Background()).Str("from", p.From).Msgf("config: %s %s", ppl.name, problem)
+		}
+	}
+
 	return nil
 }
 
@@ -3,11 +3,59 @@ package config
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/pomerium/pomerium/pkg/policy/parser"
 )
 
+// ResolvePolicyRefs sets PolicyRefs to the named policies referenced by the policy and shadow policy, directly or
+// indirectly.
+func (p *Policy) ResolvePolicyRefs(namedPolicies map[string]*PPLPolicy) error {
+	p.PolicyRefs = nil
+
+	refs := map[string]*PPLPolicy{}
+	var names []string
+	for _, ppl := range []*PPLPolicy{p.Policy, p.ShadowPolicy} {
+		if ppl != nil && ppl.Policy != nil {
+			names = append(names, ppl.PolicyRefs()...)
+		}
+	}
+	for len(names) > 0 {
+		name := names[0]
+		names = names[1:]
+		if _, ok := refs[name]; ok {
+			continue
+		}
+
+		ppl, ok := namedPolicies[name]
+		if !ok || ppl == nil || ppl.Policy == nil {
+			return fmt.Errorf("config: unknown named policy %s referenced by %s", name, p.From)
+		}
+		refs[name] = ppl
+		names = append(names, ppl.PolicyRefs()...)
+	}
+	if len(refs) > 0 {
+		p.PolicyRefs = refs
+	}
+	return nil
+}
+
+// GetNamedPolicies returns the named policies referenced by the policy.
+func (p *Policy) GetNamedPolicies() map[string]*parser.Policy {
+	return getNamedPPLPolicies(p.PolicyRefs)
+}
+
+func getNamedPPLPolicies(src map[string]*PPLPolicy) map[string]*parser.Policy {
+	dst := make(map[string]*parser.Policy, len(src))
+	for name, ppl := range src {
+		if ppl != nil && ppl.Policy != nil {
+			dst[name] = ppl.Policy
+		}
+	}
+	return dst
+}
+
 // ToPPL converts a policy into Pomerium Policy Language.
 func (p *Policy) ToPPL() *parser.Policy {
 	ppl := &parser.Policy{}
@@ -21,6 +21,7 @@ import (
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/telemetry"
 	"github.com/pomerium/pomerium/internal/version"
+	"github.com/pomerium/pomerium/internal/webhook"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/grpc/registry"
@@ -32,6 +33,7 @@ import (
 type DataBroker struct {
 	dataBrokerServer *dataBrokerServer
 	manager          *manager.Manager
+	webhook          *webhook.Sink
 
 	localListener                net.Listener
 	localGRPCServer              *grpc.Server
@@ -138,6 +140,9 @@ func (c *DataBroker) Run(ctx context.Context) error {
 	eg.Go(func() error {
 		return c.manager.Run(ctx)
 	})
+	eg.Go(func() error {
+		return c.webhook.Run(ctx)
+	})
 	return eg.Wait()
 }
 
@@ -156,6 +161,24 @@ func (c *DataBroker) update(cfg *config.Config) error {
 		return fmt.Errorf("databroker: failed to create authenticator: %w", err)
 	}
 
+	identityProviderAuthenticators := map[string]manager.Authenticator{}
+	for _, idp := range cfg.Options.GetIdentityProviders() {
+		if idp.Name == config.DefaultIdentityProviderName {
+			identityProviderAuthenticators[idp.Name] = authenticator
+			continue
+		}
+
+		idpOAuthOptions, err := cfg.Options.GetIdentityProviderOauthOptions(idp.Name)
+		if err != nil {
+			return fmt.Errorf("databroker: invalid oauth options for identity provider %s: %w", idp.Name, err)
+		}
+		idpAuthenticator, err := identity.NewAuthenticator(idpOAuthOptions)
+		if err != nil {
+			return fmt.Errorf("databroker: failed to create authenticator for identity provider %s: %w", idp.Name, err)
+		}
+		identityProviderAuthenticators[idp.Name] = idpAuthenticator
+	}
+
 	directoryProvider := directory.GetProvider(directory.Options{
 		ServiceAccount: cfg.Options.ServiceAccount,
 		Provider:       cfg.Options.Provider,
@@ -172,6 +195,7 @@ func (c *DataBroker) update(cfg *config.Config) error {
 
 	options := []manager.Option{
 		manager.WithAuthenticator(authenticator),
+		manager.WithIdentityProviderAuthenticators(identityProviderAuthenticators),
 		manager.WithDirectoryProvider(directoryProvider),
 		manager.WithDataBrokerClient(dataBrokerClient),
 		manager.WithGroupRefreshInterval(cfg.Options.RefreshDirectoryInterval),
@@ -184,6 +208,20 @@ func (c *DataBroker) update(cfg *config.Config) error {
 		c.manager.UpdateConfig(options...)
 	}
 
+	webhookOptions := []webhook.Option{
+		webhook.WithDataBrokerClient(dataBrokerClient),
+		webhook.WithURL(cfg.Options.DataBrokerWebhookURL),
+		webhook.WithSecret([]byte(cfg.Options.DataBrokerWebhookSecret)),
+		webhook.WithTypes(cfg.Options.DataBrokerWebhookTypes),
+		webhook.WithDataTypes(cfg.Options.DataBrokerWebhookDataTypes),
+	}
+
+	if c.webhook == nil {
+		c.webhook = webhook.New(webhookOptions...)
+	} else {
+		c.webhook.UpdateConfig(webhookOptions...)
+	}
+
 	return nil
 }
 
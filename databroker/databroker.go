@@ -36,13 +36,18 @@ func (srv *dataBrokerServer) OnConfigChange(ctx context.Context, cfg *config.Con
 
 func (srv *dataBrokerServer) getOptions(cfg *config.Config) []databroker.ServerOption {
 	cert, _ := cfg.Options.GetDataBrokerCertificate()
+	decryptionKeys, _ := cfg.Options.GetDataBrokerStorageDecryptionKeys()
 	return []databroker.ServerOption{
 		databroker.WithGetSharedKey(cfg.Options.GetSharedKey),
+		databroker.WithStorageDecryptionKeys(decryptionKeys),
 		databroker.WithStorageType(cfg.Options.DataBrokerStorageType),
 		databroker.WithStorageConnectionString(cfg.Options.DataBrokerStorageConnectionString),
 		databroker.WithStorageCAFile(cfg.Options.DataBrokerStorageCAFile),
 		databroker.WithStorageCertificate(cert),
 		databroker.WithStorageCertSkipVerify(cfg.Options.DataBrokerStorageCertSkipVerify),
+		databroker.WithReplicationPeers(cfg.Options.DataBrokerReplicationPeers),
+		databroker.WithReplicationCA(cfg.Options.CA, cfg.Options.CAFile),
+		databroker.WithReplicationOverrideCertificateName(cfg.Options.OverrideCertificateName),
 	}
 }
 
@@ -63,6 +68,13 @@ func (srv *dataBrokerServer) AcquireLease(ctx context.Context, req *databrokerpb
 	return srv.server.AcquireLease(ctx, req)
 }
 
+func (srv *dataBrokerServer) Export(req *databrokerpb.ExportRequest, stream databrokerpb.DataBrokerService_ExportServer) error {
+	if err := grpcutil.RequireSignedJWT(stream.Context(), srv.sharedKey.Load().([]byte)); err != nil {
+		return err
+	}
+	return srv.server.Export(req, stream)
+}
+
 func (srv *dataBrokerServer) Get(ctx context.Context, req *databrokerpb.GetRequest) (*databrokerpb.GetResponse, error) {
 	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
 		return nil, err
@@ -70,6 +82,20 @@ func (srv *dataBrokerServer) Get(ctx context.Context, req *databrokerpb.GetReque
 	return srv.server.Get(ctx, req)
 }
 
+func (srv *dataBrokerServer) GetOptions(ctx context.Context, req *databrokerpb.GetOptionsRequest) (*databrokerpb.GetOptionsResponse, error) {
+	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+		return nil, err
+	}
+	return srv.server.GetOptions(ctx, req)
+}
+
+func (srv *dataBrokerServer) Import(stream databrokerpb.DataBrokerService_ImportServer) error {
+	if err := grpcutil.RequireSignedJWT(stream.Context(), srv.sharedKey.Load().([]byte)); err != nil {
+		return err
+	}
+	return srv.server.Import(stream)
+}
+
 func (srv *dataBrokerServer) Query(ctx context.Context, req *databrokerpb.QueryRequest) (*databrokerpb.QueryResponse, error) {
 	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
 		return nil, err
@@ -84,6 +110,13 @@ func (srv *dataBrokerServer) Put(ctx context.Context, req *databrokerpb.PutReque
 	return srv.server.Put(ctx, req)
 }
 
+func (srv *dataBrokerServer) PutMany(ctx context.Context, req *databrokerpb.PutManyRequest) (*databrokerpb.PutManyResponse, error) {
+	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+		return nil, err
+	}
+	return srv.server.PutMany(ctx, req)
+}
+
 func (srv *dataBrokerServer) ReleaseLease(ctx context.Context, req *databrokerpb.ReleaseLeaseRequest) (*emptypb.Empty, error) {
 	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
 		return nil, err
@@ -138,6 +138,7 @@ module.exports = {
             "identity-providers/okta",
             "identity-providers/one-login",
             "identity-providers/ping",
+            "identity-providers/saml",
           ],
         },
         {
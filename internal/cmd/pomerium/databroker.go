@@ -0,0 +1,232 @@
+package pomerium
+
+import (
+	"context"
+	"crypto/cipher"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// RunDataBroker runs a databroker sub-command:
+//
+//     pomerium -config config.yaml databroker export -output FILE [-key KEY] [-types TYPES]
+//     pomerium -config config.yaml databroker import -input FILE [-key KEY]
+//
+// Export writes every record and record type option stored in the databroker to an archive. Import restores an
+// archive into the databroker, keeping the ids, types and modification times of the records, so an archive can be
+// used to move between storage backends without logging users out. Archives are encrypted when a base64 encoded
+// 32 byte key is given.
+func RunDataBroker(ctx context.Context, configFile string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("databroker: expected a command: export or import")
+	}
+
+	flags := flag.NewFlagSet("databroker "+args[0], flag.ContinueOnError)
+	dataBrokerURL := flags.String("databroker-url", "", "the databroker service url, defaults to the configured url")
+	key := flags.String("key", "", "base64 encoded 32 byte key used to encrypt or decrypt the archive")
+
+	switch args[0] {
+	case "export":
+		output := flags.String("output", "", "the file to write the archive to")
+		types := flags.String("types", "", "comma-separated list of record types to export, defaults to all types")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *output == "" {
+			return fmt.Errorf("databroker: an output file is required")
+		}
+
+		client, c, err := getDataBrokerCommandClients(ctx, configFile, *dataBrokerURL, *key)
+		if err != nil {
+			return err
+		}
+		return exportDataBroker(ctx, client, c, *output, *types)
+	case "import":
+		input := flags.String("input", "", "the file to read the archive from")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *input == "" {
+			return fmt.Errorf("databroker: an input file is required")
+		}
+
+		client, c, err := getDataBrokerCommandClients(ctx, configFile, *dataBrokerURL, *key)
+		if err != nil {
+			return err
+		}
+		return importDataBroker(ctx, client, c, *input)
+	}
+
+	return fmt.Errorf("databroker: unknown command: %s", args[0])
+}
+
+func exportDataBroker(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	c cipher.AEAD,
+	output, types string,
+) error {
+	req := new(databroker.ExportRequest)
+	if types != "" {
+		req.Types = strings.Split(types, ",")
+	}
+
+	stream, err := client.Export(ctx, req)
+	if err != nil {
+		return fmt.Errorf("databroker: error starting export: %w", err)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("databroker: error creating archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	aw, err := databroker.NewArchiveWriter(f, c)
+	if err != nil {
+		return err
+	}
+
+	var recordCount int
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("databroker: error exporting records: %w", err)
+		}
+
+		err = aw.Write(entry)
+		if err != nil {
+			return err
+		}
+		if entry.GetRecord() != nil {
+			recordCount++
+		}
+	}
+
+	err = aw.Close()
+	if err != nil {
+		return fmt.Errorf("databroker: error writing archive: %w", err)
+	}
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("databroker: error writing archive: %w", err)
+	}
+
+	log.Info(ctx).Int("records", recordCount).Msg("databroker: export complete")
+	return nil
+}
+
+func importDataBroker(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	c cipher.AEAD,
+	input string,
+) error {
+	f, err := os.Open(input)
+	if err != nil {
+		return fmt.Errorf("databroker: error opening archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	ar, err := databroker.NewArchiveReader(f, c)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.Import(ctx)
+	if err != nil {
+		return fmt.Errorf("databroker: error starting import: %w", err)
+	}
+
+	for {
+		entry, err := ar.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		err = stream.Send(entry)
+		if err != nil {
+			// the actual error is returned by CloseAndRecv
+			break
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("databroker: error importing records: %w", err)
+	}
+
+	log.Info(ctx).Uint64("records", res.GetRecordCount()).Msg("databroker: import complete")
+	return nil
+}
+
+func getDataBrokerCommandClients(
+	ctx context.Context,
+	configFile, rawDataBrokerURL, key string,
+) (databroker.DataBrokerServiceClient, cipher.AEAD, error) {
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return nil, nil, err
+	}
+	options := src.GetConfig().Options
+
+	var dataBrokerURL *url.URL
+	if rawDataBrokerURL != "" {
+		dataBrokerURL, err = url.Parse(rawDataBrokerURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("databroker: invalid databroker url: %w", err)
+		}
+	} else {
+		dataBrokerURLs, err := options.GetDataBrokerURLs()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(dataBrokerURLs) == 0 {
+			return nil, nil, fmt.Errorf("databroker: no databroker url configured")
+		}
+		dataBrokerURL = dataBrokerURLs[0]
+	}
+
+	sharedKey, err := options.GetSharedKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConn(ctx, &grpcutil.Options{
+		Address:                 dataBrokerURL,
+		OverrideCertificateName: options.OverrideCertificateName,
+		CA:                      options.CA,
+		CAFile:                  options.CAFile,
+		ServiceName:             "databroker-cli",
+		SignedJWTKey:            sharedKey,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("databroker: error connecting to databroker: %w", err)
+	}
+
+	var c cipher.AEAD
+	if key != "" {
+		c, err = cryptutil.NewAEADCipherFromBase64(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("databroker: invalid archive key: %w", err)
+		}
+	}
+
+	return databroker.NewDataBrokerServiceClient(cc), c, nil
+}
@@ -0,0 +1,506 @@
+package pomerium
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/policy"
+	"github.com/pomerium/pomerium/pkg/policy/linter"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+	"github.com/pomerium/pomerium/pkg/webauthnutil"
+)
+
+// RunPolicy runs a policy sub-command:
+//
+//     pomerium -config config.yaml policy test -tests FILE
+//     pomerium -config config.yaml policy lint [-strict] [-groups FILE] [FILE...]
+//
+// Test evaluates the policies of the configuration against the test cases in a YAML file, without connecting to
+// the databroker. Each test case describes a request and the session making it, and the expected decision. The
+// results are written to stdout, and an error is returned if any test case fails.
+//
+// Lint checks the policies of the routes and the named policies in the configuration file, or the given policy files,
+// for mistakes. The problems found are written to stdout, and an error is returned if any of them are errors, or with
+// -strict, warnings. With -groups, the groups criteria are checked against the group ids, names or emails listed in
+// the file, one per line.
+func RunPolicy(ctx context.Context, configFile string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("policy: expected a command: test")
+	}
+
+	flags := flag.NewFlagSet("policy "+args[0], flag.ContinueOnError)
+
+	switch args[0] {
+	case "test":
+		tests := flags.String("tests", "", "the YAML file containing the test cases")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *tests == "" {
+			return fmt.Errorf("policy: a tests file is required")
+		}
+
+		src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+		if err != nil {
+			return err
+		}
+		testCases, err := readPolicyTestCases(*tests)
+		if err != nil {
+			return err
+		}
+		return testPolicy(ctx, os.Stdout, src.GetConfig().Options, testCases)
+	case "lint":
+		strict := flags.Bool("strict", false, "fail on warnings as well as errors")
+		groups := flags.String("groups", "", "a file listing the known group ids, names or emails, one per line")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var knownGroups []string
+		if *groups != "" {
+			var err error
+			knownGroups, err = readKnownGroups(*groups)
+			if err != nil {
+				return err
+			}
+		}
+		return lintPolicy(os.Stdout, configFile, flags.Args(), knownGroups, *strict)
+	}
+
+	return fmt.Errorf("policy: unknown command: %s", args[0])
+}
+
+// policyTestFile is the YAML file of test cases read by the policy test command.
+type policyTestFile struct {
+	Tests []policyTestCase `yaml:"tests"`
+}
+
+// A policyTestCase is a request to evaluate and the expected decision.
+type policyTestCase struct {
+	Name    string                `yaml:"name"`
+	Request policyTestRequest     `yaml:"request"`
+	Session *policyTestSession    `yaml:"session"`
+	Expect  policyTestExpectation `yaml:"expect"`
+}
+
+type policyTestRequest struct {
+	Method            string            `yaml:"method"`
+	URL               string            `yaml:"url"`
+	Headers           map[string]string `yaml:"headers"`
+	IP                string            `yaml:"ip"`
+	ClientCertificate string            `yaml:"client_certificate"`
+}
+
+// A policyTestSession is the session making the request. If it is not set, the request is unauthenticated.
+type policyTestSession struct {
+	ID     string                 `yaml:"id"`
+	User   string                 `yaml:"user"`
+	Email  string                 `yaml:"email"`
+	Claims map[string]interface{} `yaml:"claims"`
+	Groups []string               `yaml:"groups"`
+	Device *policyTestDevice      `yaml:"device"`
+}
+
+// A policyTestDevice is a device registered by the session. A device which isn't enrolled has a credential, but no
+// enrollment.
+type policyTestDevice struct {
+	ID       string `yaml:"id"`
+	Type     string `yaml:"type"`
+	Enrolled bool   `yaml:"enrolled"`
+	Approved bool   `yaml:"approved"`
+}
+
+type policyTestExpectation struct {
+	Allow   bool     `yaml:"allow"`
+	Reasons []string `yaml:"reasons"`
+}
+
+func readPolicyTestCases(name string) ([]policyTestCase, error) {
+	bs, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("policy: error reading tests file: %w", err)
+	}
+
+	var f policyTestFile
+	if err := yaml.Unmarshal(bs, &f); err != nil {
+		return nil, fmt.Errorf("policy: error parsing tests file: %w", err)
+	}
+	if len(f.Tests) == 0 {
+		return nil, fmt.Errorf("policy: no tests found in %s", name)
+	}
+	return f.Tests, nil
+}
+
+func testPolicy(ctx context.Context, w io.Writer, options *config.Options, testCases []policyTestCase) error {
+	failed := 0
+	for i, tc := range testCases {
+		name := tc.Name
+		if name == "" {
+			name = fmt.Sprintf("test %d", i+1)
+		}
+
+		allow, reasons, err := evaluatePolicyTestCase(ctx, options, tc)
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "FAIL %s: %v\n", name, err)
+			continue
+		}
+
+		if allow != tc.Expect.Allow || (tc.Expect.Reasons != nil && !equalReasons(reasons, tc.Expect.Reasons)) {
+			failed++
+			fmt.Fprintf(w, "FAIL %s\n", name)
+			fmt.Fprintf(w, "    expected: %s\n", formatPolicyDecision(tc.Expect.Allow, tc.Expect.Reasons))
+			fmt.Fprintf(w, "    actual:   %s\n", formatPolicyDecision(allow, reasons))
+			continue
+		}
+		fmt.Fprintf(w, "PASS %s: %s\n", name, formatPolicyDecision(allow, reasons))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("policy: %d of %d tests failed", failed, len(testCases))
+	}
+	fmt.Fprintf(w, "%d tests passed\n", len(testCases))
+	return nil
+}
+
+// evaluatePolicyTestCase evaluates a test case, returning whether the request is allowed and the reasons for the
+// decision, in the same way as the authorize service.
+func evaluatePolicyTestCase(ctx context.Context, options *config.Options, tc policyTestCase) (bool, []string, error) {
+	requestURL, err := urlutil.ParseAndValidateURL(tc.Request.URL)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid request url: %w", err)
+	}
+	method := tc.Request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	headers := make(map[string]string, len(tc.Request.Headers))
+	for k, v := range tc.Request.Headers {
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+
+	clientCA, err := options.GetClientCA()
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid client CA: %w", err)
+	}
+	authenticateURL, err := options.GetInternalAuthenticateURL()
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid authenticate url: %w", err)
+	}
+
+	store := evaluator.NewStoreFromProtos(0, tc.Session.toProtos()...)
+	e, err := evaluator.New(ctx, store,
+		evaluator.WithPolicies(options.GetAllPolicies()),
+		evaluator.WithClientCA(clientCA),
+		evaluator.WithSigningKey(options.SigningKey),
+		evaluator.WithAuthenticateURL(authenticateURL.String()),
+		evaluator.WithGoogleCloudServerlessAuthenticationServiceAccount(options.GetGoogleCloudServerlessAuthenticationServiceAccount()),
+		evaluator.WithJWTClaimsHeaders(options.JWTClaimsHeaders),
+	)
+	if err != nil {
+		return false, nil, err
+	}
+
+	req := &evaluator.Request{
+		HTTP: evaluator.NewRequestHTTP(method, *requestURL, headers, tc.Request.ClientCertificate, tc.Request.IP),
+	}
+	if tc.Session != nil {
+		req.Session.ID = tc.Session.getID()
+	}
+	for _, p := range options.GetAllPolicies() {
+		if p.Matches(*requestURL) {
+			p := p
+			req.Policy = &p
+			break
+		}
+	}
+
+	res, err := e.Evaluate(ctx, req)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// as in the authorize service, a deny takes precedence over an allow
+	if res.Deny.Value {
+		return false, res.Deny.Reasons.Strings(), nil
+	}
+	return res.Allow.Value, res.Allow.Reasons.Strings(), nil
+}
+
+func (s *policyTestSession) getID() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return "session"
+}
+
+func (s *policyTestSession) getUserID() string {
+	if s.User != "" {
+		return s.User
+	}
+	return "user"
+}
+
+// toProtos returns the databroker records for the session.
+func (s *policyTestSession) toProtos() []proto.Message {
+	if s == nil {
+		return nil
+	}
+
+	sess := &session.Session{
+		Id:     s.getID(),
+		UserId: s.getUserID(),
+	}
+	sess.AddClaims(identity.Claims(s.Claims).Flatten())
+	msgs := []proto.Message{
+		sess,
+		&user.User{
+			Id:    s.getUserID(),
+			Email: s.Email,
+		},
+		&directory.User{
+			Id:       s.getUserID(),
+			Email:    s.Email,
+			GroupIds: s.Groups,
+		},
+	}
+	// groups are matched by id, name or email, so the group name is the same as the id
+	for _, group := range s.Groups {
+		msgs = append(msgs, &directory.Group{Id: group, Name: group})
+	}
+
+	if s.Device != nil {
+		deviceID := s.Device.ID
+		if deviceID == "" {
+			deviceID = "device"
+		}
+		deviceType := s.Device.Type
+		if deviceType == "" {
+			deviceType = webauthnutil.DefaultDeviceType
+		}
+
+		sess.DeviceCredentials = append(sess.DeviceCredentials, &session.Session_DeviceCredential{
+			TypeId:     deviceType,
+			Credential: &session.Session_DeviceCredential_Id{Id: deviceID},
+		})
+		credential := &device.Credential{
+			Id:     deviceID,
+			TypeId: deviceType,
+			UserId: s.getUserID(),
+		}
+		msgs = append(msgs, credential)
+		if s.Device.Enrolled || s.Device.Approved {
+			credential.EnrollmentId = deviceID + "-enrollment"
+			enrollment := &device.Enrollment{
+				Id:           credential.EnrollmentId,
+				TypeId:       deviceType,
+				CredentialId: deviceID,
+				UserId:       s.getUserID(),
+			}
+			if s.Device.Approved {
+				enrollment.ApprovedBy = s.getUserID()
+			}
+			msgs = append(msgs, enrollment)
+		}
+	}
+
+	return msgs
+}
+
+func equalReasons(actual, expected []string) bool {
+	sorted := append([]string{}, expected...)
+	sort.Strings(sorted)
+	if len(actual) == 0 && len(sorted) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(actual, sorted)
+}
+
+func formatPolicyDecision(allow bool, reasons []string) string {
+	decision := "deny"
+	if allow {
+		decision = "allow"
+	}
+	if len(reasons) == 0 {
+		return decision
+	}
+	return decision + " (" + strings.Join(reasons, ", ") + ")"
+}
+
+// readKnownGroups reads the known groups from a file with one group per line. Empty lines and lines starting with #
+// are ignored.
+func readKnownGroups(name string) ([]string, error) {
+	bs, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("policy: error reading groups file: %w", err)
+	}
+
+	groups := []string{}
+	for _, line := range strings.Split(string(bs), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		groups = append(groups, line)
+	}
+	return groups, nil
+}
+
+// lintPolicy lints the given policy files, or if there are none, the policies of the routes in the config file. The
+// groups criteria are only checked if knownGroups is not nil.
+func lintPolicy(w io.Writer, configFile string, policyFiles []string, knownGroups []string, strict bool) error {
+	var routeNodes []*yaml.Node
+	var namedNodes []namedPolicyNode
+	if configFile != "" {
+		var err error
+		routeNodes, namedNodes, err = readConfigPolicyNodes(configFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// named policies are needed to check the policy_ref criteria, even when linting policy files
+	namedPolicies := make(map[string]*parser.Policy, len(namedNodes))
+	for _, n := range namedNodes {
+		bs, err := yaml.Marshal(n.node)
+		if err != nil {
+			return fmt.Errorf("policy: %s:%d:%d: %w", configFile, n.node.Line, n.node.Column, err)
+		}
+		p, err := parser.ParseYAML(bytes.NewReader(bs))
+		if err != nil {
+			return fmt.Errorf("policy: %s:%d:%d: %w", configFile, n.node.Line, n.node.Column, err)
+		}
+		namedPolicies[n.name] = p
+	}
+	var lOpts []linter.Option
+	if knownGroups != nil {
+		lOpts = append(lOpts, linter.WithKnownGroups(knownGroups))
+	}
+	l := linter.New(policy.NewGenerator(policy.WithNamedPolicies(namedPolicies)), lOpts...)
+
+	var problems, failures int
+	report := func(name string, ps []linter.Problem) {
+		for _, p := range ps {
+			problems++
+			if p.Severity == linter.SeverityError || strict {
+				failures++
+			}
+			fmt.Fprintf(w, "%s:%s\n", name, p)
+		}
+	}
+
+	if len(policyFiles) == 0 {
+		if configFile == "" {
+			return fmt.Errorf("policy: a config file or policy files are required")
+		}
+		nodes := routeNodes
+		for _, n := range namedNodes {
+			nodes = append(nodes, n.node)
+		}
+		for _, node := range nodes {
+			ps, err := l.LintYAMLNode(node)
+			if err != nil {
+				return fmt.Errorf("policy: %s:%d:%d: %w", configFile, node.Line, node.Column, err)
+			}
+			report(configFile, ps)
+		}
+	}
+
+	for _, name := range policyFiles {
+		f, err := os.Open(name)
+		if err != nil {
+			return fmt.Errorf("policy: error reading policy file: %w", err)
+		}
+		ps, err := l.LintYAML(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("policy: %s: %w", name, err)
+		}
+		report(name, ps)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("policy: found %d problems", problems)
+	}
+	return nil
+}
+
+type namedPolicyNode struct {
+	name string
+	node *yaml.Node
+}
+
+// readConfigPolicyNodes returns the YAML nodes of the PPL policies of the routes in a config file, and of its named
+// policies, so that problems can be reported with their position in the file.
+func readConfigPolicyNodes(configFile string) ([]*yaml.Node, []namedPolicyNode, error) {
+	bs, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("policy: error reading config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return nil, nil, fmt.Errorf("policy: error parsing config file: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil, nil
+	}
+
+	var routeNodes []*yaml.Node
+	// routes can be defined using either "routes" or the older "policy"
+	for _, routes := range []*yaml.Node{getYAMLMappingValue(doc.Content[0], "routes"), getYAMLMappingValue(doc.Content[0], "policy")} {
+		if routes == nil || routes.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, route := range routes.Content {
+			for _, key := range []string{"policy", "shadow_policy"} {
+				if node := getYAMLMappingValue(route, key); node != nil {
+					routeNodes = append(routeNodes, node)
+				}
+			}
+		}
+	}
+
+	var namedNodes []namedPolicyNode
+	if policies := getYAMLMappingValue(doc.Content[0], "policies"); policies != nil && policies.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(policies.Content); i += 2 {
+			namedNodes = append(namedNodes, namedPolicyNode{
+				name: policies.Content[i].Value,
+				node: policies.Content[i+1],
+			})
+		}
+	}
+	return routeNodes, namedNodes, nil
+}
+
+func getYAMLMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
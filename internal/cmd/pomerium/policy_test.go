@@ -0,0 +1,210 @@
+package pomerium
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+)
+
+func TestRunPolicy(t *testing.T) {
+	os.Clearenv()
+	dir := t.TempDir()
+
+	configFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+authenticate_service_url: https://authenticate.example.com
+shared_secret: YixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM=
+cookie_secret: zixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM=
+routes:
+  - from: https://from.example.com
+    to: https://to.example.com
+    policy:
+      - allow:
+          or:
+            - groups:
+                has: admins
+            - claim/department: engineering
+      - deny:
+          and:
+            - http_path:
+                starts_with: /admin
+            - device:
+                approved: false
+`), 0o600))
+
+	writeTests := func(t *testing.T, tests string) string {
+		testsFile := filepath.Join(t.TempDir(), "tests.yaml")
+		require.NoError(t, os.WriteFile(testsFile, []byte(tests), 0o600))
+		return testsFile
+	}
+
+	t.Run("pass", func(t *testing.T) {
+		testsFile := writeTests(t, `
+tests:
+  - name: unauthenticated
+    request:
+      url: https://from.example.com/
+    expect:
+      allow: false
+      reasons: [non-pomerium-route, user-unauthenticated]
+  - name: group
+    request:
+      url: https://from.example.com/
+    session:
+      user: u1
+      groups: [admins]
+    expect:
+      allow: true
+      reasons: [groups-ok]
+  - name: claim
+    request:
+      method: POST
+      url: https://from.example.com/
+    session:
+      claims:
+        department: engineering
+    expect:
+      allow: true
+  - name: unapproved device
+    request:
+      url: https://from.example.com/admin
+    session:
+      groups: [admins]
+      device:
+        enrolled: true
+    expect:
+      allow: false
+      reasons: [device-ok, http-path-ok]
+  - name: approved device
+    request:
+      url: https://from.example.com/admin
+    session:
+      groups: [admins]
+      device:
+        approved: true
+    expect:
+      allow: true
+`)
+		err := RunPolicy(context.Background(), configFile, []string{"test", "-tests", testsFile})
+		assert.NoError(t, err)
+	})
+	t.Run("fail", func(t *testing.T) {
+		testCases, err := readPolicyTestCases(writeTests(t, `
+tests:
+  - name: wrong group
+    request:
+      url: https://from.example.com/
+    session:
+      groups: [users]
+    expect:
+      allow: true
+`))
+		require.NoError(t, err)
+
+		src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		err = testPolicy(context.Background(), &buf, src.GetConfig().Options, testCases)
+		assert.EqualError(t, err, "policy: 1 of 1 tests failed")
+		assert.Equal(t, `FAIL wrong group
+    expected: allow
+    actual:   deny (claim-unauthorized, groups-unauthorized, non-pomerium-route)
+`, buf.String())
+	})
+}
+
+func TestLintPolicy(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`routes:
+  - from: https://from.example.com
+    to: https://to.example.com
+    policy:
+      allow:
+        or:
+          - emial:
+              is: user@example.com
+  - from: https://other.example.com
+    to: https://to.example.com
+    policy:
+      - deny:
+          or:
+            - accept: true
+`), 0o600))
+
+	var buf bytes.Buffer
+	err := lintPolicy(&buf, configFile, nil, nil, false)
+	assert.EqualError(t, err, "policy: found 2 problems")
+	assert.Equal(t, configFile+`:7:13: error: unknown criterion "emial"
+`+configFile+`:12:9: warning: deny rule always matches, so every request is denied
+`, buf.String())
+
+	policyFile := filepath.Join(dir, "policy.yaml")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`deny:
+  or:
+    - accept: true
+`), 0o600))
+
+	buf.Reset()
+	assert.NoError(t, lintPolicy(&buf, configFile, []string{policyFile}, nil, false),
+		"warnings should not fail")
+	assert.EqualError(t, lintPolicy(&buf, configFile, []string{policyFile}, nil, true),
+		"policy: found 1 problems", "warnings should fail when strict")
+
+	t.Run("known groups", func(t *testing.T) {
+		policyFile := filepath.Join(dir, "groups.yaml")
+		require.NoError(t, os.WriteFile(policyFile, []byte(`allow:
+  or:
+    - groups:
+        has: admins
+    - groups:
+        has: editors
+`), 0o600))
+		groupsFile := filepath.Join(dir, "groups.txt")
+		require.NoError(t, os.WriteFile(groupsFile, []byte("# group ids\n\nadmins\n"), 0o600))
+
+		knownGroups, err := readKnownGroups(groupsFile)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admins"}, knownGroups)
+
+		var buf bytes.Buffer
+		assert.NoError(t, lintPolicy(&buf, "", []string{policyFile}, nil, true))
+		assert.EqualError(t, lintPolicy(&buf, "", []string{policyFile}, knownGroups, true),
+			"policy: found 1 problems")
+		assert.Equal(t, policyFile+`:5:7: warning: groups criterion refers to unknown group "editors"
+`, buf.String())
+	})
+	t.Run("named policies", func(t *testing.T) {
+		configFile := filepath.Join(dir, "named.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`routes:
+  - from: https://from.example.com
+    to: https://to.example.com
+    policy:
+      allow:
+        or:
+          - policy_ref: admins
+          - policy_ref: editors
+policies:
+  admins:
+    allow:
+      or:
+        - groups:
+            has: admins
+`), 0o600))
+
+		var buf bytes.Buffer
+		err := lintPolicy(&buf, configFile, nil, nil, false)
+		assert.EqualError(t, err, "policy: found 1 problems")
+		assert.Equal(t, configFile+`:8:13: error: unknown named policy "editors"
+`, buf.String())
+	})
+}
@@ -24,6 +24,7 @@ var (
 type serverConfig struct {
 	deletePermanentlyAfter  time.Duration
 	secret                  []byte
+	decryptionKeys          [][]byte
 	storageType             string
 	storageConnectionString string
 	storageCAFile           string
@@ -31,6 +32,11 @@ type serverConfig struct {
 	storageCertificate      *tls.Certificate
 	getAllPageSize          int
 	registryTTL             time.Duration
+
+	replicationPeers                   []string
+	replicationCA                      string
+	replicationCAFile                  string
+	replicationOverrideCertificateName string
 }
 
 func newServerConfig(options ...ServerOption) *serverConfig {
@@ -83,6 +89,36 @@ func WithGetSharedKey(getSharedKey func() ([]byte, error)) ServerOption {
 	}
 }
 
+// WithStorageDecryptionKeys sets the keys used to decrypt records encrypted with a previous secret.
+func WithStorageDecryptionKeys(keys [][]byte) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.decryptionKeys = keys
+	}
+}
+
+// WithReplicationPeers sets the URLs of the databroker replicas, including this one, which replicate the in-memory
+// storage between them.
+func WithReplicationPeers(peers []string) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.replicationPeers = peers
+	}
+}
+
+// WithReplicationCA sets the certificate authority used to connect to the replication peers.
+func WithReplicationCA(ca, caFile string) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.replicationCA = ca
+		cfg.replicationCAFile = caFile
+	}
+}
+
+// WithReplicationOverrideCertificateName overrides the certificate name used to verify the replication peers.
+func WithReplicationOverrideCertificateName(name string) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.replicationOverrideCertificateName = name
+	}
+}
+
 // WithStorageType sets the storage type.
 func WithStorageType(typ string) ServerOption {
 	return func(cfg *serverConfig) {
@@ -103,7 +103,7 @@ func (src *ConfigSource) rebuild(ctx context.Context, firstTime firstTime) {
 		}
 
 		for _, routepb := range cfgpb.GetRoutes() {
-			policy, err := config.NewPolicyFromProto(routepb)
+			policy, err := config.NewPolicyFromProto(routepb, cfg.Options.NamedPolicies)
 			if err != nil {
 				errCount++
 				log.Warn(ctx).Err(err).
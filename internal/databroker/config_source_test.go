@@ -9,10 +9,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/pomerium/pomerium/config"
 	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
 	"github.com/pomerium/pomerium/pkg/protoutil"
 )
 
@@ -44,6 +46,12 @@ func TestConfigSource(t *testing.T) {
 			{URL: *u},
 		}, AllowedUsers: []string{"foo@bar.com"},
 	})
+	base.NamedPolicies = map[string]*config.PPLPolicy{
+		"admins": {Policy: &parser.Policy{Rules: []parser.Rule{{
+			Action: parser.ActionAllow,
+			Or:     []parser.Criterion{{Name: "email", Data: parser.Object{"is": parser.String("admin@example.com")}}},
+		}}}},
+	}
 
 	baseSource := config.NewStaticSource(&config.Config{
 		OutboundPort: outboundPort,
@@ -61,6 +69,11 @@ func TestConfigSource(t *testing.T) {
 				From: "https://from.example.com",
 				To:   []string{"https://to.example.com"},
 			},
+			{
+				From: "https://admin.example.com",
+				To:   []string{"https://to.example.com"},
+				Ppl:  proto.String(`[{"allow":{"and":[{"policy_ref":"admins"}]}}]`),
+			},
 		},
 	})
 	_, _ = dataBrokerServer.Put(ctx, &databroker.PutRequest{
@@ -84,7 +97,9 @@ func TestConfigSource(t *testing.T) {
 		assert.NoError(t, ctx.Err())
 		return
 	case cfg := <-cfgs:
-		assert.Len(t, cfg.Options.AdditionalPolicies, 1)
+		if assert.Len(t, cfg.Options.AdditionalPolicies, 2, "should resolve named policies") {
+			assert.Contains(t, cfg.Options.AdditionalPolicies[1].PolicyRefs, "admins")
+		}
 	}
 
 	baseSource.SetConfig(ctx, &config.Config{
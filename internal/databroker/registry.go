@@ -11,6 +11,7 @@ import (
 	"github.com/pomerium/pomerium/internal/registry/redis"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	registrypb "github.com/pomerium/pomerium/pkg/grpc/registry"
+	"github.com/pomerium/pomerium/pkg/storage/postgres"
 )
 
 type registryWatchServer struct {
@@ -90,7 +91,7 @@ func (srv *Server) newRegistryLocked() (registry.Interface, error) {
 	ctx := context.Background()
 
 	switch srv.cfg.storageType {
-	case config.StorageInMemoryName:
+	case config.StorageInMemoryName, config.StorageFileName:
 		log.Info(ctx).Msg("using in-memory registry")
 		return inmemory.New(ctx, srv.cfg.registryTTL), nil
 	case config.StorageRedisName:
@@ -103,6 +104,16 @@ func (srv *Server) newRegistryLocked() (registry.Interface, error) {
 			return nil, fmt.Errorf("failed to create new redis registry: %w", err)
 		}
 		return r, nil
+	case config.StoragePostgresName:
+		log.Info(ctx).Msg("using postgres registry")
+		r, err := postgres.New(
+			srv.cfg.storageConnectionString,
+			postgres.WithRegistryTTL(srv.cfg.registryTTL),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new postgres registry: %w", err)
+		}
+		return r, nil
 	}
 
 	return nil, fmt.Errorf("unsupported registry type: %s", srv.cfg.storageType)
@@ -0,0 +1,765 @@
+package databroker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+	"github.com/pomerium/pomerium/pkg/storage"
+	"github.com/pomerium/pomerium/pkg/storage/inmemory"
+)
+
+const (
+	replicationLeaseName       = "pomerium/databroker-replication"
+	replicationLeaseTTL        = 15 * time.Second
+	replicationRenewInterval   = replicationLeaseTTL / 3
+	replicationRetryInterval   = time.Second
+	replicationOptionsInterval = 30 * time.Second
+	// replicationMetadataKey marks requests sent by another replica. They are only handled by the leader.
+	replicationMetadataKey = "x-pomerium-databroker-replication"
+	// replicationStateMetadataKey carries the replication state of a replica, so replicas can tell which of them
+	// has the newest records.
+	replicationStateMetadataKey = "x-pomerium-databroker-replication-state"
+)
+
+var (
+	errNotLeader = status.Error(codes.Unavailable, "databroker replica is not the leader")
+	errNoLeader  = status.Error(codes.Unavailable, "no databroker leader has been elected")
+	errOlderData = status.Error(codes.FailedPrecondition, "databroker replica has older data than this replica")
+)
+
+// A replicationState describes how new the records of a replica are. The term is incremented every time a leader
+// is elected, and followers take on the term of their leader. Record versions can only be compared between replicas
+// with the same server version, that is, replicas of the same leader.
+type replicationState struct {
+	term          uint64
+	serverVersion uint64
+	recordVersion uint64
+}
+
+// parseReplicationState parses a replication state formatted with String.
+func parseReplicationState(raw string) (replicationState, bool) {
+	var state replicationState
+	_, err := fmt.Sscanf(raw, "%d/%d/%d", &state.term, &state.serverVersion, &state.recordVersion)
+	return state, err == nil
+}
+
+// newerThan returns true if the replica has records which the other replica doesn't have.
+func (state replicationState) newerThan(other replicationState) bool {
+	if state.term != other.term {
+		return state.term > other.term
+	}
+	return state.serverVersion == other.serverVersion && state.recordVersion > other.recordVersion
+}
+
+// String formats the replication state for the replication state metadata.
+func (state replicationState) String() string {
+	return fmt.Sprintf("%d/%d/%d", state.term, state.serverVersion, state.recordVersion)
+}
+
+// A replicator replicates the in-memory storage between databroker replicas.
+//
+// One of the replicas is elected leader by acquiring the replication lease from a majority of the replicas, itself
+// included. The leader accepts writes. The other replicas follow the leader: they copy its records with SyncLatest
+// and Sync, serve reads from their copy and forward writes to the leader.
+//
+// Replication is asynchronous, so writes which haven't been replicated yet are lost if the leader fails. A new
+// leader starts with a new server version, so clients re-sync from the new leader.
+//
+// Candidates send their replication state with the lease requests. A replica refuses the lease to a candidate with
+// older records than its own, and a candidate stops campaigning, or leading, once a replica with newer records
+// refuses it, so the leadership goes to the replica with the newest records. Otherwise a replica restarted with an
+// empty backend could become the leader and the other replicas would replace their records with its empty copy.
+type replicator struct {
+	srv    *Server
+	peers  []databroker.DataBrokerServiceClient
+	conns  []*grpc.ClientConn
+	votes  *inmemory.Backend
+	cancel context.CancelFunc
+
+	mu sync.Mutex
+	// term is the term of the leader this replica leads or follows
+	term uint64
+	// leaderCtx is set while this replica is the leader
+	leaderCtx context.Context
+	stepDown  context.CancelFunc
+	// leader, replica and options are set while this replica follows the leader
+	leader  databroker.DataBrokerServiceClient
+	replica *inmemory.Backend
+	options map[string]*databroker.Options
+}
+
+func newReplicator(srv *Server, cfg *serverConfig) (*replicator, error) {
+	r := &replicator{
+		srv:   srv,
+		votes: inmemory.New(),
+	}
+	for _, rawPeer := range cfg.replicationPeers {
+		peer, err := urlutil.ParseAndValidateURL(rawPeer)
+		if err != nil {
+			r.close()
+			return nil, fmt.Errorf("invalid replication peer: %w", err)
+		}
+
+		cc, err := grpcutil.NewGRPCClientConn(context.Background(), &grpcutil.Options{
+			Address:                 peer,
+			OverrideCertificateName: cfg.replicationOverrideCertificateName,
+			CA:                      cfg.replicationCA,
+			CAFile:                  cfg.replicationCAFile,
+			ServiceName:             "databroker-replication",
+			SignedJWTKey:            cfg.secret,
+		}, grpc.WithDefaultCallOptions(grpc.WaitForReady(false)))
+		if err != nil {
+			r.close()
+			return nil, fmt.Errorf("error connecting to replication peer: %w", err)
+		}
+		r.conns = append(r.conns, cc)
+		r.peers = append(r.peers, databroker.NewDataBrokerServiceClient(cc))
+	}
+
+	var ctx context.Context
+	ctx, r.cancel = context.WithCancel(context.Background())
+	go r.run(ctx)
+	return r, nil
+}
+
+// stop stops replicating. It must be called with the server lock held, so that the replicator can't change the
+// server's backend afterwards.
+func (r *replicator) stop() {
+	r.cancel()
+}
+
+func (r *replicator) close() {
+	for _, cc := range r.conns {
+		_ = cc.Close()
+	}
+	_ = r.votes.Close()
+}
+
+func (r *replicator) run(ctx context.Context) {
+	defer r.close()
+
+	for {
+		following := false
+		for _, peer := range r.peers {
+			followed, err := r.follow(ctx, peer)
+			if followed {
+				following = true
+				if ctx.Err() == nil {
+					log.Warn(ctx).Err(err).Msg("databroker: stopped following the leader")
+				}
+				break
+			}
+			log.Debug(ctx).Err(err).Msg("databroker: replication peer is not the leader")
+		}
+
+		if !following {
+			r.lead(ctx)
+		}
+
+		// wait a random amount of time, so replicas don't campaign at the same time
+		jitter := time.Duration(cryptutil.NewRandomUInt64() % uint64(replicationRetryInterval))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(replicationRetryInterval + jitter):
+		}
+	}
+}
+
+// follow replicates the records of the leader until replication fails. It returns false if the peer isn't the
+// leader.
+func (r *replicator) follow(ctx context.Context, leader databroker.DataBrokerServiceClient) (bool, error) {
+	ctx, cancel := context.WithCancel(withReplication(ctx))
+	defer cancel()
+
+	records, versions, leaderTerm, err := r.syncLatest(ctx, leader)
+	if err != nil {
+		return false, err
+	}
+
+	// never replace newer records with an older copy, the leader should be replaced instead
+	leaderState := replicationState{
+		term:          leaderTerm,
+		serverVersion: versions.GetServerVersion(),
+		recordVersion: versions.GetLatestRecordVersion(),
+	}
+	if state := r.getState(); state.newerThan(leaderState) {
+		log.Warn(ctx).
+			Str("state", state.String()).
+			Str("leader_state", leaderState.String()).
+			Msg("databroker: not following a leader with older records")
+		return false, errOlderData
+	}
+
+	options := map[string]*databroker.Options{}
+	for _, record := range records {
+		if _, ok := options[record.GetType()]; ok {
+			continue
+		}
+		options[record.GetType()], err = r.getOptions(ctx, leader, record.GetType())
+		if err != nil {
+			return false, err
+		}
+	}
+
+	replica := inmemory.New(inmemory.WithServerVersion(versions.GetServerVersion()))
+	replica.Replicate(ctx, versions.GetLatestRecordVersion(), records...)
+	if !r.startFollowing(ctx, leader, leaderTerm, replica, options) {
+		_ = replica.Close()
+		return false, ctx.Err()
+	}
+	defer r.stopFollowing()
+
+	log.Info(ctx).
+		Uint64("server_version", versions.GetServerVersion()).
+		Uint64("record_version", versions.GetLatestRecordVersion()).
+		Msg("databroker: following the leader")
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		stream, err := leader.Sync(ctx, &databroker.SyncRequest{
+			ServerVersion: versions.GetServerVersion(),
+			RecordVersion: versions.GetLatestRecordVersion(),
+		})
+		if err != nil {
+			return err
+		}
+
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+
+			record := res.GetRecord()
+			if !r.hasOptions(record.GetType()) {
+				recordOptions, err := r.getOptions(ctx, leader, record.GetType())
+				if err != nil {
+					return err
+				}
+				r.setOptions(record.GetType(), recordOptions)
+			}
+			replica.Replicate(ctx, 0, record)
+		}
+	})
+	eg.Go(func() error {
+		ticker := time.NewTicker(replicationOptionsInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+
+			for _, recordType := range r.getOptionTypes() {
+				recordOptions, err := r.getOptions(ctx, leader, recordType)
+				if err != nil {
+					return err
+				}
+				r.setOptions(recordType, recordOptions)
+			}
+		}
+	})
+	return true, eg.Wait()
+}
+
+// syncLatest returns the records of the leader, along with its versions and term.
+func (r *replicator) syncLatest(
+	ctx context.Context,
+	leader databroker.DataBrokerServiceClient,
+) ([]*databroker.Record, *databroker.Versions, uint64, error) {
+	stream, err := leader.SyncLatest(ctx, &databroker.SyncLatestRequest{})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	header, err := stream.Header()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var leaderState replicationState
+	if values := header.Get(replicationStateMetadataKey); len(values) > 0 {
+		leaderState, _ = parseReplicationState(values[0])
+	}
+
+	var records []*databroker.Record
+	var versions *databroker.Versions
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, 0, err
+		}
+
+		switch res := res.GetResponse().(type) {
+		case *databroker.SyncLatestResponse_Record:
+			records = append(records, res.Record)
+		case *databroker.SyncLatestResponse_Versions:
+			versions = res.Versions
+		}
+	}
+	if versions == nil {
+		return nil, nil, 0, fmt.Errorf("databroker: no versions returned by the leader")
+	}
+	return records, versions, leaderState.term, nil
+}
+
+func (r *replicator) getOptions(
+	ctx context.Context,
+	leader databroker.DataBrokerServiceClient,
+	recordType string,
+) (*databroker.Options, error) {
+	res, err := leader.GetOptions(ctx, &databroker.GetOptionsRequest{Type: recordType})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetOptions(), nil
+}
+
+// startFollowing replaces the server's backend with the replica of the leader's records.
+func (r *replicator) startFollowing(
+	ctx context.Context,
+	leader databroker.DataBrokerServiceClient,
+	leaderTerm uint64,
+	replica *inmemory.Backend,
+	options map[string]*databroker.Options,
+) bool {
+	r.srv.mu.Lock()
+	defer r.srv.mu.Unlock()
+
+	// the replicator was stopped
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if r.srv.backend != nil {
+		_ = r.srv.backend.Close()
+	}
+	r.srv.backend = replica
+
+	r.mu.Lock()
+	r.term = leaderTerm
+	r.leader = leader
+	r.replica = replica
+	r.options = options
+	r.mu.Unlock()
+
+	return true
+}
+
+// stopFollowing stops forwarding writes to the leader. Reads are still served from the replica until another
+// leader is followed, or this replica becomes the leader.
+func (r *replicator) stopFollowing() {
+	r.mu.Lock()
+	r.leader = nil
+	r.mu.Unlock()
+}
+
+func (r *replicator) hasOptions(recordType string) bool {
+	r.mu.Lock()
+	_, ok := r.options[recordType]
+	r.mu.Unlock()
+	return ok
+}
+
+func (r *replicator) getOptionTypes() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recordTypes := make([]string, 0, len(r.options))
+	for recordType := range r.options {
+		recordTypes = append(recordTypes, recordType)
+	}
+	return recordTypes
+}
+
+func (r *replicator) setOptions(recordType string, options *databroker.Options) {
+	r.mu.Lock()
+	r.options[recordType] = options
+	r.mu.Unlock()
+}
+
+// lead campaigns to become the leader, and leads for as long as it holds the replication lease of a majority of
+// the replicas and none of the replicas has newer records.
+func (r *replicator) lead(ctx context.Context) {
+	leaseIDs := make([]string, len(r.peers))
+	defer func() {
+		r.stopLeading(ctx)
+		r.releaseLeases(leaseIDs)
+	}()
+
+	ticker := time.NewTicker(replicationRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		held, maxTerm, err := r.renewLeases(ctx, leaseIDs)
+		if err != nil {
+			log.Info(ctx).Err(err).Msg("databroker: a replica with newer records should be the leader")
+			return
+		} else if held <= len(r.peers)/2 {
+			return
+		}
+
+		if !r.isLeader() {
+			err := r.startLeading(ctx, maxTerm)
+			if err != nil {
+				log.Error(ctx).Err(err).Msg("databroker: error becoming the leader")
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renewLeases acquires or renews the replication lease from every replica, sending this replica's replication
+// state along. It returns the number of replicas the lease is held for and the highest term of the replicas, or
+// errOlderData if a replica has newer records.
+func (r *replicator) renewLeases(ctx context.Context, leaseIDs []string) (held int, maxTerm uint64, err error) {
+	ctx, cancel := context.WithTimeout(ctx, replicationRenewInterval)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, replicationStateMetadataKey, r.getState().String())
+
+	results := make([]leaseResult, len(r.peers))
+	var wg sync.WaitGroup
+	for i := range r.peers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = r.renewLease(ctx, r.peers[i], leaseIDs[i])
+			leaseIDs[i] = results[i].leaseID
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if result.held {
+			held++
+		}
+		if result.term > maxTerm {
+			maxTerm = result.term
+		}
+		if status.Code(result.err) == codes.FailedPrecondition {
+			err = result.err
+		}
+	}
+	return held, maxTerm, err
+}
+
+type leaseResult struct {
+	leaseID string
+	held    bool
+	// term is the term of the replica
+	term uint64
+	err  error
+}
+
+func (r *replicator) renewLease(
+	ctx context.Context,
+	peer databroker.DataBrokerServiceClient,
+	leaseID string,
+) leaseResult {
+	var trailer metadata.MD
+	var result leaseResult
+	defer func() {
+		if values := trailer.Get(replicationStateMetadataKey); len(values) > 0 {
+			state, _ := parseReplicationState(values[0])
+			result.term = state.term
+		}
+	}()
+
+	if leaseID == "" {
+		var res *databroker.AcquireLeaseResponse
+		res, result.err = peer.AcquireLease(ctx, &databroker.AcquireLeaseRequest{
+			Name:     replicationLeaseName,
+			Duration: durationpb.New(replicationLeaseTTL),
+		}, grpc.Trailer(&trailer))
+		result.leaseID, result.held = res.GetId(), result.err == nil
+		return result
+	}
+
+	_, result.err = peer.RenewLease(ctx, &databroker.RenewLeaseRequest{
+		Name:     replicationLeaseName,
+		Id:       leaseID,
+		Duration: durationpb.New(replicationLeaseTTL),
+	}, grpc.Trailer(&trailer))
+	if status.Code(result.err) != codes.AlreadyExists {
+		// the lease wasn't lost, so it can be renewed again
+		result.leaseID = leaseID
+	}
+	result.held = result.err == nil
+	return result
+}
+
+// releaseLeases releases the replication leases, so another replica can become the leader right away.
+func (r *replicator) releaseLeases(leaseIDs []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), replicationRenewInterval)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range r.peers {
+		if leaseIDs[i] == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = r.peers[i].ReleaseLease(ctx, &databroker.ReleaseLeaseRequest{
+				Name: replicationLeaseName,
+				Id:   leaseIDs[i],
+			})
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (r *replicator) isLeader() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.leaderCtx != nil
+}
+
+// startLeading makes this replica the leader, with a term after the highest term of the replicas. If the server is
+// serving a replica of the previous leader, its records are copied into a new backend, so the records keep their
+// modification times but get new versions.
+func (r *replicator) startLeading(ctx context.Context, maxTerm uint64) error {
+	r.srv.mu.Lock()
+	defer r.srv.mu.Unlock()
+
+	// the replicator was stopped
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.replica != nil && r.srv.backend == storage.Backend(r.replica) {
+		backend, err := r.srv.newBackendLocked()
+		if err != nil {
+			return err
+		}
+
+		err = copyReplica(ctx, backend, r.replica, r.options)
+		if err != nil {
+			_ = backend.Close()
+			return err
+		}
+
+		_ = r.replica.Close()
+		r.srv.backend = backend
+	}
+	r.replica = nil
+	r.options = nil
+
+	if maxTerm < r.term {
+		maxTerm = r.term
+	}
+	r.term = maxTerm + 1
+	r.leaderCtx, r.stepDown = context.WithCancel(ctx)
+	log.Info(ctx).Uint64("term", r.term).Msg("databroker: elected leader")
+	return nil
+}
+
+// getState returns the replication state of this replica. A replica without a backend has no records.
+func (r *replicator) getState() replicationState {
+	r.srv.mu.RLock()
+	defer r.srv.mu.RUnlock()
+
+	r.mu.Lock()
+	state := replicationState{term: r.term}
+	r.mu.Unlock()
+
+	if backend, ok := r.srv.backend.(*inmemory.Backend); ok {
+		versions := backend.Versions()
+		state.serverVersion = versions.GetServerVersion()
+		state.recordVersion = versions.GetLatestRecordVersion()
+	}
+	return state
+}
+
+func (r *replicator) stopLeading(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stepDown == nil {
+		return
+	}
+	r.stepDown()
+	r.leaderCtx, r.stepDown = nil, nil
+	log.Info(ctx).Msg("databroker: no longer the leader")
+}
+
+func copyReplica(
+	ctx context.Context,
+	dst storage.Backend,
+	src *inmemory.Backend,
+	options map[string]*databroker.Options,
+) error {
+	for recordType, recordOptions := range options {
+		err := dst.SetOptions(ctx, recordType, recordOptions)
+		if err != nil {
+			return err
+		}
+	}
+
+	records, _, err := src.GetAll(ctx)
+	if err != nil || len(records) == 0 {
+		return err
+	}
+
+	_, err = dst.PutMany(ctx, records, storage.PutOptions{KeepModifiedAt: true})
+	return err
+}
+
+// getLeader returns the leader a request should be forwarded to, or nil if the request should be handled by this
+// replica.
+func (r *replicator) getLeader(ctx context.Context) (databroker.DataBrokerServiceClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case r.leaderCtx != nil:
+		return nil, nil
+	case isReplicationRequest(ctx):
+		// requests from other replicas are never forwarded again
+		return nil, errNotLeader
+	case r.leader == nil:
+		return nil, errNoLeader
+	}
+	return r.leader, nil
+}
+
+// getLeaderContext returns a context which is canceled once this replica stops being the leader.
+func (r *replicator) getLeaderContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	r.mu.Lock()
+	leaderCtx := r.leaderCtx
+	r.mu.Unlock()
+
+	if leaderCtx == nil {
+		return nil, nil, errNotLeader
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-leaderCtx.Done():
+			cancel()
+		}
+	}()
+	return ctx, cancel, nil
+}
+
+func (srv *Server) getReplicator() *replicator {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+
+	return srv.replicator
+}
+
+// getLeader returns the leader a request should be forwarded to, or nil if the request should be handled by this
+// server.
+func (srv *Server) getLeader(ctx context.Context) (databroker.DataBrokerServiceClient, error) {
+	r := srv.getReplicator()
+	if r == nil {
+		return nil, nil
+	}
+	return r.getLeader(ctx)
+}
+
+// getLeaseBackend returns the backend which holds the given lease, or the leader the lease request should be
+// forwarded to. The replication lease is always held by the replica itself.
+func (srv *Server) getLeaseBackend(ctx context.Context, leaseName string) (storage.Backend, databroker.DataBrokerServiceClient, error) {
+	if r := srv.getReplicator(); r != nil && leaseName == replicationLeaseName {
+		return r.votes, nil, nil
+	}
+
+	leader, err := srv.getLeader(ctx)
+	if err != nil || leader != nil {
+		return nil, leader, err
+	}
+
+	backend, err := srv.getBackend()
+	return backend, nil, err
+}
+
+// checkReplicationCandidate is called before granting or renewing a lease. The replication lease is refused to a
+// candidate with older records than this replica. This replica's replication state is sent back in the trailer, so
+// the candidate can pick a term after every replica's term.
+func (srv *Server) checkReplicationCandidate(ctx context.Context, leaseName string) error {
+	r := srv.getReplicator()
+	if r == nil || leaseName != replicationLeaseName {
+		return nil
+	}
+
+	state := r.getState()
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(replicationStateMetadataKey, state.String()))
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(replicationStateMetadataKey)
+	if len(values) == 0 {
+		return nil
+	}
+	candidate, ok := parseReplicationState(values[0])
+	if !ok {
+		return status.Error(codes.InvalidArgument, "invalid replication state")
+	}
+	if state.newerThan(candidate) {
+		return errOlderData
+	}
+	return nil
+}
+
+// setReplicationHeader sends this replica's replication state to a replica about to follow it.
+func (srv *Server) setReplicationHeader(ctx context.Context, stream grpc.ServerStream) error {
+	r := srv.getReplicator()
+	if r == nil || !isReplicationRequest(ctx) {
+		return nil
+	}
+	return stream.SetHeader(metadata.Pairs(replicationStateMetadataKey, r.getState().String()))
+}
+
+// withReplicaContext restricts requests from other replicas to the leader. The returned context is canceled once
+// this server stops being the leader.
+func (srv *Server) withReplicaContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	r := srv.getReplicator()
+	if r == nil || !isReplicationRequest(ctx) {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	return r.getLeaderContext(ctx)
+}
+
+func withReplication(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, replicationMetadataKey, "true")
+}
+
+func isReplicationRequest(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(replicationMetadataKey)) > 0
+}
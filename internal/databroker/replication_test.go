@@ -0,0 +1,226 @@
+package databroker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+func TestReplication(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sharedKey := cryptutil.NewKey()
+
+	var listeners []net.Listener
+	var peers []string
+	for i := 0; i < 3; i++ {
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		listeners = append(listeners, li)
+		peers = append(peers, "http://"+li.Addr().String())
+	}
+
+	var srvs []*Server
+	var gss []*grpc.Server
+	var clients []databroker.DataBrokerServiceClient
+	for _, li := range listeners {
+		srv, gs, client := startTestReplica(ctx, t, li, peers, sharedKey)
+		srvs = append(srvs, srv)
+		gss = append(gss, gs)
+		clients = append(clients, client)
+	}
+
+	var leader int
+	require.Eventually(t, func() bool {
+		leader = getLeader(srvs)
+		return leader != -1
+	}, 30*time.Second, 50*time.Millisecond, "should elect a leader")
+	follower := (leader + 1) % 3
+
+	data := protoutil.NewAny(wrapperspb.String("HELLO"))
+	assert.Eventually(t, func() bool {
+		_, err := clients[follower].SetOptions(ctx, &databroker.SetOptionsRequest{
+			Type:    data.GetTypeUrl(),
+			Options: &databroker.Options{Capacity: proto.Uint64(10)},
+		})
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond, "should forward writes to the leader")
+	_, err := clients[follower].Put(ctx, &databroker.PutRequest{
+		Record: &databroker.Record{Type: data.GetTypeUrl(), Id: "1", Data: data},
+	})
+	require.NoError(t, err)
+
+	for i := range srvs {
+		assert.Eventually(t, func() bool {
+			res, err := clients[i].Get(ctx, &databroker.GetRequest{Type: data.GetTypeUrl(), Id: "1"})
+			return err == nil && proto.Equal(data, res.GetRecord().GetData())
+		}, 10*time.Second, 50*time.Millisecond, "should replicate records to every replica")
+	}
+
+	// stop the leader
+	gss[leader].Stop()
+	srvs[leader].UpdateConfig()
+
+	var remaining []*Server
+	var remainingClients []databroker.DataBrokerServiceClient
+	for i := range srvs {
+		if i != leader {
+			remaining = append(remaining, srvs[i])
+			remainingClients = append(remainingClients, clients[i])
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		leader = getLeader(remaining)
+		return leader != -1
+	}, 30*time.Second, 50*time.Millisecond, "should elect a new leader")
+
+	res, err := remainingClients[leader].Get(ctx, &databroker.GetRequest{Type: data.GetTypeUrl(), Id: "1"})
+	require.NoError(t, err, "should keep the replicated records")
+	assert.True(t, proto.Equal(data, res.GetRecord().GetData()))
+
+	options, err := remainingClients[leader].GetOptions(ctx, &databroker.GetOptionsRequest{Type: data.GetTypeUrl()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), options.GetOptions().GetCapacity(), "should keep the replicated options")
+
+	assert.Eventually(t, func() bool {
+		_, err := remainingClients[1-leader].Put(ctx, &databroker.PutRequest{
+			Record: &databroker.Record{Type: data.GetTypeUrl(), Id: "2", Data: data},
+		})
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond, "should forward writes to the new leader")
+}
+
+func TestReplicationRestart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sharedKey := cryptutil.NewKey()
+
+	var listeners []net.Listener
+	var peers []string
+	for i := 0; i < 3; i++ {
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		listeners = append(listeners, li)
+		peers = append(peers, "http://"+li.Addr().String())
+	}
+
+	var srvs []*Server
+	var gss []*grpc.Server
+	var clients []databroker.DataBrokerServiceClient
+	for _, li := range listeners {
+		srv, gs, client := startTestReplica(ctx, t, li, peers, sharedKey)
+		srvs = append(srvs, srv)
+		gss = append(gss, gs)
+		clients = append(clients, client)
+	}
+
+	var leader int
+	require.Eventually(t, func() bool {
+		leader = getLeader(srvs)
+		return leader != -1
+	}, 30*time.Second, 50*time.Millisecond, "should elect a leader")
+
+	data := protoutil.NewAny(wrapperspb.String("HELLO"))
+	_, err := clients[leader].Put(ctx, &databroker.PutRequest{
+		Record: &databroker.Record{Type: data.GetTypeUrl(), Id: "1", Data: data},
+	})
+	require.NoError(t, err)
+	for i := range srvs {
+		require.Eventually(t, func() bool {
+			_, err := clients[i].Get(ctx, &databroker.GetRequest{Type: data.GetTypeUrl(), Id: "1"})
+			return err == nil
+		}, 10*time.Second, 50*time.Millisecond, "should replicate records to every replica")
+	}
+
+	// restart the leader with an empty backend
+	restarted := leader
+	gss[restarted].Stop()
+	srvs[restarted].UpdateConfig()
+	li, err := net.Listen("tcp", listeners[restarted].Addr().String())
+	require.NoError(t, err)
+	srvs[restarted], gss[restarted], clients[restarted] = startTestReplica(ctx, t, li, peers, sharedKey)
+
+	require.Eventually(t, func() bool {
+		leader = getLeader(srvs)
+		return leader != -1
+	}, 30*time.Second, 50*time.Millisecond, "should elect a new leader")
+	assert.NotEqual(t, restarted, leader, "a replica without the records should not become the leader")
+
+	for i := range srvs {
+		assert.Eventually(t, func() bool {
+			_, err := clients[i].Get(ctx, &databroker.GetRequest{Type: data.GetTypeUrl(), Id: "1"})
+			return err == nil
+		}, 10*time.Second, 50*time.Millisecond, "every replica should keep the records")
+	}
+}
+
+func TestReplicationState(t *testing.T) {
+	state := replicationState{term: 2, serverVersion: 10, recordVersion: 5}
+
+	parsed, ok := parseReplicationState(state.String())
+	assert.True(t, ok)
+	assert.Equal(t, state, parsed)
+	_, ok = parseReplicationState("invalid")
+	assert.False(t, ok)
+
+	assert.True(t, state.newerThan(replicationState{term: 1, serverVersion: 10, recordVersion: 50}))
+	assert.True(t, state.newerThan(replicationState{term: 2, serverVersion: 10, recordVersion: 4}))
+	assert.True(t, state.newerThan(replicationState{}), "a replica without records is older")
+	assert.False(t, state.newerThan(state))
+	assert.False(t, state.newerThan(replicationState{term: 3}))
+	assert.False(t, state.newerThan(replicationState{term: 2, serverVersion: 11, recordVersion: 1}),
+		"record versions of different leaders can't be compared")
+}
+
+func startTestReplica(
+	ctx context.Context,
+	t *testing.T,
+	li net.Listener,
+	peers []string,
+	sharedKey []byte,
+) (*Server, *grpc.Server, databroker.DataBrokerServiceClient) {
+	srv := New(
+		WithGetSharedKey(func() ([]byte, error) { return sharedKey, nil }),
+		WithReplicationPeers(peers),
+	)
+	t.Cleanup(func() { srv.UpdateConfig() })
+
+	gs := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(gs, srv)
+	go func() { _ = gs.Serve(li) }()
+	t.Cleanup(gs.Stop)
+
+	cc, err := grpc.DialContext(ctx, li.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+
+	return srv, gs, databroker.NewDataBrokerServiceClient(cc)
+}
+
+// getLeader returns the index of the only leader, or -1.
+func getLeader(srvs []*Server) int {
+	leader := -1
+	for i, srv := range srvs {
+		if srv.getReplicator().isLeader() {
+			if leader != -1 {
+				return -1
+			}
+			leader = i
+		}
+	}
+	return leader
+}
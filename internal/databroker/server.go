@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 
@@ -22,7 +23,9 @@ import (
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/storage"
+	"github.com/pomerium/pomerium/pkg/storage/file"
 	"github.com/pomerium/pomerium/pkg/storage/inmemory"
+	"github.com/pomerium/pomerium/pkg/storage/postgres"
 	"github.com/pomerium/pomerium/pkg/storage/redis"
 )
 
@@ -30,9 +33,10 @@ import (
 type Server struct {
 	cfg *serverConfig
 
-	mu       sync.RWMutex
-	backend  storage.Backend
-	registry registry.Interface
+	mu         sync.RWMutex
+	backend    storage.Backend
+	registry   registry.Interface
+	replicator *replicator
 }
 
 // New creates a new server.
@@ -71,6 +75,20 @@ func (srv *Server) UpdateConfig(options ...ServerOption) {
 		}
 		srv.registry = nil
 	}
+
+	if srv.replicator != nil {
+		srv.replicator.stop()
+		srv.replicator = nil
+	}
+
+	if len(cfg.replicationPeers) > 0 && cfg.storageType == config.StorageInMemoryName {
+		r, err := newReplicator(srv, cfg)
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("databroker: error starting replication")
+		} else {
+			srv.replicator = r
+		}
+	}
 }
 
 // AcquireLease acquires a lease.
@@ -82,9 +100,15 @@ func (srv *Server) AcquireLease(ctx context.Context, req *databroker.AcquireLeas
 		Dur("duration", req.GetDuration().AsDuration()).
 		Msg("acquire lease")
 
-	db, err := srv.getBackend()
+	db, leader, err := srv.getLeaseBackend(ctx, req.GetName())
 	if err != nil {
 		return nil, err
+	} else if leader != nil {
+		return leader.AcquireLease(withReplication(ctx), req)
+	}
+
+	if err := srv.checkReplicationCandidate(ctx, req.GetName()); err != nil {
+		return nil, err
 	}
 
 	leaseID := uuid.NewString()
@@ -100,6 +124,46 @@ func (srv *Server) AcquireLease(ctx context.Context, req *databroker.AcquireLeas
 	}, nil
 }
 
+// Export streams the options and the latest version of every record.
+func (srv *Server) Export(req *databroker.ExportRequest, stream databroker.DataBrokerService_ExportServer) error {
+	ctx := stream.Context()
+	ctx, span := trace.StartSpan(ctx, "databroker.grpc.Export")
+	defer span.End()
+	log.Info(ctx).
+		Strs("types", req.GetTypes()).
+		Msg("export")
+
+	leader, err := srv.getLeader(ctx)
+	if err != nil {
+		return err
+	} else if leader != nil {
+		src, err := leader.Export(withReplication(ctx), req)
+		if err != nil {
+			return err
+		}
+		for {
+			entry, err := src.Recv()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			err = stream.Send(entry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	backend, err := srv.getBackend()
+	if err != nil {
+		return err
+	}
+
+	return storage.Export(ctx, backend, storage.SyncFilter{Types: req.GetTypes()}, stream.Send)
+}
+
 // Get gets a record from the in-memory list.
 func (srv *Server) Get(ctx context.Context, req *databroker.GetRequest) (*databroker.GetResponse, error) {
 	_, span := trace.StartSpan(ctx, "databroker.grpc.Get")
@@ -127,6 +191,82 @@ func (srv *Server) Get(ctx context.Context, req *databroker.GetRequest) (*databr
 	}, nil
 }
 
+// GetOptions gets the options for a type in the databroker.
+func (srv *Server) GetOptions(ctx context.Context, req *databroker.GetOptionsRequest) (*databroker.GetOptionsResponse, error) {
+	_, span := trace.StartSpan(ctx, "databroker.grpc.GetOptions")
+	defer span.End()
+
+	leader, err := srv.getLeader(ctx)
+	if err != nil {
+		return nil, err
+	} else if leader != nil {
+		return leader.GetOptions(withReplication(ctx), req)
+	}
+
+	backend, err := srv.getBackend()
+	if err != nil {
+		return nil, err
+	}
+	options, err := backend.GetOptions(ctx, req.GetType())
+	if err != nil {
+		return nil, err
+	}
+	return &databroker.GetOptionsResponse{
+		Options: options,
+	}, nil
+}
+
+// Import restores exported options and records.
+func (srv *Server) Import(stream databroker.DataBrokerService_ImportServer) error {
+	ctx := stream.Context()
+	ctx, span := trace.StartSpan(ctx, "databroker.grpc.Import")
+	defer span.End()
+	log.Info(ctx).Msg("import")
+
+	leader, err := srv.getLeader(ctx)
+	if err != nil {
+		return err
+	} else if leader != nil {
+		dst, err := leader.Import(withReplication(ctx))
+		if err != nil {
+			return err
+		}
+		for {
+			entry, err := stream.Recv()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+
+			err = dst.Send(entry)
+			if err != nil {
+				return err
+			}
+		}
+		res, err := dst.CloseAndRecv()
+		if err != nil {
+			return err
+		}
+		return stream.SendAndClose(res)
+	}
+
+	backend, err := srv.getBackend()
+	if err != nil {
+		return err
+	}
+
+	serverVersion, recordCount, err := storage.Import(ctx, backend, stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&databroker.ImportResponse{
+		ServerVersion: serverVersion,
+		RecordCount:   recordCount,
+	})
+}
+
 // Query queries for records.
 func (srv *Server) Query(ctx context.Context, req *databroker.QueryRequest) (*databroker.QueryResponse, error) {
 	_, span := trace.StartSpan(ctx, "databroker.grpc.Query")
@@ -134,38 +274,58 @@ func (srv *Server) Query(ctx context.Context, req *databroker.QueryRequest) (*da
 	log.Info(ctx).
 		Str("type", req.GetType()).
 		Str("query", req.GetQuery()).
+		Str("filter", req.GetFilter()).
+		Str("order_by", req.GetOrderBy()).
 		Int64("offset", req.GetOffset()).
 		Int64("limit", req.GetLimit()).
 		Msg("query")
 
 	query := strings.ToLower(req.GetQuery())
 
+	q, err := storage.ParseQuery(req.GetFilter(), req.GetOrderBy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	db, err := srv.getBackend()
 	if err != nil {
 		return nil, err
 	}
 
-	all, _, err := db.GetAll(ctx)
+	// the id prefix is matched by the backend, but the rest of the filter, the order and the limit are applied
+	// here since the total count and the cursor need every matching record
+	all, err := db.GetAllOfType(ctx, req.GetType(), q.IDPrefix())
 	if err != nil {
 		return nil, err
 	}
 
 	var filtered []*databroker.Record
 	for _, record := range all {
-		if record.GetType() != req.GetType() {
-			continue
-		}
 		if query != "" && !storage.MatchAny(record.GetData(), query) {
 			continue
 		}
 		filtered = append(filtered, record)
 	}
 
-	records, totalCount := databroker.ApplyOffsetAndLimit(filtered, int(req.GetOffset()), int(req.GetLimit()))
-	return &databroker.QueryResponse{
+	filtered = q.Execute(ctx, filtered)
+	totalCount := len(filtered)
+
+	if req.GetCursor() != "" {
+		filtered, err = q.After(ctx, filtered, req.GetCursor())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	records, remaining := databroker.ApplyOffsetAndLimit(filtered, int(req.GetOffset()), int(req.GetLimit()))
+	res := &databroker.QueryResponse{
 		Records:    records,
 		TotalCount: int64(totalCount),
-	}, nil
+	}
+	if len(records) > 0 && int(req.GetOffset())+len(records) < remaining {
+		res.NextCursor = q.Cursor(ctx, records[len(records)-1])
+	}
+	return res, nil
 }
 
 // Put updates an existing record or adds a new one.
@@ -179,12 +339,21 @@ func (srv *Server) Put(ctx context.Context, req *databroker.PutRequest) (*databr
 		Str("id", record.GetId()).
 		Msg("put")
 
+	leader, err := srv.getLeader(ctx)
+	if err != nil {
+		return nil, err
+	} else if leader != nil {
+		return leader.Put(withReplication(ctx), req)
+	}
+
 	db, err := srv.getBackend()
 	if err != nil {
 		return nil, err
 	}
 
-	serverVersion, err := db.Put(ctx, record)
+	serverVersion, err := db.PutMany(ctx, []*databroker.Record{record}, storage.PutOptions{
+		CheckVersions: req.GetCheckVersion(),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -194,6 +363,41 @@ func (srv *Server) Put(ctx context.Context, req *databroker.PutRequest) (*databr
 	}, nil
 }
 
+// PutMany puts multiple records into the databroker atomically.
+func (srv *Server) PutMany(ctx context.Context, req *databroker.PutManyRequest) (*databroker.PutManyResponse, error) {
+	_, span := trace.StartSpan(ctx, "databroker.grpc.PutMany")
+	defer span.End()
+	records := req.GetRecords()
+
+	log.Info(ctx).
+		Int("count", len(records)).
+		Bool("check_versions", req.GetCheckVersions()).
+		Msg("put many")
+
+	leader, err := srv.getLeader(ctx)
+	if err != nil {
+		return nil, err
+	} else if leader != nil {
+		return leader.PutMany(withReplication(ctx), req)
+	}
+
+	db, err := srv.getBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	serverVersion, err := db.PutMany(ctx, records, storage.PutOptions{
+		CheckVersions: req.GetCheckVersions(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &databroker.PutManyResponse{
+		ServerVersion: serverVersion,
+		Records:       records,
+	}, nil
+}
+
 // ReleaseLease releases a lease.
 func (srv *Server) ReleaseLease(ctx context.Context, req *databroker.ReleaseLeaseRequest) (*emptypb.Empty, error) {
 	_, span := trace.StartSpan(ctx, "databroker.grpc.ReleaseLease")
@@ -203,9 +407,11 @@ func (srv *Server) ReleaseLease(ctx context.Context, req *databroker.ReleaseLeas
 		Str("id", req.GetId()).
 		Msg("release lease")
 
-	db, err := srv.getBackend()
+	db, leader, err := srv.getLeaseBackend(ctx, req.GetName())
 	if err != nil {
 		return nil, err
+	} else if leader != nil {
+		return leader.ReleaseLease(withReplication(ctx), req)
 	}
 
 	_, err = db.Lease(ctx, req.GetName(), req.GetId(), -1)
@@ -226,9 +432,15 @@ func (srv *Server) RenewLease(ctx context.Context, req *databroker.RenewLeaseReq
 		Dur("duration", req.GetDuration().AsDuration()).
 		Msg("renew lease")
 
-	db, err := srv.getBackend()
+	db, leader, err := srv.getLeaseBackend(ctx, req.GetName())
 	if err != nil {
 		return nil, err
+	} else if leader != nil {
+		return leader.RenewLease(withReplication(ctx), req)
+	}
+
+	if err := srv.checkReplicationCandidate(ctx, req.GetName()); err != nil {
+		return nil, err
 	}
 
 	acquired, err := db.Lease(ctx, req.GetName(), req.GetId(), req.GetDuration().AsDuration())
@@ -246,6 +458,13 @@ func (srv *Server) SetOptions(ctx context.Context, req *databroker.SetOptionsReq
 	_, span := trace.StartSpan(ctx, "databroker.grpc.SetOptions")
 	defer span.End()
 
+	leader, err := srv.getLeader(ctx)
+	if err != nil {
+		return nil, err
+	} else if leader != nil {
+		return leader.SetOptions(withReplication(ctx), req)
+	}
+
 	backend, err := srv.getBackend()
 	if err != nil {
 		return nil, err
@@ -269,12 +488,17 @@ func (srv *Server) Sync(req *databroker.SyncRequest, stream databroker.DataBroke
 	ctx, span := trace.StartSpan(ctx, "databroker.grpc.Sync")
 	defer span.End()
 
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel, err := srv.withReplicaContext(ctx)
+	if err != nil {
+		return err
+	}
 	defer cancel()
 
 	log.Info(ctx).
 		Uint64("server_version", req.GetServerVersion()).
 		Uint64("record_version", req.GetRecordVersion()).
+		Strs("types", req.GetTypes()).
+		Strs("id_prefixes", req.GetIdPrefixes()).
 		Msg("sync")
 
 	backend, err := srv.getBackend()
@@ -282,7 +506,10 @@ func (srv *Server) Sync(req *databroker.SyncRequest, stream databroker.DataBroke
 		return err
 	}
 
-	recordStream, err := backend.Sync(ctx, req.GetServerVersion(), req.GetRecordVersion())
+	recordStream, err := backend.Sync(ctx, req.GetServerVersion(), req.GetRecordVersion(), storage.SyncFilter{
+		Types:      req.GetTypes(),
+		IDPrefixes: req.GetIdPrefixes(),
+	})
 	if err != nil {
 		return err
 	}
@@ -306,13 +533,20 @@ func (srv *Server) SyncLatest(req *databroker.SyncLatestRequest, stream databrok
 	ctx, span := trace.StartSpan(ctx, "databroker.grpc.SyncLatest")
 	defer span.End()
 
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel, err := srv.withReplicaContext(ctx)
+	if err != nil {
+		return err
+	}
 	defer cancel()
 
 	log.Info(ctx).
 		Str("type", req.GetType()).
 		Msg("sync latest")
 
+	if err := srv.setReplicationHeader(ctx, stream); err != nil {
+		return err
+	}
+
 	backend, err := srv.getBackend()
 	if err != nil {
 		return err
@@ -382,11 +616,23 @@ func (srv *Server) newBackendLocked() (backend storage.Backend, err error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create new redis storage: %w", err)
 		}
+	case config.StoragePostgresName:
+		log.Info(ctx).Msg("using postgres store")
+		backend, err = postgres.New(srv.cfg.storageConnectionString)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new postgres storage: %w", err)
+		}
+	case config.StorageFileName:
+		log.Info(ctx).Msg("using file store")
+		backend, err = file.New(srv.cfg.storageConnectionString)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new file storage: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("unsupported storage type: %s", srv.cfg.storageType)
 	}
 	if srv.cfg.secret != nil {
-		backend, err = storage.NewEncryptedBackend(srv.cfg.secret, backend)
+		backend, err = storage.NewEncryptedBackend(srv.cfg.secret, backend, srv.cfg.decryptionKeys...)
 		if err != nil {
 			return nil, err
 		}
@@ -1,8 +1,10 @@
 package databroker
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"testing"
@@ -82,6 +84,48 @@ func TestServer_Get(t *testing.T) {
 	})
 }
 
+func TestServer_PutMany(t *testing.T) {
+	cfg := newServerConfig()
+	srv := newServer(cfg)
+
+	any := protoutil.NewAny(new(session.Session))
+	res, err := srv.PutMany(context.Background(), &databroker.PutManyRequest{
+		Records: []*databroker.Record{
+			{Type: any.TypeUrl, Id: "1", Data: any},
+			{Type: any.TypeUrl, Id: "2", Data: any},
+		},
+	})
+	require.NoError(t, err)
+	if assert.Len(t, res.GetRecords(), 2) {
+		assert.Equal(t, uint64(1), res.GetRecords()[0].GetVersion())
+		assert.Equal(t, uint64(2), res.GetRecords()[1].GetVersion())
+	}
+
+	t.Run("check version", func(t *testing.T) {
+		_, err := srv.Put(context.Background(), &databroker.PutRequest{
+			Record:       &databroker.Record{Type: any.TypeUrl, Id: "1", Data: any, Version: 2},
+			CheckVersion: true,
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = srv.PutMany(context.Background(), &databroker.PutManyRequest{
+			Records: []*databroker.Record{
+				{Type: any.TypeUrl, Id: "1", Data: any, Version: 1},
+				{Type: any.TypeUrl, Id: "3", Data: any, Version: 1},
+			},
+			CheckVersions: true,
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		res, err := srv.Put(context.Background(), &databroker.PutRequest{
+			Record:       &databroker.Record{Type: any.TypeUrl, Id: "1", Data: any, Version: 1},
+			CheckVersion: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), res.GetRecord().GetVersion())
+	})
+}
+
 func TestServer_Options(t *testing.T) {
 	cfg := newServerConfig()
 	srv := newServer(cfg)
@@ -150,6 +194,53 @@ func TestServer_Query(t *testing.T) {
 		Type: any.TypeUrl,
 	})
 	assert.NoError(t, err)
+
+	t.Run("filter", func(t *testing.T) {
+		for i := 2; i <= 5; i++ {
+			s := &session.Session{Id: fmt.Sprint(i), UserId: fmt.Sprintf("user-%d", i%2)}
+			_, err := srv.Put(context.Background(), &databroker.PutRequest{
+				Record: &databroker.Record{
+					Type: any.TypeUrl,
+					Id:   s.Id,
+					Data: protoutil.NewAny(s),
+				},
+			})
+			require.NoError(t, err)
+		}
+
+		res, err := srv.Query(context.Background(), &databroker.QueryRequest{
+			Type:    any.TypeUrl,
+			Filter:  `user_id = "user-0"`,
+			OrderBy: "$id desc",
+			Limit:   1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), res.GetTotalCount())
+		if assert.Len(t, res.GetRecords(), 1) {
+			assert.Equal(t, "4", res.GetRecords()[0].GetId())
+		}
+		assert.NotEmpty(t, res.GetNextCursor())
+
+		res, err = srv.Query(context.Background(), &databroker.QueryRequest{
+			Type:    any.TypeUrl,
+			Filter:  `user_id = "user-0"`,
+			OrderBy: "$id desc",
+			Cursor:  res.GetNextCursor(),
+			Limit:   1,
+		})
+		require.NoError(t, err)
+		if assert.Len(t, res.GetRecords(), 1) {
+			assert.Equal(t, "2", res.GetRecords()[0].GetId())
+		}
+		assert.Empty(t, res.GetNextCursor())
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := srv.Query(context.Background(), &databroker.QueryRequest{
+			Type:   any.TypeUrl,
+			Filter: `user_id =`,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 }
 
 func TestServer_Sync(t *testing.T) {
@@ -235,6 +326,108 @@ func TestServer_Sync(t *testing.T) {
 	assert.NoError(t, eg.Wait())
 }
 
+func TestServer_ExportImport(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	newClient := func(t *testing.T, srv *Server) databroker.DataBrokerServiceClient {
+		gs := grpc.NewServer()
+		databroker.RegisterDataBrokerServiceServer(gs, srv)
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go func() { _ = gs.Serve(li) }()
+		t.Cleanup(gs.Stop)
+
+		cc, err := grpc.DialContext(ctx, li.Addr().String(), grpc.WithInsecure())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = cc.Close() })
+		return databroker.NewDataBrokerServiceClient(cc)
+	}
+
+	src := newServer(newServerConfig())
+	any := protoutil.NewAny(new(session.Session))
+	_, err := src.SetOptions(ctx, &databroker.SetOptionsRequest{
+		Type:    "TYPE0",
+		Options: &databroker.Options{Capacity: proto.Uint64(1000)},
+	})
+	require.NoError(t, err)
+	_, err = src.SetOptions(ctx, &databroker.SetOptionsRequest{
+		Type:    "TYPE2",
+		Options: &databroker.Options{Capacity: proto.Uint64(10)},
+	})
+	require.NoError(t, err)
+	var expect []*databroker.Record
+	for i := 0; i < 100; i++ {
+		res, err := src.Put(ctx, &databroker.PutRequest{
+			Record: &databroker.Record{Type: fmt.Sprint("TYPE", i%2), Id: fmt.Sprint(i), Data: any},
+		})
+		require.NoError(t, err)
+		expect = append(expect, res.GetRecord())
+	}
+	_, err = src.Put(ctx, &databroker.PutRequest{
+		Record: &databroker.Record{Type: any.TypeUrl, Id: "session", Data: any},
+	})
+	require.NoError(t, err)
+
+	c, err := cryptutil.NewAEADCipher(cryptutil.NewKey())
+	require.NoError(t, err)
+
+	// export
+	var buf bytes.Buffer
+	aw, err := databroker.NewArchiveWriter(&buf, c)
+	require.NoError(t, err)
+	stream, err := newClient(t, src).Export(ctx, &databroker.ExportRequest{
+		Types: []string{"TYPE0", "TYPE1", "TYPE2"},
+	})
+	require.NoError(t, err)
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, aw.Write(entry))
+	}
+	require.NoError(t, aw.Close())
+
+	// import
+	dst := newServer(newServerConfig())
+	ar, err := databroker.NewArchiveReader(&buf, c)
+	require.NoError(t, err)
+	importStream, err := newClient(t, dst).Import(ctx)
+	require.NoError(t, err)
+	for {
+		entry, err := ar.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, importStream.Send(entry))
+	}
+	res, err := importStream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), res.GetRecordCount())
+
+	for _, record := range expect {
+		res, err := dst.Get(ctx, &databroker.GetRequest{Type: record.GetType(), Id: record.GetId()})
+		require.NoError(t, err)
+		assert.Equal(t, record.GetModifiedAt().AsTime(), res.GetRecord().GetModifiedAt().AsTime(),
+			"should keep the modification time")
+		assert.True(t, proto.Equal(record.GetData(), res.GetRecord().GetData()))
+	}
+	_, err = dst.Get(ctx, &databroker.GetRequest{Type: any.TypeUrl, Id: "session"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "should only export the given types")
+
+	backend, err := dst.getBackend()
+	require.NoError(t, err)
+	options, err := backend.GetOptions(ctx, "TYPE0")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), options.GetCapacity(), "should import the options")
+	options, err = backend.GetOptions(ctx, "TYPE2")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), options.GetCapacity(), "should import the options of types without records")
+}
+
 func TestServerInvalidStorage(t *testing.T) {
 	srv := newServer(&serverConfig{
 		storageType: "<INVALID>",
@@ -1,6 +1,7 @@
 package httputil
 
 import (
+	"encoding/json"
 	"html/template"
 	"net/http"
 	"net/url"
@@ -21,6 +22,8 @@ type HTTPError struct {
 	DebugURL *url.URL
 	// The request ID.
 	RequestID string
+	// Explanation is an optional JSON explanation of the policy decision which led to the error.
+	Explanation json.RawMessage
 }
 
 // NewError returns an error that contains a HTTP status and error.
@@ -46,20 +49,22 @@ func (e *HTTPError) ErrorResponse(w http.ResponseWriter, r *http.Request) {
 		reqID = requestid.FromContext(r.Context())
 	}
 	response := struct {
-		Status     int
-		Error      string
-		StatusText string   `json:"-"`
-		RequestID  string   `json:",omitempty"`
-		CanDebug   bool     `json:"-"`
-		Version    string   `json:"-"`
-		DebugURL   *url.URL `json:",omitempty"`
+		Status      int
+		Error       string
+		StatusText  string          `json:"-"`
+		RequestID   string          `json:",omitempty"`
+		CanDebug    bool            `json:"-"`
+		Version     string          `json:"-"`
+		DebugURL    *url.URL        `json:",omitempty"`
+		Explanation json.RawMessage `json:",omitempty"`
 	}{
-		Status:     e.Status,
-		StatusText: StatusText(e.Status),
-		Error:      e.Error(),
-		RequestID:  reqID,
-		CanDebug:   e.Status/100 == 4 && (e.DebugURL != nil || reqID != ""),
-		DebugURL:   e.DebugURL,
+		Status:      e.Status,
+		StatusText:  StatusText(e.Status),
+		Error:       e.Error(),
+		RequestID:   reqID,
+		CanDebug:    e.Status/100 == 4 && (e.DebugURL != nil || reqID != ""),
+		DebugURL:    e.DebugURL,
+		Explanation: e.Explanation,
 	}
 	// indicate to clients that the error originates from Pomerium, not the app
 	w.Header().Set(HeaderPomeriumResponse, "true")
@@ -1,6 +1,7 @@
 package manager
 
 import (
+	"fmt"
 	"sync/atomic"
 	"time"
 
@@ -16,14 +17,15 @@ var (
 )
 
 type config struct {
-	authenticator                 Authenticator
-	directory                     directory.Provider
-	dataBrokerClient              databroker.DataBrokerServiceClient
-	groupRefreshInterval          time.Duration
-	groupRefreshTimeout           time.Duration
-	sessionRefreshGracePeriod     time.Duration
-	sessionRefreshCoolOffDuration time.Duration
-	now                           func() time.Time
+	authenticator                  Authenticator
+	identityProviderAuthenticators map[string]Authenticator
+	directory                      directory.Provider
+	dataBrokerClient               databroker.DataBrokerServiceClient
+	groupRefreshInterval           time.Duration
+	groupRefreshTimeout            time.Duration
+	sessionRefreshGracePeriod      time.Duration
+	sessionRefreshCoolOffDuration  time.Duration
+	now                            func() time.Time
 }
 
 func newConfig(options ...Option) *config {
@@ -49,6 +51,14 @@ func WithAuthenticator(authenticator Authenticator) Option {
 	}
 }
 
+// WithIdentityProviderAuthenticators sets the authenticators used for sessions created with a named identity
+// provider. Sessions without an identity provider use the authenticator set by WithAuthenticator.
+func WithIdentityProviderAuthenticators(authenticators map[string]Authenticator) Option {
+	return func(cfg *config) {
+		cfg.identityProviderAuthenticators = authenticators
+	}
+}
+
 // WithDirectoryProvider sets the directory provider in the config.
 func WithDirectoryProvider(directoryProvider directory.Provider) Option {
 	return func(cfg *config) {
@@ -98,6 +108,18 @@ func WithNow(now func() time.Time) Option {
 	}
 }
 
+// getAuthenticator returns the authenticator for the named identity provider.
+func (cfg *config) getAuthenticator(identityProvider string) (Authenticator, error) {
+	if identityProvider == "" {
+		return cfg.authenticator, nil
+	}
+	authenticator, ok := cfg.identityProviderAuthenticators[identityProvider]
+	if !ok {
+		return nil, fmt.Errorf("identity/manager: unknown identity provider: %s", identityProvider)
+	}
+	return authenticator, nil
+}
+
 type atomicConfig struct {
 	value atomic.Value
 }
@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/internal/identity"
+)
+
+func TestConfig_getAuthenticator(t *testing.T) {
+	defaultAuthenticator := identity.MockProvider{GetSignInURLResponse: "https://okta.example.com"}
+	contractorsAuthenticator := identity.MockProvider{GetSignInURLResponse: "https://github.com"}
+
+	cfg := newConfig(
+		WithAuthenticator(defaultAuthenticator),
+		WithIdentityProviderAuthenticators(map[string]Authenticator{
+			"default":     defaultAuthenticator,
+			"contractors": contractorsAuthenticator,
+		}),
+	)
+
+	authenticator, err := cfg.getAuthenticator("")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultAuthenticator, authenticator, "sessions without an identity provider should use the default")
+
+	authenticator, err = cfg.getAuthenticator("contractors")
+	assert.NoError(t, err)
+	assert.Equal(t, contractorsAuthenticator, authenticator)
+
+	_, err = cfg.getAuthenticator("unknown")
+	assert.Error(t, err)
+}
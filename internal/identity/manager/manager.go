@@ -12,7 +12,7 @@ import (
 	"github.com/rs/zerolog"
 	"golang.org/x/oauth2"
 	"golang.org/x/sync/errgroup"
-	"golang.org/x/sync/semaphore"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -29,10 +29,6 @@ import (
 	"github.com/pomerium/pomerium/pkg/protoutil"
 )
 
-const (
-	dataBrokerParallelism = 10
-)
-
 // Authenticator is an identity.Provider with only the methods needed by the manager.
 type Authenticator interface {
 	Refresh(context.Context, *oauth2.Token, identity.State) (*oauth2.Token, error)
@@ -60,8 +56,6 @@ type Manager struct {
 
 	directoryBackoff     *backoff.ExponentialBackOff
 	directoryNextRefresh time.Time
-
-	dataBrokerSemaphore *semaphore.Weighted
 }
 
 // New creates a new identity manager.
@@ -73,8 +67,6 @@ func New(
 
 		sessionScheduler: scheduler.New(),
 		userScheduler:    scheduler.New(),
-
-		dataBrokerSemaphore: semaphore.NewWeighted(dataBrokerParallelism),
 	}
 	mgr.directoryBackoff = backoff.NewExponentialBackOff()
 	mgr.directoryBackoff.MaxElapsedTime = 0
@@ -243,35 +235,20 @@ func (mgr *Manager) refreshDirectoryUserGroups(ctx context.Context) (nextRefresh
 }
 
 func (mgr *Manager) mergeGroups(ctx context.Context, directoryGroups []*directory.Group) {
-	eg, ctx := errgroup.WithContext(ctx)
-
 	lookup := map[string]*directory.Group{}
 	for _, dg := range directoryGroups {
 		lookup[dg.GetId()] = dg
 	}
 
+	var records []*databroker.Record
 	for groupID, newDG := range lookup {
 		curDG, ok := mgr.directoryGroups[groupID]
 		if !ok || !proto.Equal(newDG, curDG) {
-			id := newDG.GetId()
 			any := protoutil.NewAny(newDG)
-			eg.Go(func() error {
-				if err := mgr.dataBrokerSemaphore.Acquire(ctx, 1); err != nil {
-					return err
-				}
-				defer mgr.dataBrokerSemaphore.Release(1)
-
-				_, err := mgr.cfg.Load().dataBrokerClient.Put(ctx, &databroker.PutRequest{
-					Record: &databroker.Record{
-						Type: any.GetTypeUrl(),
-						Id:   id,
-						Data: any,
-					},
-				})
-				if err != nil {
-					return fmt.Errorf("failed to update directory group: %s", id)
-				}
-				return nil
+			records = append(records, &databroker.Record{
+				Type: any.GetTypeUrl(),
+				Id:   newDG.GetId(),
+				Data: any,
 			})
 		}
 	}
@@ -279,64 +256,35 @@ func (mgr *Manager) mergeGroups(ctx context.Context, directoryGroups []*director
 	for groupID, curDG := range mgr.directoryGroups {
 		_, ok := lookup[groupID]
 		if !ok {
-			id := curDG.GetId()
 			any := protoutil.NewAny(curDG)
-			eg.Go(func() error {
-				if err := mgr.dataBrokerSemaphore.Acquire(ctx, 1); err != nil {
-					return err
-				}
-				defer mgr.dataBrokerSemaphore.Release(1)
-
-				_, err := mgr.cfg.Load().dataBrokerClient.Put(ctx, &databroker.PutRequest{
-					Record: &databroker.Record{
-						Type:      any.GetTypeUrl(),
-						Id:        id,
-						DeletedAt: timestamppb.Now(),
-					},
-				})
-				if err != nil {
-					return fmt.Errorf("failed to delete directory group: %s", id)
-				}
-				return nil
+			records = append(records, &databroker.Record{
+				Type:      any.GetTypeUrl(),
+				Id:        curDG.GetId(),
+				DeletedAt: timestamppb.Now(),
 			})
 		}
 	}
 
-	if err := eg.Wait(); err != nil {
+	if err := mgr.putMany(ctx, records); err != nil {
 		log.Warn(ctx).Err(err).Msg("manager: failed to merge groups")
 	}
 }
 
 func (mgr *Manager) mergeUsers(ctx context.Context, directoryUsers []*directory.User) {
-	eg, ctx := errgroup.WithContext(ctx)
-
 	lookup := map[string]*directory.User{}
 	for _, du := range directoryUsers {
 		lookup[du.GetId()] = du
 	}
 
+	var records []*databroker.Record
 	for userID, newDU := range lookup {
 		curDU, ok := mgr.directoryUsers[userID]
 		if !ok || !proto.Equal(newDU, curDU) {
-			id := newDU.GetId()
 			any := protoutil.NewAny(newDU)
-			eg.Go(func() error {
-				if err := mgr.dataBrokerSemaphore.Acquire(ctx, 1); err != nil {
-					return err
-				}
-				defer mgr.dataBrokerSemaphore.Release(1)
-
-				client := mgr.cfg.Load().dataBrokerClient
-				if _, err := client.Put(ctx, &databroker.PutRequest{
-					Record: &databroker.Record{
-						Type: any.GetTypeUrl(),
-						Id:   id,
-						Data: any,
-					},
-				}); err != nil {
-					return fmt.Errorf("failed to update directory user: %s", id)
-				}
-				return nil
+			records = append(records, &databroker.Record{
+				Type: any.GetTypeUrl(),
+				Id:   newDU.GetId(),
+				Data: any,
 			})
 		}
 	}
@@ -344,35 +292,61 @@ func (mgr *Manager) mergeUsers(ctx context.Context, directoryUsers []*directory.
 	for userID, curDU := range mgr.directoryUsers {
 		_, ok := lookup[userID]
 		if !ok {
-			id := curDU.GetId()
 			any := protoutil.NewAny(curDU)
-			eg.Go(func() error {
-				if err := mgr.dataBrokerSemaphore.Acquire(ctx, 1); err != nil {
-					return err
-				}
-				defer mgr.dataBrokerSemaphore.Release(1)
-
-				client := mgr.cfg.Load().dataBrokerClient
-				if _, err := client.Put(ctx, &databroker.PutRequest{
-					Record: &databroker.Record{
-						Type:      any.GetTypeUrl(),
-						Id:        id,
-						Data:      any,
-						DeletedAt: timestamppb.Now(),
-					},
-				}); err != nil {
-					return fmt.Errorf("failed to delete directory user (%s): %w", id, err)
-				}
-				return nil
+			records = append(records, &databroker.Record{
+				Type:      any.GetTypeUrl(),
+				Id:        curDU.GetId(),
+				Data:      any,
+				DeletedAt: timestamppb.Now(),
 			})
 		}
 	}
 
-	if err := eg.Wait(); err != nil {
+	if err := mgr.putMany(ctx, records); err != nil {
 		log.Warn(ctx).Err(err).Msg("manager: failed to merge users")
 	}
 }
 
+// maxPutManySize is the maximum size of the records in a single PutMany request. It's well below gRPC's default 4MB
+// message limit, so large directories can still be written.
+const maxPutManySize = 1024 * 1024
+
+// putMany writes the records to the databroker. The records are written in batches of at most maxPutManySize bytes,
+// each of which is an atomic update.
+func (mgr *Manager) putMany(ctx context.Context, records []*databroker.Record) error {
+	for _, batch := range batchRecords(records, maxPutManySize) {
+		_, err := mgr.cfg.Load().dataBrokerClient.PutMany(ctx, &databroker.PutManyRequest{
+			Records: batch,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update %d directory records: %w", len(batch), err)
+		}
+	}
+	return nil
+}
+
+// batchRecords splits the records into batches whose size, encoded as a PutManyRequest, is at most maxSize. A record
+// larger than maxSize is put in a batch of its own.
+func batchRecords(records []*databroker.Record, maxSize int) [][]*databroker.Record {
+	var batches [][]*databroker.Record
+	var batch []*databroker.Record
+	var batchSize int
+	for _, record := range records {
+		// each record is a length-delimited field of the request
+		size := protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(record))
+		if len(batch) > 0 && batchSize+size > maxSize {
+			batches = append(batches, batch)
+			batch, batchSize = nil, 0
+		}
+		batch = append(batch, record)
+		batchSize += size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
 func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string) {
 	log.Info(ctx).
 		Str("user_id", userID).
@@ -406,7 +380,17 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 		return
 	}
 
-	newToken, err := mgr.cfg.Load().authenticator.Refresh(ctx, FromOAuthToken(s.OauthToken), &s)
+	authenticator, err := mgr.cfg.Load().getAuthenticator(s.GetIdentityProvider())
+	if err != nil {
+		log.Error(ctx).Err(err).
+			Str("user_id", s.GetUserId()).
+			Str("session_id", s.GetId()).
+			Msg("no authenticator found for refresh, deleting session")
+		mgr.deleteSession(ctx, s.Session)
+		return
+	}
+
+	newToken, err := authenticator.Refresh(ctx, FromOAuthToken(s.OauthToken), &s)
 	mgr.maybeDispatchErrorEvent(err)
 	metrics.RecordIdentityManagerSessionRefresh(ctx, err)
 	if isTemporaryError(err) {
@@ -425,7 +409,7 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 	}
 	s.OauthToken = ToOAuthToken(newToken)
 
-	err = mgr.cfg.Load().authenticator.UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &s)
+	err = authenticator.UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &s)
 	mgr.maybeDispatchErrorEvent(err)
 	if isTemporaryError(err) {
 		log.Error(ctx).Err(err).
@@ -477,7 +461,17 @@ func (mgr *Manager) refreshUser(ctx context.Context, userID string) {
 			continue
 		}
 
-		err := mgr.cfg.Load().authenticator.UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &u)
+		authenticator, err := mgr.cfg.Load().getAuthenticator(s.GetIdentityProvider())
+		if err != nil {
+			log.Error(ctx).Err(err).
+				Str("user_id", s.GetUserId()).
+				Str("session_id", s.GetId()).
+				Msg("no authenticator found for refresh, deleting session")
+			mgr.deleteSession(ctx, s.Session)
+			continue
+		}
+
+		err = authenticator.UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &u)
 		mgr.maybeDispatchErrorEvent(err)
 		metrics.RecordIdentityManagerUserRefresh(ctx, err)
 		if isTemporaryError(err) {
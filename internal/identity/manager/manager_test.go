@@ -3,10 +3,12 @@ package manager
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/pomerium/pomerium/internal/directory"
@@ -99,6 +101,55 @@ func TestManager_refreshDirectoryUserGroups(t *testing.T) {
 	})
 }
 
+type putManyDataBrokerServiceClient struct {
+	databroker.DataBrokerServiceClient
+
+	requests []*databroker.PutManyRequest
+}
+
+func (c *putManyDataBrokerServiceClient) PutMany(ctx context.Context, in *databroker.PutManyRequest, opts ...grpc.CallOption) (*databroker.PutManyResponse, error) {
+	c.requests = append(c.requests, in)
+	return &databroker.PutManyResponse{}, nil
+}
+
+func TestManager_mergeUsers(t *testing.T) {
+	client := new(putManyDataBrokerServiceClient)
+	mgr := New(WithDataBrokerClient(client))
+
+	// about 10MB of directory users, more than fits in a single gRPC message
+	var directoryUsers []*directory.User
+	for i := 0; i < 10000; i++ {
+		directoryUsers = append(directoryUsers, &directory.User{
+			Id:          fmt.Sprintf("USER%d", i),
+			DisplayName: strings.Repeat("x", 1024),
+		})
+	}
+	mgr.mergeUsers(context.Background(), directoryUsers)
+
+	assert.Greater(t, len(client.requests), 1)
+	cnt := 0
+	for _, req := range client.requests {
+		assert.LessOrEqual(t, proto.Size(req), maxPutManySize)
+		cnt += len(req.GetRecords())
+	}
+	assert.Equal(t, len(directoryUsers), cnt)
+}
+
+func TestBatchRecords(t *testing.T) {
+	records := []*databroker.Record{
+		{Id: "r1", Type: "TYPE"},
+		{Id: "r2", Type: "TYPE"},
+		{Id: "r3", Type: "TYPE"},
+	}
+	size := proto.Size(&databroker.PutManyRequest{Records: records[:1]})
+
+	assert.Nil(t, batchRecords(nil, size))
+	assert.Equal(t, [][]*databroker.Record{records}, batchRecords(records, 3*size))
+	assert.Equal(t, [][]*databroker.Record{records[:2], records[2:]}, batchRecords(records, 2*size))
+	assert.Equal(t, [][]*databroker.Record{records[:1], records[1:2], records[2:]}, batchRecords(records, 1),
+		"records larger than the maximum size should be sent on their own")
+}
+
 func mkRecord(msg recordable) *databroker.Record {
 	any := protoutil.NewAny(msg)
 	return &databroker.Record{
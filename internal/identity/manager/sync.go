@@ -4,6 +4,10 @@ import (
 	"context"
 
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
 )
 
 type dataBrokerSyncer struct {
@@ -27,7 +31,13 @@ func newDataBrokerSyncer(
 		update: update,
 		clear:  clear,
 	}
-	syncer.syncer = databroker.NewSyncer("identity_manager", syncer)
+	// only the record types handled by the manager are sync'd
+	syncer.syncer = databroker.NewSyncer("identity_manager", syncer, databroker.WithTypeURLs(
+		grpcutil.GetTypeURL(new(directory.Group)),
+		grpcutil.GetTypeURL(new(directory.User)),
+		grpcutil.GetTypeURL(new(session.Session)),
+		grpcutil.GetTypeURL(new(user.User)),
+	))
 	return syncer
 }
 
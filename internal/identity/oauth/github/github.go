@@ -89,7 +89,9 @@ func New(ctx context.Context, o *oauth.Options) (*Provider, error) {
 
 // Authenticate creates an identity session with github from a authorization code, and follows up
 // call to the user and user group endpoint with the
-func (p *Provider) Authenticate(ctx context.Context, code string, v identity.State) (*oauth2.Token, error) {
+//
+// The code verifier is not used.
+func (p *Provider) Authenticate(ctx context.Context, code, codeVerifier string, v identity.State) (*oauth2.Token, error) {
 	oauth2Token, err := p.Oauth.Exchange(ctx, code)
 	if err != nil {
 		return nil, fmt.Errorf("github: token exchange failed %v", err)
@@ -241,7 +243,9 @@ func (p *Provider) Revoke(ctx context.Context, token *oauth2.Token) error {
 
 // GetSignInURL returns a URL to OAuth 2.0 provider's consent page
 // that asks for permissions for the required scopes explicitly.
-func (p *Provider) GetSignInURL(state string) (string, error) {
+//
+// The code verifier is not used.
+func (p *Provider) GetSignInURL(state, codeVerifier string) (string, error) {
 	return p.Oauth.AuthCodeURL(state, oauth2.AccessTypeOffline), nil
 }
 
@@ -33,4 +33,8 @@ type Options struct {
 	// AuthCodeOptions specifies additional key value pairs query params to add
 	// to the request flow signin url.
 	AuthCodeOptions map[string]string
+
+	// DisablePKCE disables PKCE and the nonce check for identity providers
+	// which reject them.
+	DisablePKCE bool
 }
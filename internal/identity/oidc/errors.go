@@ -26,3 +26,10 @@ var ErrMissingRefreshToken = errors.New("identity/oidc: missing refresh token")
 
 // ErrMissingAccessToken is returned when no access token was found.
 var ErrMissingAccessToken = errors.New("identity/oidc: missing access token")
+
+// ErrInvalidNonce is returned when the nonce of an id_token doesn't match the
+// nonce sent with the sign in request.
+var ErrInvalidNonce = errors.New("identity/oidc: invalid nonce")
+
+// ErrInvalidLogoutToken is returned when a back-channel logout token is invalid.
+var ErrInvalidLogoutToken = errors.New("identity/oidc: invalid logout token")
@@ -0,0 +1,64 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+)
+
+// BackChannelLogoutEvent is the event in the events claim of a logout token.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+const BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// A LogoutToken is a verified back-channel logout token. It identifies the
+// sessions to log out by the session id (`sid`), the subject (`sub`), or both.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+type LogoutToken struct {
+	Issuer    string
+	Subject   string
+	SessionID string
+}
+
+// VerifyLogoutToken verifies a back-channel logout token sent by the identity
+// provider. The signature, issuer, audience and expiry are verified like an
+// id_token.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#Validation
+func (p *Provider) VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (*LogoutToken, error) {
+	v, err := p.GetVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := v.Verify(ctx, rawLogoutToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLogoutToken, err)
+	}
+
+	var claims struct {
+		SessionID string                 `json:"sid"`
+		Events    map[string]interface{} `json:"events"`
+		Nonce     *string                `json:"nonce"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLogoutToken, err)
+	}
+
+	// logout tokens must not be mistaken for id_tokens, or the other way around
+	if _, ok := claims.Events[BackChannelLogoutEvent]; !ok {
+		return nil, fmt.Errorf("%w: missing back-channel logout event", ErrInvalidLogoutToken)
+	}
+	if claims.Nonce != nil {
+		return nil, fmt.Errorf("%w: unexpected nonce", ErrInvalidLogoutToken)
+	}
+	if token.Subject == "" && claims.SessionID == "" {
+		return nil, fmt.Errorf("%w: missing sub and sid", ErrInvalidLogoutToken)
+	}
+
+	return &LogoutToken{
+		Issuer:    token.Issuer,
+		Subject:   token.Subject,
+		SessionID: claims.SessionID,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+)
+
+func TestProvider_VerifyLogoutToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: privateKey},
+		(&jose.SignerOptions{}).WithHeader("kid", "KEY"))
+	require.NoError(t, err)
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 srv.URL,
+				"authorization_endpoint": srv.URL + "/oauth2/authorize",
+				"token_endpoint":         srv.URL + "/oauth2/token",
+				"jwks_uri":               srv.URL + "/.well-known/jwks.json",
+			})
+		case "/.well-known/jwks.json":
+			_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+				{Key: privateKey.Public(), KeyID: "KEY", Algorithm: string(jose.RS256), Use: "sig"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := New(context.Background(), &oauth.Options{
+		ProviderURL:  srv.URL,
+		ClientID:     "CLIENT_ID",
+		ClientSecret: "CLIENT_SECRET",
+		RedirectURL:  &url.URL{Scheme: "https", Host: "authenticate.example.com", Path: "/oauth2/callback"},
+	})
+	require.NoError(t, err)
+
+	newLogoutToken := func(t *testing.T, f func(claims map[string]interface{})) string {
+		claims := map[string]interface{}{
+			"iss":    srv.URL,
+			"aud":    "CLIENT_ID",
+			"sub":    "USER_ID",
+			"sid":    "SESSION_ID",
+			"iat":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Minute).Unix(),
+			"jti":    "JTI",
+			"events": map[string]interface{}{BackChannelLogoutEvent: map[string]interface{}{}},
+		}
+		f(claims)
+		rawLogoutToken, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+		require.NoError(t, err)
+		return rawLogoutToken
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		token, err := p.VerifyLogoutToken(context.Background(), newLogoutToken(t, func(claims map[string]interface{}) {}))
+		require.NoError(t, err)
+		assert.Equal(t, &LogoutToken{Issuer: srv.URL, Subject: "USER_ID", SessionID: "SESSION_ID"}, token)
+	})
+	t.Run("session id only", func(t *testing.T) {
+		token, err := p.VerifyLogoutToken(context.Background(), newLogoutToken(t, func(claims map[string]interface{}) {
+			delete(claims, "sub")
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, &LogoutToken{Issuer: srv.URL, SessionID: "SESSION_ID"}, token)
+	})
+	for _, tc := range []struct {
+		name string
+		f    func(claims map[string]interface{})
+	}{
+		{"missing sub and sid", func(claims map[string]interface{}) { delete(claims, "sub"); delete(claims, "sid") }},
+		{"missing event", func(claims map[string]interface{}) { claims["events"] = map[string]interface{}{} }},
+		{"nonce", func(claims map[string]interface{}) { claims["nonce"] = "NONCE" }},
+		{"wrong audience", func(claims map[string]interface{}) { claims["aud"] = "OTHER_CLIENT_ID" }},
+		{"wrong issuer", func(claims map[string]interface{}) { claims["iss"] = "https://idp.example.com" }},
+		{"expired", func(claims map[string]interface{}) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := p.VerifyLogoutToken(context.Background(), newLogoutToken(t, tc.f))
+			assert.ErrorIs(t, err, ErrInvalidLogoutToken)
+		})
+	}
+}
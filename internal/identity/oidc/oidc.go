@@ -5,6 +5,9 @@ package oidc
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +23,7 @@ import (
 	"github.com/pomerium/pomerium/internal/identity/oauth"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/internal/version"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
 )
 
 // Name identifies the generic OpenID Connect provider.
@@ -48,6 +52,10 @@ type Provider struct {
 	// to the request flow signin url.
 	AuthCodeOptions map[string]string
 
+	// DisablePKCE disables PKCE and the nonce check, for identity providers
+	// which reject them.
+	DisablePKCE bool
+
 	mu       sync.Mutex
 	provider *go_oidc.Provider
 }
@@ -65,6 +73,7 @@ func New(ctx context.Context, o *oauth.Options, options ...Option) (*Provider, e
 	if len(o.AuthCodeOptions) != 0 {
 		p.AuthCodeOptions = o.AuthCodeOptions
 	}
+	p.DisablePKCE = o.DisablePKCE
 
 	p.cfg = getConfig(append([]Option{
 		WithGetOauthConfig(func(provider *go_oidc.Provider) *oauth2.Config {
@@ -103,7 +112,10 @@ func New(ctx context.Context, o *oauth.Options, options ...Option) (*Provider, e
 // always provide a non-empty string and validate that it matches the
 // the state query parameter on your redirect callback.
 // See http://tools.ietf.org/html/rfc6749#section-10.12 for more info.
-func (p *Provider) GetSignInURL(state string) (string, error) {
+//
+// Unless PKCE is disabled, the code challenge and nonce derived from the code
+// verifier are added to the url.
+func (p *Provider) GetSignInURL(state, codeVerifier string) (string, error) {
 	oa, err := p.GetOauthConfig()
 	if err != nil {
 		return "", err
@@ -113,19 +125,33 @@ func (p *Provider) GetSignInURL(state string) (string, error) {
 	for k, v := range p.AuthCodeOptions {
 		opts = append(opts, oauth2.SetAuthURLParam(k, v))
 	}
+	if p.usePKCE(codeVerifier) {
+		opts = append(opts,
+			oauth2.SetAuthURLParam("code_challenge", getCodeChallenge(codeVerifier)),
+			oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+			oauth2.SetAuthURLParam("nonce", getNonce(codeVerifier)))
+	}
 	return oa.AuthCodeURL(state, opts...), nil
 }
 
 // Authenticate converts an authorization code returned from the identity
 // provider into a token which is then converted into a user session.
-func (p *Provider) Authenticate(ctx context.Context, code string, v identity.State) (*oauth2.Token, error) {
+//
+// Unless PKCE is disabled, the code verifier is sent with the code and the
+// nonce of the id_token must match the nonce derived from it.
+func (p *Provider) Authenticate(ctx context.Context, code, codeVerifier string, v identity.State) (*oauth2.Token, error) {
 	oa, err := p.GetOauthConfig()
 	if err != nil {
 		return nil, err
 	}
 
+	var opts []oauth2.AuthCodeOption
+	if p.usePKCE(codeVerifier) {
+		opts = append(opts, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
+	}
+
 	// Exchange converts an authorization code into a token.
-	oauth2Token, err := oa.Exchange(ctx, code)
+	oauth2Token, err := oa.Exchange(ctx, code, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("identity/oidc: token exchange failed: %w", err)
 	}
@@ -135,6 +161,10 @@ func (p *Provider) Authenticate(ctx context.Context, code string, v identity.Sta
 		return nil, fmt.Errorf("identity/oidc: failed getting id_token: %w", err)
 	}
 
+	if p.usePKCE(codeVerifier) && subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(getNonce(codeVerifier))) != 1 {
+		return nil, ErrInvalidNonce
+	}
+
 	if rawIDToken, ok := oauth2Token.Extra("id_token").(string); ok {
 		v.SetRawIDToken(rawIDToken)
 	}
@@ -225,6 +255,27 @@ func (p *Provider) getIDToken(ctx context.Context, t *oauth2.Token) (*go_oidc.ID
 	return v.Verify(ctx, rawIDToken)
 }
 
+func (p *Provider) usePKCE(codeVerifier string) bool {
+	return !p.DisablePKCE && codeVerifier != ""
+}
+
+// getCodeChallenge returns the S256 code challenge for the code verifier.
+//
+// https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func getCodeChallenge(codeVerifier string) string {
+	h := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// getNonce returns the nonce for the code verifier. Deriving it from the code
+// verifier binds the id_token to the same sign in request, without storing
+// another secret.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#NonceNotes
+func getNonce(codeVerifier string) string {
+	return base64.RawURLEncoding.EncodeToString(cryptutil.Hash("oidc nonce", []byte(codeVerifier)))
+}
+
 // Revoke enables a user to revoke her token. If the identity provider does not
 // support revocation an error is thrown.
 //
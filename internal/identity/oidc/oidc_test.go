@@ -0,0 +1,148 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+)
+
+type testState struct {
+	Subject string `json:"sub"`
+}
+
+func (s *testState) SetRawIDToken(rawIDToken string) {}
+
+func TestProvider_PKCE(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: privateKey},
+		(&jose.SignerOptions{}).WithHeader("kid", "KEY"))
+	require.NoError(t, err)
+
+	// the test identity provider checks the code verifier against the code challenge of the last sign in url and
+	// returns an id_token with its nonce, or the nonce override if set
+	var codeChallenge, nonce, nonceOverride string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 srv.URL,
+				"authorization_endpoint": srv.URL + "/oauth2/authorize",
+				"token_endpoint":         srv.URL + "/oauth2/token",
+				"userinfo_endpoint":      srv.URL + "/oauth2/userInfo",
+				"jwks_uri":               srv.URL + "/.well-known/jwks.json",
+			})
+		case "/.well-known/jwks.json":
+			_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+				{Key: privateKey.Public(), KeyID: "KEY", Algorithm: string(jose.RS256), Use: "sig"},
+			}})
+		case "/oauth2/token":
+			_ = r.ParseForm()
+			if codeChallenge != "" && getCodeChallenge(r.PostForm.Get("code_verifier")) != codeChallenge {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			claims := map[string]interface{}{
+				"iss": srv.URL,
+				"aud": "CLIENT_ID",
+				"sub": "USER_ID",
+				"exp": time.Now().Add(time.Hour).Unix(),
+			}
+			if nonceOverride != "" {
+				claims["nonce"] = nonceOverride
+			} else if nonce != "" {
+				claims["nonce"] = nonce
+			}
+			idToken, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "ACCESS_TOKEN",
+				"token_type":   "Bearer",
+				"id_token":     idToken,
+			})
+		case "/oauth2/userInfo":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	newProvider := func(t *testing.T, disablePKCE bool) *Provider {
+		p, err := New(context.Background(), &oauth.Options{
+			ProviderURL:  srv.URL,
+			ClientID:     "CLIENT_ID",
+			ClientSecret: "CLIENT_SECRET",
+			RedirectURL:  &url.URL{Scheme: "https", Host: "authenticate.example.com", Path: "/oauth2/callback"},
+			DisablePKCE:  disablePKCE,
+		})
+		require.NoError(t, err)
+		return p
+	}
+	signIn := func(t *testing.T, p *Provider, codeVerifier string) url.Values {
+		rawSignInURL, err := p.GetSignInURL("STATE", codeVerifier)
+		require.NoError(t, err)
+		signInURL, err := url.Parse(rawSignInURL)
+		require.NoError(t, err)
+		q := signInURL.Query()
+		codeChallenge, nonce, nonceOverride = q.Get("code_challenge"), q.Get("nonce"), ""
+		return q
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		p := newProvider(t, false)
+		q := signIn(t, p, "CODE_VERIFIER")
+		assert.Equal(t, "S256", q.Get("code_challenge_method"))
+		assert.Equal(t, getCodeChallenge("CODE_VERIFIER"), q.Get("code_challenge"))
+		assert.NotEmpty(t, q.Get("nonce"))
+		assert.NotEqual(t, q.Get("code_challenge"), q.Get("nonce"))
+
+		_, err := p.Authenticate(context.Background(), "CODE", "CODE_VERIFIER", &testState{})
+		assert.NoError(t, err)
+	})
+	t.Run("wrong code verifier", func(t *testing.T) {
+		p := newProvider(t, false)
+		signIn(t, p, "CODE_VERIFIER")
+
+		_, err := p.Authenticate(context.Background(), "CODE", "OTHER_CODE_VERIFIER", &testState{})
+		assert.Error(t, err)
+	})
+	t.Run("wrong nonce", func(t *testing.T) {
+		p := newProvider(t, false)
+		signIn(t, p, "CODE_VERIFIER")
+		nonceOverride = "OTHER_NONCE"
+
+		_, err := p.Authenticate(context.Background(), "CODE", "CODE_VERIFIER", &testState{})
+		assert.ErrorIs(t, err, ErrInvalidNonce)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		p := newProvider(t, true)
+		q := signIn(t, p, "CODE_VERIFIER")
+		assert.Empty(t, q.Get("code_challenge"))
+		assert.Empty(t, q.Get("nonce"))
+
+		_, err := p.Authenticate(context.Background(), "CODE", "CODE_VERIFIER", &testState{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetCodeChallenge(t *testing.T) {
+	// BASE64URL-ENCODE(SHA256(ASCII(code_verifier)))
+	assert.Equal(t, "pBvokOmo_vU_rFF4MeFzkkUbVJOGVGPCH14UHuQAhM4", getCodeChallenge("CODE_VERIFIER"))
+}
@@ -21,14 +21,18 @@ import (
 	"github.com/pomerium/pomerium/internal/identity/oidc/okta"
 	"github.com/pomerium/pomerium/internal/identity/oidc/onelogin"
 	"github.com/pomerium/pomerium/internal/identity/oidc/ping"
+	"github.com/pomerium/pomerium/internal/identity/saml"
 )
 
 // Authenticator is an interface representing the ability to authenticate with an identity provider.
+//
+// The code verifier is a secret generated for each sign in request. It's passed to GetSignInURL, and then to
+// Authenticate with the code returned by the identity provider, to bind the two together with PKCE and a nonce.
 type Authenticator interface {
-	Authenticate(context.Context, string, identity.State) (*oauth2.Token, error)
+	Authenticate(ctx context.Context, code, codeVerifier string, v identity.State) (*oauth2.Token, error)
 	Refresh(context.Context, *oauth2.Token, identity.State) (*oauth2.Token, error)
 	Revoke(context.Context, *oauth2.Token) error
-	GetSignInURL(state string) (string, error)
+	GetSignInURL(state, codeVerifier string) (string, error)
 	Name() string
 	LogOut() (*url.URL, error)
 	UpdateUserInfo(ctx context.Context, t *oauth2.Token, v interface{}) error
@@ -56,6 +60,8 @@ func NewAuthenticator(o oauth.Options) (a Authenticator, err error) {
 		a, err = onelogin.New(ctx, &o)
 	case ping.Name:
 		a, err = ping.New(ctx, &o)
+	case saml.Name:
+		a, err = saml.New(ctx, &o)
 	default:
 		return nil, fmt.Errorf("identity: unknown provider: %s", o.ProviderName)
 	}
@@ -65,6 +71,40 @@ func NewAuthenticator(o oauth.Options) (a Authenticator, err error) {
 	return a, nil
 }
 
+// ServiceProviderMetadata returns the service provider metadata published by
+// the authenticator, like the SAML metadata. ok is false if the authenticator
+// does not publish any.
+func ServiceProviderMetadata(a Authenticator) (metadata []byte, ok bool, err error) {
+	if v, isValue := a.(authenticatorValue); isValue {
+		a = v.Authenticator
+	}
+	mp, ok := a.(interface {
+		ServiceProviderMetadata() ([]byte, error)
+	})
+	if !ok {
+		return nil, false, nil
+	}
+	metadata, err = mp.ServiceProviderMetadata()
+	return metadata, true, err
+}
+
+// VerifyLogoutToken verifies an OpenID Connect back-channel logout token with
+// the authenticator. ok is false if the authenticator does not support
+// back-channel logout.
+func VerifyLogoutToken(ctx context.Context, a Authenticator, rawLogoutToken string) (token *oidc.LogoutToken, ok bool, err error) {
+	if v, isValue := a.(authenticatorValue); isValue {
+		a = v.Authenticator
+	}
+	lv, ok := a.(interface {
+		VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (*oidc.LogoutToken, error)
+	})
+	if !ok {
+		return nil, false, nil
+	}
+	token, err = lv.VerifyLogoutToken(ctx, rawLogoutToken)
+	return token, true, err
+}
+
 // wrap the Authenticator for the AtomicAuthenticator to support a nil default value.
 type authenticatorValue struct {
 	Authenticator
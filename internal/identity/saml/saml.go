@@ -0,0 +1,512 @@
+// Package saml implements a SAML 2.0 identity provider.
+//
+// Sign in is service provider initiated: the AuthnRequest is sent with the
+// HTTP-Redirect binding and the identity provider returns the signed
+// response to the callback url with the HTTP-POST binding.
+//
+// http://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+package saml
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	saml2 "github.com/russellhaering/gosaml2"
+	"github.com/russellhaering/gosaml2/types"
+	dsig "github.com/russellhaering/goxmldsig"
+	"golang.org/x/oauth2"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity/identity"
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/version"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// Name identifies the SAML 2.0 identity provider.
+const Name = "saml"
+
+// MetadataPath is the path the service provider metadata is served from.
+const MetadataPath = "/.well-known/pomerium/saml/metadata.xml"
+
+// maxMetadataSize is the maximum size of the identity provider metadata.
+const maxMetadataSize = 10 << 20
+
+// metadataTTL is how long the identity provider metadata is cached for, unless
+// the metadata expires earlier.
+const metadataTTL = 24 * time.Hour
+
+// minMetadataRefreshInterval is the minimum time between metadata fetches, so
+// that invalid responses or an unavailable identity provider don't result in a
+// fetch on every request.
+const minMetadataRefreshInterval = time.Minute
+
+// the extra key used to pass the assertion claims from Authenticate to UpdateUserInfo.
+const claimsExtraKey = "saml_claims"
+
+var metadataClient = httputil.NewLoggingClient(&http.Client{Timeout: time.Minute}, "idp_saml_metadata")
+
+var (
+	// ErrMissingEntityID is returned when the service provider entity id is not set.
+	ErrMissingEntityID = errors.New("identity/saml: missing service provider entity id")
+	// ErrMissingRedirectBinding is returned when the identity provider does not
+	// support the HTTP-Redirect binding for single sign on.
+	ErrMissingRedirectBinding = errors.New("identity/saml: identity provider does not support the HTTP-Redirect binding")
+	// ErrMissingSigningCertificate is returned when the identity provider metadata
+	// does not contain any signing certificates.
+	ErrMissingSigningCertificate = errors.New("identity/saml: identity provider metadata has no signing certificates")
+	// ErrRefreshNotSupported is returned on refresh, SAML sessions last until
+	// the assertion expires.
+	ErrRefreshNotSupported = errors.New("identity/saml: refresh not supported")
+)
+
+// well known attribute names for the standard claims. The first attribute
+// found is used.
+var claimAttributes = map[string][]string{
+	"email": {
+		"email",
+		"mail",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+	},
+	"name": {
+		"name",
+		"displayName",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+	},
+	"given_name": {
+		"given_name",
+		"givenName",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname",
+		"urn:oid:2.5.4.42",
+	},
+	"family_name": {
+		"family_name",
+		"surname",
+		"sn",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname",
+		"urn:oid:2.5.4.4",
+	},
+	"groups": {
+		"groups",
+		"memberOf",
+		"http://schemas.microsoft.com/ws/2008/06/identity/claims/groups",
+		"http://schemas.xmlsoap.org/claims/Group",
+		"urn:oid:1.3.6.1.4.1.5923.1.5.1.1",
+	},
+}
+
+// multi-valued claims are always returned as a list.
+var multiValuedClaims = map[string]bool{
+	"groups": true,
+}
+
+// Provider is a SAML 2.0 identity provider.
+type Provider struct {
+	entityID    string
+	acsURL      string
+	metadataURL string
+	clock       *dsig.Clock
+
+	mu        sync.Mutex
+	sp        *saml2.SAMLServiceProvider
+	spExpiry  time.Time
+	lastFetch time.Time
+}
+
+// New creates a new SAML 2.0 identity provider. The provider url is the
+// location of the identity provider's metadata and the client id is used
+// as the service provider's entity id.
+func New(ctx context.Context, o *oauth.Options) (*Provider, error) {
+	if o.ProviderURL == "" {
+		return nil, oidc.ErrMissingProviderURL
+	}
+	if o.ClientID == "" {
+		return nil, ErrMissingEntityID
+	}
+	if o.RedirectURL == nil {
+		return nil, fmt.Errorf("identity/saml: missing redirect url")
+	}
+	return &Provider{
+		entityID:    o.ClientID,
+		acsURL:      o.RedirectURL.String(),
+		metadataURL: o.ProviderURL,
+	}, nil
+}
+
+// GetSignInURL returns the identity provider's single sign on url with a
+// deflated AuthnRequest. The state is passed along as the RelayState. The
+// code verifier is not used, SAML responses are bound to the request by the
+// RelayState.
+func (p *Provider) GetSignInURL(state, codeVerifier string) (string, error) {
+	sp, err := p.getServiceProvider(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	requestID, err := authnRequestID(codeVerifier)
+	if err != nil {
+		return "", err
+	}
+	doc, err := sp.BuildAuthRequestDocumentNoSig()
+	if err != nil {
+		return "", fmt.Errorf("identity/saml: failed to build authn request: %w", err)
+	}
+	doc.Root().CreateAttr("ID", requestID)
+	return sp.BuildAuthURLRedirect(state, doc)
+}
+
+// Authenticate validates the base64 encoded SAMLResponse returned from the
+// identity provider and fills v with the claims from the assertion.
+func (p *Provider) Authenticate(ctx context.Context, code, codeVerifier string, v identity.State) (*oauth2.Token, error) {
+	sp, err := p.getServiceProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := sp.RetrieveAssertionInfo(code)
+	if errors.As(err, new(saml2.ErrVerification)) {
+		// the identity provider may have rotated its signing certificate
+		if sp, ok := p.refreshServiceProvider(ctx, sp); ok {
+			info, err = sp.RetrieveAssertionInfo(code)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("identity/saml: invalid response: %w", err)
+	}
+	if info.WarningInfo.InvalidTime {
+		return nil, fmt.Errorf("identity/saml: assertion is expired or not yet valid")
+	}
+	if info.WarningInfo.NotInAudience {
+		return nil, fmt.Errorf("identity/saml: assertion is not intended for %s", p.entityID)
+	}
+	if err := checkInResponseTo(info, codeVerifier); err != nil {
+		return nil, err
+	}
+
+	claims := getClaims(sp.IdentityProviderIssuer, info)
+	rawClaims, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rawClaims, v); err != nil {
+		return nil, fmt.Errorf("identity/saml: couldn't unmarshal claims: %w", err)
+	}
+
+	token := new(oauth2.Token)
+	if info.SessionNotOnOrAfter != nil {
+		token.Expiry = *info.SessionNotOnOrAfter
+	}
+	return token.WithExtra(map[string]interface{}{
+		claimsExtraKey: json.RawMessage(rawClaims),
+	}), nil
+}
+
+// authnRequestID derives the ID of the AuthnRequest from the code verifier,
+// which is kept in the encrypted state, so that the response can be bound to
+// the sign in request that started it without storing the ID separately.
+func authnRequestID(codeVerifier string) (string, error) {
+	if codeVerifier == "" {
+		return "", fmt.Errorf("identity/saml: code verifier is required")
+	}
+	// IDs must start with a letter or underscore (xs:ID)
+	return "_" + hex.EncodeToString(cryptutil.Hash("saml authn request id", []byte(codeVerifier))), nil
+}
+
+// checkInResponseTo verifies that every assertion was issued in response to
+// the AuthnRequest bound to the code verifier. Unsolicited (IdP initiated)
+// responses have no InResponseTo and are rejected.
+func checkInResponseTo(info *saml2.AssertionInfo, codeVerifier string) error {
+	requestID, err := authnRequestID(codeVerifier)
+	if err != nil {
+		return err
+	}
+	for _, assertion := range info.Assertions {
+		var inResponseTo string
+		if assertion.Subject != nil && assertion.Subject.SubjectConfirmation != nil &&
+			assertion.Subject.SubjectConfirmation.SubjectConfirmationData != nil {
+			inResponseTo = assertion.Subject.SubjectConfirmation.SubjectConfirmationData.InResponseTo
+		}
+		if inResponseTo == "" {
+			return fmt.Errorf("identity/saml: assertion is missing InResponseTo")
+		}
+		if subtle.ConstantTimeCompare([]byte(inResponseTo), []byte(requestID)) != 1 {
+			return fmt.Errorf("identity/saml: assertion is not a response to this sign in request")
+		}
+	}
+	return nil
+}
+
+// Refresh is not supported. SAML sessions are valid until the session expires
+// at the identity provider.
+func (p *Provider) Refresh(ctx context.Context, t *oauth2.Token, v identity.State) (*oauth2.Token, error) {
+	return nil, ErrRefreshNotSupported
+}
+
+// Revoke is not supported.
+func (p *Provider) Revoke(ctx context.Context, t *oauth2.Token) error {
+	return oidc.ErrRevokeNotImplemented
+}
+
+// LogOut is not supported.
+func (p *Provider) LogOut() (*url.URL, error) {
+	return nil, oidc.ErrSignoutNotImplemented
+}
+
+// UpdateUserInfo fills v with the claims from the assertion the token was
+// issued for. SAML has no user info endpoint so tokens which are not freshly
+// returned from Authenticate are left as is.
+func (p *Provider) UpdateUserInfo(ctx context.Context, t *oauth2.Token, v interface{}) error {
+	if t == nil {
+		return nil
+	}
+	rawClaims, ok := t.Extra(claimsExtraKey).(json.RawMessage)
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(rawClaims, v); err != nil {
+		return fmt.Errorf("identity/saml: couldn't unmarshal claims: %w", err)
+	}
+	return nil
+}
+
+// Name returns the provider name.
+func (p *Provider) Name() string {
+	return Name
+}
+
+// ServiceProviderMetadata returns the SAML metadata for the service provider.
+func (p *Provider) ServiceProviderMetadata() ([]byte, error) {
+	sp := &saml2.SAMLServiceProvider{
+		ServiceProviderIssuer:       p.entityID,
+		AssertionConsumerServiceURL: p.acsURL,
+		Clock:                       p.clock,
+	}
+	md, err := sp.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("identity/saml: failed to build metadata: %w", err)
+	}
+	bs, err := xml.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("identity/saml: failed to marshal metadata: %w", err)
+	}
+	return append([]byte(xml.Header), bs...), nil
+}
+
+// getServiceProvider returns the service provider for the identity provider's
+// metadata. Expired metadata is re-fetched, but is still used while the
+// identity provider is unavailable.
+func (p *Provider) getServiceProvider(ctx context.Context) (*saml2.SAMLServiceProvider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.sp != nil && now.Before(p.spExpiry) {
+		return p.sp, nil
+	}
+
+	sp, err := p.fetchServiceProvider(ctx, now)
+	if err != nil && p.sp != nil {
+		log.Warn(ctx).Err(err).Msg("identity/saml: failed to refresh metadata, using the expired metadata")
+		p.spExpiry = now.Add(minMetadataRefreshInterval)
+		return p.sp, nil
+	}
+	return sp, err
+}
+
+// refreshServiceProvider re-fetches the identity provider's metadata after sp
+// failed to verify a response. ok is false if there is no newer service
+// provider to retry with.
+func (p *Provider) refreshServiceProvider(ctx context.Context, sp *saml2.SAMLServiceProvider) (_ *saml2.SAMLServiceProvider, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// the metadata was already refreshed by another request
+	if p.sp != sp {
+		return p.sp, p.sp != nil
+	}
+
+	now := time.Now()
+	if now.Sub(p.lastFetch) < minMetadataRefreshInterval {
+		return nil, false
+	}
+
+	newSP, err := p.fetchServiceProvider(ctx, now)
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("identity/saml: failed to refresh metadata")
+		return nil, false
+	}
+	return newSP, true
+}
+
+// fetchServiceProvider fetches the identity provider's metadata and replaces
+// the current service provider. p.mu must be held.
+func (p *Provider) fetchServiceProvider(ctx context.Context, now time.Time) (*saml2.SAMLServiceProvider, error) {
+	p.lastFetch = now
+
+	md, err := fetchMetadata(ctx, p.metadataURL)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err := p.newServiceProvider(md)
+	if err != nil {
+		return nil, err
+	}
+
+	p.sp = sp
+	p.spExpiry = now.Add(metadataTTL)
+	if !md.ValidUntil.IsZero() && md.ValidUntil.Before(p.spExpiry) {
+		p.spExpiry = md.ValidUntil
+	}
+	if p.spExpiry.Before(now.Add(minMetadataRefreshInterval)) {
+		p.spExpiry = now.Add(minMetadataRefreshInterval)
+	}
+	return sp, nil
+}
+
+func (p *Provider) newServiceProvider(md *types.EntityDescriptor) (*saml2.SAMLServiceProvider, error) {
+	if md.IDPSSODescriptor == nil {
+		return nil, fmt.Errorf("identity/saml: metadata has no identity provider descriptor")
+	}
+
+	var ssoURL string
+	for _, sso := range md.IDPSSODescriptor.SingleSignOnServices {
+		if sso.Binding == saml2.BindingHttpRedirect {
+			ssoURL = sso.Location
+			break
+		}
+	}
+	if ssoURL == "" {
+		return nil, ErrMissingRedirectBinding
+	}
+
+	certStore := &dsig.MemoryX509CertificateStore{}
+	for _, kd := range md.IDPSSODescriptor.KeyDescriptors {
+		// key descriptors without a use are used for both signing and encryption
+		if kd.Use != "" && kd.Use != "signing" {
+			continue
+		}
+		for _, xcert := range kd.KeyInfo.X509Data.X509Certificates {
+			der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(xcert.Data), ""))
+			if err != nil {
+				return nil, fmt.Errorf("identity/saml: invalid metadata certificate: %w", err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("identity/saml: invalid metadata certificate: %w", err)
+			}
+			certStore.Roots = append(certStore.Roots, cert)
+		}
+	}
+	if len(certStore.Roots) == 0 {
+		return nil, ErrMissingSigningCertificate
+	}
+
+	return &saml2.SAMLServiceProvider{
+		IdentityProviderSSOURL:      ssoURL,
+		IdentityProviderSSOBinding:  saml2.BindingHttpRedirect,
+		IdentityProviderIssuer:      md.EntityID,
+		ServiceProviderIssuer:       p.entityID,
+		AssertionConsumerServiceURL: p.acsURL,
+		AudienceURI:                 p.entityID,
+		IDPCertificateStore:         certStore,
+		Clock:                       p.clock,
+	}, nil
+}
+
+func fetchMetadata(ctx context.Context, metadataURL string) (*types.EntityDescriptor, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("identity/saml: invalid metadata url: %w", err)
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+
+	res, err := metadataClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("identity/saml: could not fetch metadata: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("identity/saml: could not fetch metadata: unexpected status code %d", res.StatusCode)
+	}
+
+	bs, err := io.ReadAll(io.LimitReader(res.Body, maxMetadataSize))
+	if err != nil {
+		return nil, fmt.Errorf("identity/saml: could not fetch metadata: %w", err)
+	}
+
+	var md types.EntityDescriptor
+	if err := xml.Unmarshal(bs, &md); err != nil {
+		return nil, fmt.Errorf("identity/saml: invalid metadata: %w", err)
+	}
+	return &md, nil
+}
+
+// getClaims maps the assertion into session claims. Every attribute is
+// available as a claim using its name and the well known attributes are
+// additionally mapped to their standard claims.
+func getClaims(issuer string, info *saml2.AssertionInfo) map[string]interface{} {
+	claims := map[string]interface{}{}
+	for name, attr := range info.Values {
+		values := getAttributeValues(attr)
+		switch len(values) {
+		case 0:
+		case 1:
+			claims[name] = values[0]
+		default:
+			claims[name] = values
+		}
+	}
+
+	for claim, names := range claimAttributes {
+		for _, name := range names {
+			values := getAttributeValues(info.Values[name])
+			if len(values) == 0 {
+				continue
+			}
+			if multiValuedClaims[claim] || len(values) > 1 {
+				claims[claim] = values
+			} else {
+				claims[claim] = values[0]
+			}
+			break
+		}
+	}
+
+	claims["sub"] = info.NameID
+	if issuer != "" {
+		claims["iss"] = issuer
+	}
+	if info.SessionIndex != "" {
+		claims["sid"] = info.SessionIndex
+	}
+	return claims
+}
+
+func getAttributeValues(attr types.Attribute) []string {
+	var values []string
+	for _, v := range attr.Values {
+		if v.Value != "" {
+			values = append(values, v.Value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,378 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/russellhaering/gosaml2/types"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+)
+
+const (
+	testEntityID = "https://authenticate.example.com"
+	testACSURL   = "https://authenticate.example.com/oauth2/callback"
+
+	testCodeVerifier = "CODE_VERIFIER"
+)
+
+var testMetadataTemplate = template.Must(template.New("").Parse(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="{{.Issuer}}">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data>
+          <ds:X509Certificate>{{.Certificate}}</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="{{.SSOURL}}/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="{{.SSOURL}}/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`))
+
+var testResponseTemplate = template.Must(template.New("").Parse(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_response" Version="2.0" IssueInstant="{{.Now}}" Destination="{{.ACSURL}}">
+  <saml:Issuer>{{.Issuer}}</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+  <saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion" Version="2.0" IssueInstant="{{.Now}}">
+    <saml:Issuer>{{.Issuer}}</saml:Issuer>
+    <saml:Subject>
+      <saml:NameID Format="urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified">user-id</saml:NameID>
+      <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <saml:SubjectConfirmationData NotOnOrAfter="{{.NotOnOrAfter}}" Recipient="{{.ACSURL}}"{{if .InResponseTo}} InResponseTo="{{.InResponseTo}}"{{end}}/>
+      </saml:SubjectConfirmation>
+    </saml:Subject>
+    <saml:Conditions NotBefore="{{.NotBefore}}" NotOnOrAfter="{{.NotOnOrAfter}}">
+      <saml:AudienceRestriction><saml:Audience>{{.Audience}}</saml:Audience></saml:AudienceRestriction>
+    </saml:Conditions>
+    <saml:AuthnStatement AuthnInstant="{{.Now}}" SessionIndex="session-index" SessionNotOnOrAfter="{{.SessionNotOnOrAfter}}"/>
+    <saml:AttributeStatement>
+      <saml:Attribute Name="http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"><saml:AttributeValue>user@example.com</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="displayName"><saml:AttributeValue>User</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="memberOf"><saml:AttributeValue>admins</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="department"><saml:AttributeValue>engineering</saml:AttributeValue><saml:AttributeValue>security</saml:AttributeValue></saml:Attribute>
+    </saml:AttributeStatement>
+  </saml:Assertion>
+</samlp:Response>`))
+
+type testIdP struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	keyStore    dsig.X509KeyStore
+	fetches     int
+	unavailable bool
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	idp := &testIdP{keyStore: dsig.RandomKeyStoreForTest()}
+
+	idp.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+
+		idp.fetches++
+		if idp.unavailable {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		_, cert, err := idp.keyStore.GetKeyPair()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = testMetadataTemplate.Execute(w, map[string]string{
+			"Issuer":      idp.issuer(),
+			"Certificate": base64.StdEncoding.EncodeToString(cert),
+			"SSOURL":      idp.URL + "/sso",
+		})
+	}))
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+// rotateKey replaces the signing key and returns the number of metadata
+// fetches so far.
+func (idp *testIdP) rotateKey() int {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.keyStore = dsig.RandomKeyStoreForTest()
+	return idp.fetches
+}
+
+func (idp *testIdP) getFetches() int {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	return idp.fetches
+}
+
+func (idp *testIdP) setUnavailable(unavailable bool) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.unavailable = unavailable
+}
+
+func (idp *testIdP) issuer() string {
+	return idp.URL + "/metadata"
+}
+
+// response returns a base64 encoded SAMLResponse to the AuthnRequest with the
+// given ID. The assertion is signed if sign is true.
+func (idp *testIdP) response(t *testing.T, audience, inResponseTo string, sign bool) string {
+	now := time.Now().UTC()
+	var buf bytes.Buffer
+	require.NoError(t, testResponseTemplate.Execute(&buf, map[string]string{
+		"Issuer":              idp.issuer(),
+		"ACSURL":              testACSURL,
+		"Audience":            audience,
+		"InResponseTo":        inResponseTo,
+		"Now":                 now.Format(time.RFC3339),
+		"NotBefore":           now.Add(-time.Minute).Format(time.RFC3339),
+		"NotOnOrAfter":        now.Add(5 * time.Minute).Format(time.RFC3339),
+		"SessionNotOnOrAfter": now.Add(8 * time.Hour).Format(time.RFC3339),
+	}))
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromBytes(buf.Bytes()))
+	if sign {
+		assertion := doc.Root().FindElement("./Assertion")
+		idp.mu.Lock()
+		ctx := dsig.NewDefaultSigningContext(idp.keyStore)
+		idp.mu.Unlock()
+		ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+		signed, err := ctx.SignEnveloped(assertion)
+		require.NoError(t, err)
+		doc.Root().RemoveChild(assertion)
+		doc.Root().AddChild(signed)
+	}
+	bs, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(bs)
+}
+
+// testRequestID returns the ID of the AuthnRequest bound to testCodeVerifier.
+func testRequestID(t *testing.T) string {
+	id, err := authnRequestID(testCodeVerifier)
+	require.NoError(t, err)
+	return id
+}
+
+func newTestProvider(t *testing.T, idp *testIdP) *Provider {
+	p, err := New(context.Background(), &oauth.Options{
+		ProviderName: Name,
+		ProviderURL:  idp.issuer(),
+		ClientID:     testEntityID,
+		RedirectURL:  mustParseURL(testACSURL),
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(context.Background(), &oauth.Options{ClientID: testEntityID, RedirectURL: mustParseURL(testACSURL)})
+	assert.Error(t, err)
+	_, err = New(context.Background(), &oauth.Options{ProviderURL: "https://idp.example.com", RedirectURL: mustParseURL(testACSURL)})
+	assert.Equal(t, ErrMissingEntityID, err)
+}
+
+func TestProvider_GetSignInURL(t *testing.T) {
+	idp := newTestIdP(t)
+	p := newTestProvider(t, idp)
+
+	rawSignInURL, err := p.GetSignInURL("STATE", testCodeVerifier)
+	require.NoError(t, err)
+
+	signInURL, err := url.Parse(rawSignInURL)
+	require.NoError(t, err)
+	assert.Equal(t, idp.URL+"/sso/redirect", signInURL.Scheme+"://"+signInURL.Host+signInURL.Path)
+	assert.Equal(t, "STATE", signInURL.Query().Get("RelayState"))
+
+	deflated, err := base64.StdEncoding.DecodeString(signInURL.Query().Get("SAMLRequest"))
+	require.NoError(t, err)
+	authnRequest, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	require.NoError(t, err)
+	assert.Contains(t, string(authnRequest), fmt.Sprintf(`AssertionConsumerServiceURL="%s"`, testACSURL))
+	assert.Contains(t, string(authnRequest), fmt.Sprintf(`<saml:Issuer>%s</saml:Issuer>`, testEntityID))
+	assert.Contains(t, string(authnRequest), fmt.Sprintf(`ID="%s"`, testRequestID(t)))
+
+	_, err = p.GetSignInURL("STATE", "")
+	assert.Error(t, err, "the request id should be bound to a code verifier")
+}
+
+func TestProvider_Authenticate(t *testing.T) {
+	idp := newTestIdP(t)
+
+	t.Run("valid", func(t *testing.T) {
+		p := newTestProvider(t, idp)
+
+		claims := map[string]interface{}{}
+		token, err := p.Authenticate(context.Background(), idp.response(t, testEntityID, testRequestID(t), true), testCodeVerifier, (*testState)(&claims))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"sub":         "user-id",
+			"iss":         idp.issuer(),
+			"sid":         "session-index",
+			"email":       "user@example.com",
+			"name":        "User",
+			"groups":      []interface{}{"admins"},
+			"displayName": "User",
+			"memberOf":    "admins",
+			"department":  []interface{}{"engineering", "security"},
+			"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress": "user@example.com",
+		}, claims)
+		assert.WithinDuration(t, time.Now().Add(8*time.Hour), token.Expiry, time.Minute)
+
+		userInfo := map[string]interface{}{}
+		assert.NoError(t, p.UpdateUserInfo(context.Background(), token, &userInfo))
+		assert.Equal(t, claims, userInfo)
+
+		userInfo = map[string]interface{}{}
+		assert.NoError(t, p.UpdateUserInfo(context.Background(), &oauth2.Token{}, &userInfo))
+		assert.Empty(t, userInfo)
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		p := newTestProvider(t, idp)
+
+		claims := map[string]interface{}{}
+		_, err := p.Authenticate(context.Background(), idp.response(t, testEntityID, testRequestID(t), false), testCodeVerifier, (*testState)(&claims))
+		assert.Error(t, err)
+		assert.Empty(t, claims)
+	})
+	t.Run("wrong audience", func(t *testing.T) {
+		p := newTestProvider(t, idp)
+
+		claims := map[string]interface{}{}
+		_, err := p.Authenticate(context.Background(), idp.response(t, "https://other.example.com", testRequestID(t), true), testCodeVerifier, (*testState)(&claims))
+		assert.Error(t, err)
+		assert.Empty(t, claims)
+	})
+	t.Run("mismatched in response to", func(t *testing.T) {
+		p := newTestProvider(t, idp)
+
+		claims := map[string]interface{}{}
+		_, err := p.Authenticate(context.Background(), idp.response(t, testEntityID, testRequestID(t), true), "OTHER_CODE_VERIFIER", (*testState)(&claims))
+		assert.Error(t, err)
+		assert.Empty(t, claims)
+	})
+	t.Run("missing in response to", func(t *testing.T) {
+		p := newTestProvider(t, idp)
+
+		claims := map[string]interface{}{}
+		_, err := p.Authenticate(context.Background(), idp.response(t, testEntityID, "", true), testCodeVerifier, (*testState)(&claims))
+		assert.Error(t, err)
+		assert.Empty(t, claims)
+	})
+	t.Run("other identity provider", func(t *testing.T) {
+		p := newTestProvider(t, idp)
+
+		claims := map[string]interface{}{}
+		_, err := p.Authenticate(context.Background(), newTestIdP(t).response(t, testEntityID, testRequestID(t), true), testCodeVerifier, (*testState)(&claims))
+		assert.Error(t, err)
+		assert.Empty(t, claims)
+	})
+}
+
+func TestProvider_getServiceProvider(t *testing.T) {
+	t.Run("expired", func(t *testing.T) {
+		idp := newTestIdP(t)
+		p := newTestProvider(t, idp)
+
+		_, err := p.GetSignInURL("STATE", testCodeVerifier)
+		require.NoError(t, err)
+		_, err = p.GetSignInURL("STATE", testCodeVerifier)
+		require.NoError(t, err)
+		assert.Equal(t, 1, idp.getFetches(), "metadata should be cached")
+
+		p.spExpiry = time.Now().Add(-time.Second)
+		_, err = p.GetSignInURL("STATE", testCodeVerifier)
+		require.NoError(t, err)
+		assert.Equal(t, 2, idp.getFetches(), "expired metadata should be re-fetched")
+	})
+	t.Run("unavailable", func(t *testing.T) {
+		idp := newTestIdP(t)
+		p := newTestProvider(t, idp)
+
+		_, err := p.GetSignInURL("STATE", testCodeVerifier)
+		require.NoError(t, err)
+
+		idp.setUnavailable(true)
+		p.spExpiry = time.Now().Add(-time.Second)
+		_, err = p.GetSignInURL("STATE", testCodeVerifier)
+		assert.NoError(t, err, "expired metadata should be used while the identity provider is unavailable")
+		_, err = p.GetSignInURL("STATE", testCodeVerifier)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, idp.getFetches(), "failed fetches should not be retried immediately")
+	})
+	t.Run("rotated signing key", func(t *testing.T) {
+		idp := newTestIdP(t)
+		p := newTestProvider(t, idp)
+
+		_, err := p.GetSignInURL("STATE", testCodeVerifier)
+		require.NoError(t, err)
+		fetches := idp.rotateKey()
+
+		claims := map[string]interface{}{}
+		_, err = p.Authenticate(context.Background(), idp.response(t, testEntityID, testRequestID(t), true), testCodeVerifier, (*testState)(&claims))
+		assert.Error(t, err, "metadata should not be re-fetched more than once a minute")
+		assert.Equal(t, fetches, idp.getFetches())
+
+		p.lastFetch = time.Now().Add(-2 * minMetadataRefreshInterval)
+		_, err = p.Authenticate(context.Background(), idp.response(t, testEntityID, testRequestID(t), true), testCodeVerifier, (*testState)(&claims))
+		assert.NoError(t, err, "metadata should be re-fetched when the signature is invalid")
+		assert.Equal(t, fetches+1, idp.getFetches())
+		assert.Equal(t, "user-id", claims["sub"])
+	})
+}
+
+func TestProvider_Refresh(t *testing.T) {
+	p := newTestProvider(t, newTestIdP(t))
+	_, err := p.Refresh(context.Background(), &oauth2.Token{}, nil)
+	assert.Equal(t, ErrRefreshNotSupported, err)
+}
+
+func TestProvider_ServiceProviderMetadata(t *testing.T) {
+	p := newTestProvider(t, newTestIdP(t))
+
+	bs, err := p.ServiceProviderMetadata()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(bs), xml.Header))
+
+	var md types.EntityDescriptor
+	require.NoError(t, xml.Unmarshal(bs, &md))
+	assert.Equal(t, testEntityID, md.EntityID)
+	require.NotNil(t, md.SPSSODescriptor)
+	assert.True(t, md.SPSSODescriptor.WantAssertionsSigned)
+	require.Len(t, md.SPSSODescriptor.AssertionConsumerServices, 1)
+	assert.Equal(t, testACSURL, md.SPSSODescriptor.AssertionConsumerServices[0].Location)
+}
+
+type testState map[string]interface{}
+
+func (s *testState) SetRawIDToken(rawIDToken string) {}
+
+func mustParseURL(rawURL string) *url.URL {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+var (
+	// AuthorizeViews contains opencensus views for authorize metrics
+	AuthorizeViews = []*view.View{AuthorizeShadowPolicyDisagreementsView}
+
+	authorizeShadowPolicyDisagreements = stats.Int64(
+		"authorize_shadow_policy_disagreements_total",
+		"Number of requests where the decision of a route's shadow policy differed from the enforced decision",
+		stats.UnitDimensionless)
+
+	// AuthorizeShadowPolicyDisagreementsView is an OpenCensus view that counts the requests where a shadow policy
+	// disagreed with the enforced decision, by host and by the two decisions
+	AuthorizeShadowPolicyDisagreementsView = &view.View{
+		Name:        authorizeShadowPolicyDisagreements.Name(),
+		Description: authorizeShadowPolicyDisagreements.Description(),
+		Measure:     authorizeShadowPolicyDisagreements,
+		TagKeys:     []tag.Key{TagKeyHost, TagKeyAuthorizeDecision, TagKeyAuthorizeShadowDecision},
+		Aggregation: view.Count(),
+	}
+)
+
+// RecordShadowPolicyDisagreement records a request to the given host where the decision of the route's shadow policy
+// differed from the enforced decision.
+func RecordShadowPolicyDisagreement(ctx context.Context, host string, allowed, shadowAllowed bool) {
+	err := stats.RecordWithTags(ctx,
+		[]tag.Mutator{
+			tag.Upsert(TagKeyHost, host),
+			tag.Upsert(TagKeyAuthorizeDecision, getDecision(allowed)),
+			tag.Upsert(TagKeyAuthorizeShadowDecision, getDecision(shadowAllowed)),
+		},
+		authorizeShadowPolicyDisagreements.M(1),
+	)
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("internal/telemetry/metrics: failed to record")
+	}
+}
+
+func getDecision(allowed bool) string {
+	if allowed {
+		return "allow"
+	}
+	return "deny"
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+)
+
+func Test_RecordShadowPolicyDisagreement(t *testing.T) {
+	tests := []struct {
+		name                   string
+		allowed, shadowAllowed bool
+		want                   string
+	}{
+		{"stricter", true, false, "{ { {decision allow}{host example.com}{shadow_decision deny} }&{"},
+		{"looser", false, true, "{ { {decision deny}{host example.com}{shadow_decision allow} }&{"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view.Unregister(AuthorizeViews...)
+			view.Register(AuthorizeViews...)
+			RecordShadowPolicyDisagreement(context.Background(), "example.com", tt.allowed, tt.shadowAllowed)
+
+			testDataRetrieval(AuthorizeShadowPolicyDisagreementsView, t, tt.want)
+		})
+	}
+}
@@ -18,6 +18,9 @@ var (
 	TagKeyStorageOperation = tag.MustNewKey("operation")
 	TagKeyStorageResult    = tag.MustNewKey("result")
 	TagKeyStorageBackend   = tag.MustNewKey("backend")
+
+	TagKeyAuthorizeDecision       = tag.MustNewKey("decision")
+	TagKeyAuthorizeShadowDecision = tag.MustNewKey("shadow_decision")
 )
 
 // Default distributions used by views in this package.
@@ -38,6 +41,7 @@ var (
 // DefaultViews are a set of default views to view HTTP and GRPC metrics.
 var (
 	DefaultViews = [][]*view.View{
+		AuthorizeViews,
 		GRPCClientViews,
 		GRPCServerViews,
 		HTTPClientViews,
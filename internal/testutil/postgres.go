@@ -0,0 +1,56 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ory/dockertest/v3"
+
+	// register the postgres driver
+	_ "github.com/lib/pq"
+)
+
+// WithTestPostgres creates a test postgres instance using docker.
+func WithTestPostgres(handler func(dsn string) error) error {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), maxWait)
+	defer clearTimeout()
+
+	// uses a sensible default on windows (tcp/http) and linux/osx (socket)
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return err
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "14",
+		Env:        []string{"POSTGRES_DB=pomeriumtest", "POSTGRES_HOST_AUTH_METHOD=trust"},
+	})
+	if err != nil {
+		return err
+	}
+	_ = resource.Expire(uint(maxWait.Seconds()))
+
+	dsn := fmt.Sprintf("postgres://postgres@%s/pomeriumtest?sslmode=disable", resource.GetHostPort("5432/tcp"))
+	if err := pool.Retry(func() error {
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		return db.PingContext(ctx)
+	}); err != nil {
+		_ = pool.Purge(resource)
+		return err
+	}
+
+	e := handler(dsn)
+
+	if err := pool.Purge(resource); err != nil {
+		return err
+	}
+
+	return e
+}
@@ -10,6 +10,7 @@ const (
 	QueryEnrollmentToken    = "pomerium_enrollment_token" //nolint
 	QueryIsProgrammatic     = "pomerium_programmatic"
 	QueryForwardAuth        = "pomerium_forward_auth"
+	QueryIdentityProvider   = "pomerium_idp"
 	QueryPomeriumJWT        = "pomerium_jwt"
 	QuerySession            = "pomerium_session"
 	QuerySessionEncrypted   = "pomerium_session_encrypted"
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+var (
+	defaultCheckpointInterval = 10 * time.Second
+	defaultRequestTimeout     = 30 * time.Second
+)
+
+type config struct {
+	dataBrokerClient   databroker.DataBrokerServiceClient
+	url                string
+	secret             []byte
+	types              []string
+	dataTypes          []string
+	httpClient         *http.Client
+	checkpointInterval time.Duration
+}
+
+func newConfig(options ...Option) *config {
+	cfg := new(config)
+	WithHTTPClient(&http.Client{Timeout: defaultRequestTimeout})(cfg)
+	WithCheckpointInterval(defaultCheckpointInterval)(cfg)
+	for _, option := range options {
+		option(cfg)
+	}
+	return cfg
+}
+
+// An Option customizes the configuration used for the webhook sink.
+type Option func(*config)
+
+// WithDataBrokerClient sets the databroker client in the config.
+func WithDataBrokerClient(dataBrokerClient databroker.DataBrokerServiceClient) Option {
+	return func(cfg *config) {
+		cfg.dataBrokerClient = dataBrokerClient
+	}
+}
+
+// WithURL sets the URL events are delivered to. If empty, no events are delivered.
+func WithURL(url string) Option {
+	return func(cfg *config) {
+		cfg.url = url
+	}
+}
+
+// WithSecret sets the secret used to sign events.
+func WithSecret(secret []byte) Option {
+	return func(cfg *config) {
+		cfg.secret = secret
+	}
+}
+
+// WithTypes restricts the delivered events to records of the given types. By default events are delivered for
+// records of every type.
+func WithTypes(types []string) Option {
+	return func(cfg *config) {
+		cfg.types = types
+	}
+}
+
+// WithDataTypes sets the record types whose data is included in events. Events for other types only identify the
+// record, since records like sessions contain secrets such as OAuth tokens.
+func WithDataTypes(types []string) Option {
+	return func(cfg *config) {
+		cfg.dataTypes = types
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to deliver events.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.httpClient = client
+	}
+}
+
+// WithCheckpointInterval sets how often the version of the last delivered event is stored in the databroker.
+func WithCheckpointInterval(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.checkpointInterval = interval
+	}
+}
+
+type atomicConfig struct {
+	value atomic.Value
+}
+
+func newAtomicConfig(cfg *config) *atomicConfig {
+	ac := new(atomicConfig)
+	ac.Store(cfg)
+	return ac
+}
+
+func (ac *atomicConfig) Load() *config {
+	return ac.value.Load().(*config)
+}
+
+func (ac *atomicConfig) Store(cfg *config) {
+	ac.value.Store(cfg)
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+const (
+	// SignatureHeader is the header containing the signature of an event.
+	SignatureHeader = "X-Pomerium-Webhook-Signature"
+	// TimestampHeader is the header containing the time, in unix seconds, an event was signed.
+	TimestampHeader = "X-Pomerium-Webhook-Timestamp"
+)
+
+// ErrInvalidSignature indicates the signature of an event is invalid.
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// An EventType is the type of change made to a record.
+type EventType string
+
+// EventTypes
+const (
+	EventTypeCreate EventType = "create"
+	EventTypeUpdate EventType = "update"
+	EventTypeDelete EventType = "delete"
+)
+
+// An Event is a change to a databroker record. It's delivered as the JSON body of a webhook request.
+//
+// Events are delivered at least once, so the same event may be delivered more than once. Events for the same
+// server version can be de-duplicated and ordered by their record version.
+type Event struct {
+	Type          EventType `json:"type"`
+	ServerVersion uint64    `json:"server_version"`
+	RecordVersion uint64    `json:"record_version"`
+	RecordType    string    `json:"record_type"`
+	RecordID      string    `json:"record_id"`
+	ModifiedAt    time.Time `json:"modified_at"`
+	// Data is the protobuf JSON encoding of the record data. It's only set for the record types the data was
+	// requested for, and empty for deleted records.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+func newEvent(eventType EventType, serverVersion uint64, record *databroker.Record, includeData bool) (*Event, error) {
+	evt := &Event{
+		Type:          eventType,
+		ServerVersion: serverVersion,
+		RecordVersion: record.GetVersion(),
+		RecordType:    record.GetType(),
+		RecordID:      record.GetId(),
+		ModifiedAt:    record.GetModifiedAt().AsTime(),
+	}
+	if includeData && eventType != EventTypeDelete && record.GetData() != nil {
+		var err error
+		evt.Data, err = protojson.Marshal(record.GetData())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return evt, nil
+}
+
+// sign adds the signature headers for the body to the request. The signature is an HMAC of the timestamp and the
+// body, so old requests can't be replayed.
+func sign(req *http.Request, body, secret []byte, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, base64.StdEncoding.EncodeToString(
+		cryptutil.GenerateHMAC(getSignedData(timestamp, body), secret)))
+}
+
+// Verify verifies the signature of a webhook request with the given body.
+func Verify(req *http.Request, body, secret []byte) error {
+	timestamp := req.Header.Get(TimestampHeader)
+	if err := cryptutil.ValidTimestamp(timestamp); err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(req.Header.Get(SignatureHeader))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !cryptutil.CheckHMAC(getSignedData(timestamp, body), signature, secret) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func getSignedData(timestamp string, body []byte) []byte {
+	data := make([]byte, 0, len(timestamp)+1+len(body))
+	data = append(data, timestamp...)
+	data = append(data, '.')
+	return append(data, body...)
+}
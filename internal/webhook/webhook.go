@@ -0,0 +1,433 @@
+// Package webhook contains a sink which delivers changes to databroker records to an HTTP endpoint.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+const (
+	leaseName = "pomerium/databroker-webhook"
+	leaseTTL  = 30 * time.Second
+)
+
+// checkpointType is the type of the records used to store the versions of the last delivered events.
+var checkpointType = protoutil.GetTypeURL(new(databroker.Versions))
+
+// A Sink delivers the changes to databroker records as signed JSON events to a webhook.
+//
+// The sink syncs the records with a databroker.Syncer and delivers an event for every change, in order. Failed
+// deliveries are retried with an exponential backoff until they succeed. The version of the last delivered event is
+// periodically stored in the databroker, so after a restart delivery resumes where it left off. Events may therefore
+// be delivered more than once, but aren't lost.
+//
+// Only one sink delivers events at a time, even if multiple databrokers are running.
+type Sink struct {
+	cfg     *atomicConfig
+	updated chan struct{}
+}
+
+// New creates a new webhook sink.
+func New(options ...Option) *Sink {
+	sink := &Sink{
+		cfg:     newAtomicConfig(newConfig()),
+		updated: make(chan struct{}, 1),
+	}
+	sink.UpdateConfig(options...)
+	return sink
+}
+
+func withLog(ctx context.Context) context.Context {
+	return log.WithContext(ctx, func(c zerolog.Context) zerolog.Context {
+		return c.Str("service", "webhook")
+	})
+}
+
+// UpdateConfig updates the sink with the new options.
+func (sink *Sink) UpdateConfig(options ...Option) {
+	sink.cfg.Store(newConfig(options...))
+	select {
+	case sink.updated <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs the sink. This method blocks until the given context is canceled. Whenever the configuration is updated
+// delivery is restarted.
+func (sink *Sink) Run(ctx context.Context) error {
+	ctx = withLog(ctx)
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+		errc := make(chan error, 1)
+		go func() {
+			if sink.cfg.Load().url == "" {
+				<-runCtx.Done()
+				errc <- nil
+				return
+			}
+			errc <- databroker.NewLeaser(leaseName, leaseTTL, sink).Run(runCtx)
+		}()
+
+		select {
+		case <-ctx.Done():
+			cancel()
+			<-errc
+			return ctx.Err()
+		case <-sink.updated:
+			cancel()
+			<-errc
+		case err := <-errc:
+			cancel()
+			if err != nil && !errors.Is(err, context.Canceled) {
+				return err
+			}
+		}
+	}
+}
+
+// GetDataBrokerServiceClient gets the databroker client.
+func (sink *Sink) GetDataBrokerServiceClient() databroker.DataBrokerServiceClient {
+	return sink.cfg.Load().dataBrokerClient
+}
+
+// RunLeased delivers events while the lease is held.
+func (sink *Sink) RunLeased(ctx context.Context) error {
+	cfg := sink.cfg.Load()
+	d := &deliverer{
+		cfg:          cfg,
+		checkpointID: hex.EncodeToString(cryptutil.Hash("webhook", []byte(cfg.url))),
+		known:        map[recordKey]struct{}{},
+	}
+
+	serverVersion, recordVersion, err := d.init(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx).
+		Str("url", cfg.url).
+		Uint64("server_version", serverVersion).
+		Uint64("record_version", recordVersion).
+		Msg("webhook: delivering events")
+
+	syncer := databroker.NewSyncer("webhook", d, databroker.WithInitialVersions(serverVersion, recordVersion))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return syncer.Run(ctx)
+	})
+	eg.Go(func() error {
+		ticker := time.NewTicker(cfg.checkpointInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				// always store the last checkpoint, even though the context is canceled
+				saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				_ = d.saveCheckpoint(saveCtx)
+				cancel()
+				return ctx.Err()
+			case <-ticker.C:
+			}
+
+			err := d.saveCheckpoint(ctx)
+			if err != nil {
+				log.Warn(ctx).Err(err).Msg("webhook: error storing checkpoint")
+			}
+		}
+	})
+	return eg.Wait()
+}
+
+type recordKey struct {
+	recordType, recordID string
+}
+
+// A deliverer delivers the events for the records received from the syncer.
+type deliverer struct {
+	cfg          *config
+	checkpointID string
+
+	// known are the records which exist, so created and updated records can be told apart
+	known map[recordKey]struct{}
+	// resync is set when the syncer starts over with the latest records
+	resync bool
+
+	mu        sync.Mutex
+	delivered *databroker.Versions
+	saved     *databroker.Versions
+}
+
+// init loads the known records and returns the versions to start syncing from. If a checkpoint is stored for the
+// current server version, delivery resumes from it. Otherwise only changes made from now on are delivered.
+//
+// When resuming, the records changed since the checkpoint aren't known yet, so their first event is a create. It's
+// not known whether they existed at the checkpoint, so a record updated while no events were delivered is reported
+// as created too.
+func (d *deliverer) init(ctx context.Context) (serverVersion, recordVersion uint64, err error) {
+	checkpoint, err := d.loadCheckpoint(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	records, recordVersion, serverVersion, err := databroker.InitialSync(ctx, d.cfg.dataBrokerClient, new(databroker.SyncLatestRequest))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if checkpoint.GetServerVersion() == serverVersion && checkpoint.GetLatestRecordVersion() <= recordVersion {
+		recordVersion = checkpoint.GetLatestRecordVersion()
+	}
+
+	for _, record := range records {
+		if d.matches(record) && record.GetVersion() <= recordVersion {
+			d.known[recordKey{record.GetType(), record.GetId()}] = struct{}{}
+		}
+	}
+
+	d.delivered = &databroker.Versions{
+		ServerVersion:       serverVersion,
+		LatestRecordVersion: recordVersion,
+	}
+	return serverVersion, recordVersion, d.saveCheckpoint(ctx)
+}
+
+func (d *deliverer) GetDataBrokerServiceClient() databroker.DataBrokerServiceClient {
+	return d.cfg.dataBrokerClient
+}
+
+func (d *deliverer) ClearRecords(ctx context.Context) {
+	d.resync = true
+}
+
+func (d *deliverer) UpdateRecords(ctx context.Context, serverVersion uint64, records []*databroker.Record) {
+	if d.resync {
+		d.resync = false
+		d.reconcile(ctx, serverVersion, records)
+		return
+	}
+
+	for _, record := range records {
+		// changes to the checkpoint itself are never delivered, or stored in a checkpoint
+		if record.GetType() == checkpointType {
+			continue
+		}
+
+		if d.matches(record) {
+			key := recordKey{record.GetType(), record.GetId()}
+			eventType := EventTypeCreate
+			if record.GetDeletedAt() != nil {
+				eventType = EventTypeDelete
+				delete(d.known, key)
+			} else if _, ok := d.known[key]; ok {
+				eventType = EventTypeUpdate
+			} else {
+				d.known[key] = struct{}{}
+			}
+
+			if !d.deliver(ctx, eventType, serverVersion, record) {
+				return
+			}
+		}
+
+		d.setDelivered(serverVersion, record.GetVersion())
+	}
+}
+
+// reconcile delivers the differences between the known records and the latest records after the server version
+// changed. Records which are still known are delivered as updates, as it's unknown whether they changed.
+func (d *deliverer) reconcile(ctx context.Context, serverVersion uint64, records []*databroker.Record) {
+	latest := map[recordKey]struct{}{}
+	var recordVersion uint64
+	for _, record := range records {
+		if record.GetVersion() > recordVersion {
+			recordVersion = record.GetVersion()
+		}
+		if !d.matches(record) {
+			continue
+		}
+
+		key := recordKey{record.GetType(), record.GetId()}
+		latest[key] = struct{}{}
+
+		eventType := EventTypeCreate
+		if _, ok := d.known[key]; ok {
+			eventType = EventTypeUpdate
+		}
+		if !d.deliver(ctx, eventType, serverVersion, record) {
+			return
+		}
+	}
+
+	for key := range d.known {
+		if _, ok := latest[key]; ok {
+			continue
+		}
+		if !d.deliver(ctx, EventTypeDelete, serverVersion, &databroker.Record{
+			Type: key.recordType,
+			Id:   key.recordID,
+		}) {
+			return
+		}
+	}
+
+	d.known = latest
+	d.setDelivered(serverVersion, recordVersion)
+}
+
+func (d *deliverer) matches(record *databroker.Record) bool {
+	if record.GetType() == checkpointType {
+		return false
+	}
+	return len(d.cfg.types) == 0 || containsString(d.cfg.types, record.GetType())
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver delivers an event, retrying until it succeeds. It returns false if the context is canceled.
+func (d *deliverer) deliver(ctx context.Context, eventType EventType, serverVersion uint64, record *databroker.Record) bool {
+	ctx = log.WithContext(ctx, func(c zerolog.Context) zerolog.Context {
+		return c.Str("event_type", string(eventType)).
+			Str("record_type", record.GetType()).
+			Str("record_id", record.GetId()).
+			Uint64("record_version", record.GetVersion())
+	})
+
+	evt, err := newEvent(eventType, serverVersion, record, containsString(d.cfg.dataTypes, record.GetType()))
+	if err != nil {
+		// the record data can never be encoded, so deliver the event without it
+		log.Error(ctx).Err(err).Msg("webhook: error encoding record data")
+		evt, _ = newEvent(eventType, serverVersion, record, false)
+	}
+
+	body, err := json.Marshal(evt)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("webhook: error encoding event")
+		return true
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0
+	err = backoff.RetryNotify(func() error {
+		return d.send(ctx, body)
+	}, backoff.WithContext(bo, ctx), func(err error, next time.Duration) {
+		log.Warn(ctx).Err(err).Dur("next", next).Msg("webhook: error delivering event, retrying")
+	})
+	if ctx.Err() != nil {
+		return false
+	} else if err != nil {
+		// the event was rejected, so it won't be delivered
+		log.Error(ctx).Err(err).Msg("webhook: event rejected")
+	}
+	return true
+}
+
+func (d *deliverer) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.cfg.url, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	sign(req, body, d.cfg.secret, time.Now())
+
+	res, err := d.cfg.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+	_ = res.Body.Close()
+
+	switch {
+	case res.StatusCode/100 == 2:
+		return nil
+	case res.StatusCode == http.StatusRequestTimeout,
+		res.StatusCode == http.StatusTooManyRequests,
+		res.StatusCode/100 == 5:
+		return fmt.Errorf("webhook: unexpected status code: %d", res.StatusCode)
+	}
+	return backoff.Permanent(fmt.Errorf("webhook: unexpected status code: %d", res.StatusCode))
+}
+
+func (d *deliverer) setDelivered(serverVersion, recordVersion uint64) {
+	d.mu.Lock()
+	d.delivered = &databroker.Versions{
+		ServerVersion:       serverVersion,
+		LatestRecordVersion: recordVersion,
+	}
+	d.mu.Unlock()
+}
+
+func (d *deliverer) loadCheckpoint(ctx context.Context) (*databroker.Versions, error) {
+	res, err := d.cfg.dataBrokerClient.Get(ctx, &databroker.GetRequest{
+		Type: checkpointType,
+		Id:   d.checkpointID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var checkpoint databroker.Versions
+	err = res.GetRecord().GetData().UnmarshalTo(&checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// saveCheckpoint stores the versions of the last delivered event, if they changed.
+func (d *deliverer) saveCheckpoint(ctx context.Context) error {
+	d.mu.Lock()
+	delivered, saved := d.delivered, d.saved
+	d.mu.Unlock()
+
+	if delivered == nil || (saved != nil &&
+		saved.GetServerVersion() == delivered.GetServerVersion() &&
+		saved.GetLatestRecordVersion() == delivered.GetLatestRecordVersion()) {
+		return nil
+	}
+
+	_, err := d.cfg.dataBrokerClient.Put(ctx, &databroker.PutRequest{
+		Record: &databroker.Record{
+			Type: checkpointType,
+			Id:   d.checkpointID,
+			Data: protoutil.NewAny(delivered),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.saved = delivered
+	d.mu.Unlock()
+	return nil
+}
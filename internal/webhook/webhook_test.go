@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("SECRET")
+	body := []byte(`{"type":"create"}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	sign(req, body, secret, time.Now())
+	assert.NoError(t, Verify(req, body, secret))
+	assert.ErrorIs(t, Verify(req, []byte(`{"type":"delete"}`), secret), ErrInvalidSignature, "should reject a modified body")
+	assert.ErrorIs(t, Verify(req, body, []byte("OTHER")), ErrInvalidSignature, "should reject a different secret")
+
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	sign(req, body, secret, time.Now().Add(-time.Hour))
+	assert.Error(t, Verify(req, body, secret), "should reject an old timestamp")
+}
+
+func TestNewEvent(t *testing.T) {
+	record := &databroker.Record{
+		Version: 1,
+		Type:    "TYPE",
+		Id:      "1",
+		Data:    protoutil.NewAny(wrapperspb.String("SECRET")),
+	}
+
+	evt, err := newEvent(EventTypeCreate, 2, record, false)
+	require.NoError(t, err)
+	assert.Equal(t, &Event{
+		Type:          EventTypeCreate,
+		ServerVersion: 2,
+		RecordVersion: 1,
+		RecordType:    "TYPE",
+		RecordID:      "1",
+		ModifiedAt:    record.GetModifiedAt().AsTime(),
+	}, evt, "should only identify the record by default")
+
+	evt, err = newEvent(EventTypeUpdate, 2, record, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"@type":"type.googleapis.com/google.protobuf.StringValue","value":"SECRET"}`, string(evt.Data))
+
+	evt, err = newEvent(EventTypeDelete, 2, record, true)
+	require.NoError(t, err)
+	assert.Empty(t, evt.Data)
+}
+
+func TestSink(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := newTestDataBrokerClient(t)
+
+	secret := []byte("SECRET")
+	var mu sync.Mutex
+	var events []Event
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !assert.NoError(t, Verify(r, body, secret)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		// fail the first request, so it has to be retried
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var evt Event
+		assert.NoError(t, json.Unmarshal(body, &evt))
+		events = append(events, evt)
+	}))
+	defer srv.Close()
+
+	data := protoutil.NewAny(wrapperspb.String("HELLO"))
+	other := protoutil.NewAny(wrapperspb.Int64(1))
+	put := func(record *databroker.Record) {
+		_, err := client.Put(ctx, &databroker.PutRequest{Record: record})
+		require.NoError(t, err)
+	}
+
+	// records which exist before the sink is started aren't delivered as events
+	put(&databroker.Record{Type: data.GetTypeUrl(), Id: "1", Data: data})
+
+	sink := New(
+		WithDataBrokerClient(client),
+		WithURL(srv.URL),
+		WithSecret(secret),
+		WithTypes([]string{data.GetTypeUrl()}),
+		WithDataTypes([]string{data.GetTypeUrl()}),
+		WithCheckpointInterval(50*time.Millisecond),
+	)
+	go func() { _ = sink.Run(ctx) }()
+
+	// wait for the sink to store its initial checkpoint
+	require.Eventually(t, func() bool {
+		_, err := client.Get(ctx, &databroker.GetRequest{
+			Type: checkpointType,
+			Id:   hex.EncodeToString(cryptutil.Hash("webhook", []byte(srv.URL))),
+		})
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+
+	put(&databroker.Record{Type: data.GetTypeUrl(), Id: "2", Data: data})
+	put(&databroker.Record{Type: other.GetTypeUrl(), Id: "3", Data: other})
+	put(&databroker.Record{Type: data.GetTypeUrl(), Id: "1", Data: data})
+	put(&databroker.Record{Type: data.GetTypeUrl(), Id: "2", DeletedAt: timestamppb.Now()})
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) == 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, EventTypeCreate, events[0].Type)
+	assert.Equal(t, "2", events[0].RecordID)
+	assert.Equal(t, data.GetTypeUrl(), events[0].RecordType)
+	assert.JSONEq(t, `{"@type":"type.googleapis.com/google.protobuf.StringValue","value":"HELLO"}`, string(events[0].Data))
+
+	assert.Equal(t, EventTypeUpdate, events[1].Type)
+	assert.Equal(t, "1", events[1].RecordID)
+
+	assert.Equal(t, EventTypeDelete, events[2].Type)
+	assert.Equal(t, "2", events[2].RecordID)
+	assert.Empty(t, events[2].Data)
+
+	assert.Less(t, events[0].RecordVersion, events[1].RecordVersion)
+	assert.Less(t, events[1].RecordVersion, events[2].RecordVersion)
+}
+
+func TestDelivererResume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := newTestDataBrokerClient(t)
+	data := protoutil.NewAny(wrapperspb.String("HELLO"))
+	put := func(record *databroker.Record) *databroker.Record {
+		res, err := client.Put(ctx, &databroker.PutRequest{Record: record})
+		require.NoError(t, err)
+		return res.GetRecord()
+	}
+
+	put(&databroker.Record{Type: data.GetTypeUrl(), Id: "1", Data: data})
+	d := &deliverer{
+		cfg:          newConfig(WithDataBrokerClient(client)),
+		checkpointID: "CHECKPOINT",
+		known:        map[recordKey]struct{}{},
+	}
+	serverVersion, recordVersion, err := d.init(ctx)
+	require.NoError(t, err)
+
+	// the records created after the checkpoint aren't known when delivery resumes
+	put(&databroker.Record{Type: data.GetTypeUrl(), Id: "2", Data: data})
+	d = &deliverer{
+		cfg:          newConfig(WithDataBrokerClient(client)),
+		checkpointID: "CHECKPOINT",
+		known:        map[recordKey]struct{}{},
+	}
+	resumedServerVersion, resumedRecordVersion, err := d.init(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, serverVersion, resumedServerVersion)
+	assert.Equal(t, recordVersion, resumedRecordVersion, "should resume from the checkpoint")
+	assert.Equal(t, map[recordKey]struct{}{
+		{data.GetTypeUrl(), "1"}: {},
+	}, d.known)
+}
+
+func newTestDataBrokerClient(t *testing.T) databroker.DataBrokerServiceClient {
+	t.Helper()
+
+	li := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(gs, internal_databroker.New())
+	go func() { _ = gs.Serve(li) }()
+	t.Cleanup(gs.Stop)
+
+	cc, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return li.Dial()
+	}), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+	return databroker.NewDataBrokerServiceClient(cc)
+}
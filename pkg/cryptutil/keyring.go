@@ -0,0 +1,97 @@
+package cryptutil
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"fmt"
+)
+
+const keyringKeyIDSize = 8
+
+// keyringMagic prefixes every ciphertext encrypted by a Keyring.
+var keyringMagic = []byte("PKR1")
+
+type keyringKey struct {
+	id     []byte
+	cipher cipher.AEAD
+}
+
+// A Keyring encrypts data with an active key and decrypts data encrypted with any of its keys. Ciphertexts are
+// prefixed with the id of the key used to encrypt them, so keys can be rotated: data encrypted with an old key can
+// be read as long as the old key is in the keyring, and re-encrypted with the active key.
+//
+// Ciphertexts without a key id, as returned by Encrypt, are decrypted by trying every key.
+type Keyring struct {
+	active *keyringKey
+	keys   []*keyringKey
+}
+
+// NewKeyring creates a new Keyring. The active key is used for encryption, the other keys are only used for
+// decryption.
+func NewKeyring(activeKey []byte, otherKeys ...[]byte) (*Keyring, error) {
+	kr := new(Keyring)
+	for _, key := range append([][]byte{activeKey}, otherKeys...) {
+		c, err := NewAEADCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		kr.keys = append(kr.keys, &keyringKey{
+			id:     GetKeyID(key),
+			cipher: c,
+		})
+	}
+	kr.active = kr.keys[0]
+	return kr, nil
+}
+
+// GetKeyID returns the id of a key. The id identifies the key without revealing it.
+func GetKeyID(key []byte) []byte {
+	return Hash("keyring", key)[:keyringKeyIDSize]
+}
+
+// Len returns the number of keys in the keyring.
+func (kr *Keyring) Len() int {
+	return len(kr.keys)
+}
+
+// Encrypt encrypts data with the active key.
+func (kr *Keyring) Encrypt(data []byte) []byte {
+	out := make([]byte, 0, len(keyringMagic)+keyringKeyIDSize)
+	out = append(out, keyringMagic...)
+	out = append(out, kr.active.id...)
+	return append(out, Encrypt(kr.active.cipher, data, nil)...)
+}
+
+// Decrypt decrypts data encrypted with any of the keys in the keyring.
+func (kr *Keyring) Decrypt(data []byte) ([]byte, error) {
+	if id, ciphertext, ok := splitKeyringCiphertext(data); ok {
+		for _, key := range kr.keys {
+			if bytes.Equal(key.id, id) {
+				return Decrypt(key.cipher, ciphertext, nil)
+			}
+		}
+	}
+
+	// the data may not have a key id, in which case try every key
+	for _, key := range kr.keys {
+		plaintext, err := Decrypt(key.cipher, data, nil)
+		if err == nil {
+			return plaintext, nil
+		}
+	}
+	return nil, fmt.Errorf("cryptutil: decryption failed: no matching key found in keyring")
+}
+
+// IsActive returns true if the data was encrypted with the active key.
+func (kr *Keyring) IsActive(data []byte) bool {
+	id, _, ok := splitKeyringCiphertext(data)
+	return ok && bytes.Equal(id, kr.active.id)
+}
+
+func splitKeyringCiphertext(data []byte) (id, ciphertext []byte, ok bool) {
+	if len(data) < len(keyringMagic)+keyringKeyIDSize || !bytes.HasPrefix(data, keyringMagic) {
+		return nil, nil, false
+	}
+	data = data[len(keyringMagic):]
+	return data[:keyringKeyIDSize], data[keyringKeyIDSize:], true
+}
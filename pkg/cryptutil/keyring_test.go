@@ -0,0 +1,60 @@
+package cryptutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	oldKey, newKey := NewKey(), NewKey()
+
+	old, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+	ciphertext := old.Encrypt([]byte("HELLO"))
+	assert.True(t, old.IsActive(ciphertext))
+
+	plaintext, err := old.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("HELLO"), plaintext)
+
+	t.Run("rotated", func(t *testing.T) {
+		rotated, err := NewKeyring(newKey, oldKey)
+		require.NoError(t, err)
+		assert.Equal(t, 2, rotated.Len())
+		assert.False(t, rotated.IsActive(ciphertext))
+
+		plaintext, err := rotated.Decrypt(ciphertext)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("HELLO"), plaintext)
+
+		reencrypted := rotated.Encrypt(plaintext)
+		assert.True(t, rotated.IsActive(reencrypted))
+		_, err = old.Decrypt(reencrypted)
+		assert.Error(t, err)
+	})
+	t.Run("legacy", func(t *testing.T) {
+		c, err := NewAEADCipher(oldKey)
+		require.NoError(t, err)
+		legacy := Encrypt(c, []byte("HELLO"), nil)
+
+		rotated, err := NewKeyring(newKey, oldKey)
+		require.NoError(t, err)
+		assert.False(t, rotated.IsActive(legacy))
+
+		plaintext, err := rotated.Decrypt(legacy)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("HELLO"), plaintext)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		other, err := NewKeyring(newKey)
+		require.NoError(t, err)
+		_, err = other.Decrypt(ciphertext)
+		assert.Error(t, err)
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := NewKeyring(newKey, []byte("short"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,241 @@
+package databroker
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// ArchiveVersion is the current version of the archive format.
+const ArchiveVersion = 2
+
+const (
+	archiveFlagEncrypted = 1 << iota
+)
+
+// the kinds of archive frames
+const (
+	archiveFrameEntry byte = iota
+	archiveFrameTrailer
+)
+
+// maxArchiveEntrySize limits the memory used to read a single archive entry.
+const maxArchiveEntrySize = 64 << 20
+
+var archiveMagic = []byte("PDBARCH")
+
+// ErrArchiveEncrypted indicates that an archive is encrypted, but no key was provided.
+var ErrArchiveEncrypted = errors.New("databroker archive is encrypted")
+
+// ErrArchiveTruncated indicates that an archive ended before its trailer.
+var ErrArchiveTruncated = errors.New("databroker archive is truncated")
+
+// An ArchiveWriter writes a databroker archive.
+//
+// An archive starts with a header: the magic bytes `PDBARCH`, the archive version and a byte of flags. The header is
+// followed by frames, each stored as a uvarint length followed by a byte for the kind of frame and its data. Every
+// entry is a frame with the protobuf encoded entry, and the last frame is a trailer with the number of entries, so
+// a truncated archive is detected. When the archive is encrypted each frame is encrypted separately, authenticated
+// with its position in the archive, so frames can't be removed or reordered either.
+type ArchiveWriter struct {
+	w      *bufio.Writer
+	cipher cipher.AEAD
+
+	frames  uint64
+	entries uint64
+}
+
+// NewArchiveWriter creates a new ArchiveWriter. If the cipher is not nil the archive entries are encrypted.
+func NewArchiveWriter(w io.Writer, cipher cipher.AEAD) (*ArchiveWriter, error) {
+	aw := &ArchiveWriter{
+		w:      bufio.NewWriter(w),
+		cipher: cipher,
+	}
+
+	var flags byte
+	if cipher != nil {
+		flags |= archiveFlagEncrypted
+	}
+
+	header := append(append([]byte{}, archiveMagic...), ArchiveVersion, flags)
+	_, err := aw.w.Write(header)
+	if err != nil {
+		return nil, fmt.Errorf("error writing databroker archive header: %w", err)
+	}
+
+	return aw, nil
+}
+
+// Write writes an entry to the archive.
+func (aw *ArchiveWriter) Write(entry *ArchiveEntry) error {
+	bs, err := proto.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding databroker archive entry: %w", err)
+	}
+
+	err = aw.writeFrame(archiveFrameEntry, bs)
+	if err != nil {
+		return fmt.Errorf("error writing databroker archive entry: %w", err)
+	}
+	aw.entries++
+	return nil
+}
+
+// Close writes the archive trailer and any buffered data to the underlying writer. The archive is incomplete until
+// it's closed.
+func (aw *ArchiveWriter) Close() error {
+	var trailer [binary.MaxVarintLen64]byte
+	err := aw.writeFrame(archiveFrameTrailer, trailer[:binary.PutUvarint(trailer[:], aw.entries)])
+	if err != nil {
+		return fmt.Errorf("error writing databroker archive trailer: %w", err)
+	}
+	return aw.w.Flush()
+}
+
+func (aw *ArchiveWriter) writeFrame(kind byte, data []byte) error {
+	bs := append([]byte{kind}, data...)
+	if aw.cipher != nil {
+		bs = cryptutil.Encrypt(aw.cipher, bs, getArchiveFrameAdditionalData(aw.frames))
+	}
+	aw.frames++
+
+	var length [binary.MaxVarintLen64]byte
+	_, err := aw.w.Write(length[:binary.PutUvarint(length[:], uint64(len(bs)))])
+	if err != nil {
+		return err
+	}
+	_, err = aw.w.Write(bs)
+	return err
+}
+
+// An ArchiveReader reads a databroker archive written by an ArchiveWriter.
+type ArchiveReader struct {
+	r      *bufio.Reader
+	cipher cipher.AEAD
+
+	frames  uint64
+	entries uint64
+	done    bool
+}
+
+// NewArchiveReader creates a new ArchiveReader. If the archive is encrypted the cipher is required.
+func NewArchiveReader(r io.Reader, cipher cipher.AEAD) (*ArchiveReader, error) {
+	ar := &ArchiveReader{
+		r:      bufio.NewReader(r),
+		cipher: cipher,
+	}
+
+	header := make([]byte, len(archiveMagic)+2)
+	_, err := io.ReadFull(ar.r, header)
+	if err != nil {
+		return nil, fmt.Errorf("error reading databroker archive header: %w", err)
+	}
+
+	if !bytes.Equal(header[:len(archiveMagic)], archiveMagic) {
+		return nil, fmt.Errorf("invalid databroker archive")
+	}
+
+	if version := header[len(archiveMagic)]; version != ArchiveVersion {
+		return nil, fmt.Errorf("unsupported databroker archive version: %d", version)
+	}
+
+	flags := header[len(archiveMagic)+1]
+	if flags&archiveFlagEncrypted == 0 {
+		ar.cipher = nil
+	} else if cipher == nil {
+		return nil, ErrArchiveEncrypted
+	}
+
+	return ar, nil
+}
+
+// Read reads the next entry from the archive. io.EOF is returned once there are no more entries. If the archive ends
+// before its trailer ErrArchiveTruncated is returned.
+func (ar *ArchiveReader) Read() (*ArchiveEntry, error) {
+	for {
+		kind, data, err := ar.readFrame()
+		if errors.Is(err, io.EOF) {
+			if !ar.done {
+				return nil, ErrArchiveTruncated
+			}
+			return nil, io.EOF
+		} else if err != nil {
+			return nil, err
+		}
+
+		if ar.done {
+			return nil, fmt.Errorf("invalid databroker archive: data after the trailer")
+		}
+
+		switch kind {
+		case archiveFrameEntry:
+			var entry ArchiveEntry
+			err = proto.Unmarshal(data, &entry)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding databroker archive entry: %w", err)
+			}
+			ar.entries++
+			return &entry, nil
+		case archiveFrameTrailer:
+			entries, n := binary.Uvarint(data)
+			if n <= 0 || n != len(data) {
+				return nil, fmt.Errorf("invalid databroker archive trailer")
+			}
+			if entries != ar.entries {
+				return nil, fmt.Errorf("invalid databroker archive: expected %d entries, read %d", entries, ar.entries)
+			}
+			ar.done = true
+		default:
+			return nil, fmt.Errorf("invalid databroker archive frame: %d", kind)
+		}
+	}
+}
+
+// readFrame reads the next frame. io.EOF is returned if the archive ends at a frame boundary.
+func (ar *ArchiveReader) readFrame() (kind byte, data []byte, err error) {
+	length, err := binary.ReadUvarint(ar.r)
+	if errors.Is(err, io.EOF) {
+		return 0, nil, io.EOF
+	} else if err != nil {
+		return 0, nil, fmt.Errorf("error reading databroker archive entry: %w", err)
+	}
+
+	if length > maxArchiveEntrySize {
+		return 0, nil, fmt.Errorf("databroker archive entry too large: %d bytes", length)
+	}
+
+	bs := make([]byte, length)
+	_, err = io.ReadFull(ar.r, bs)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading databroker archive entry: %w", err)
+	}
+
+	if ar.cipher != nil {
+		bs, err = cryptutil.Decrypt(ar.cipher, bs, getArchiveFrameAdditionalData(ar.frames))
+		if err != nil {
+			return 0, nil, fmt.Errorf("error decrypting databroker archive entry: %w", err)
+		}
+	}
+	ar.frames++
+
+	if len(bs) == 0 {
+		return 0, nil, fmt.Errorf("invalid databroker archive: empty frame")
+	}
+	return bs[0], bs[1:], nil
+}
+
+// getArchiveFrameAdditionalData returns the additional data used to authenticate an encrypted frame: its position in
+// the archive.
+func getArchiveFrameAdditionalData(frame uint64) []byte {
+	var ad [8]byte
+	binary.BigEndian.PutUint64(ad[:], frame)
+	return ad[:]
+}
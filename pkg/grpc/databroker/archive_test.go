@@ -0,0 +1,137 @@
+package databroker
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func TestArchive(t *testing.T) {
+	entries := []*ArchiveEntry{
+		{Entry: &ArchiveEntry_Options{Options: &ArchiveOptions{
+			Type:    "TYPE",
+			Options: &Options{Capacity: proto.Uint64(10)},
+		}}},
+		{Entry: &ArchiveEntry_Record{Record: &Record{Type: "TYPE", Id: "1"}}},
+		{Entry: &ArchiveEntry_Record{Record: &Record{Type: "TYPE", Id: "2"}}},
+	}
+
+	write := func(t *testing.T, key []byte, entries []*ArchiveEntry) []byte {
+		var buf bytes.Buffer
+		var c cipher.AEAD
+		if key != nil {
+			var err error
+			c, err = cryptutil.NewAEADCipher(key)
+			require.NoError(t, err)
+		}
+		aw, err := NewArchiveWriter(&buf, c)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			require.NoError(t, aw.Write(entry))
+		}
+		require.NoError(t, aw.Close())
+		return buf.Bytes()
+	}
+
+	read := func(t *testing.T, bs, key []byte) ([]*ArchiveEntry, error) {
+		var c cipher.AEAD
+		if key != nil {
+			var err error
+			c, err = cryptutil.NewAEADCipher(key)
+			require.NoError(t, err)
+		}
+		ar, err := NewArchiveReader(bytes.NewReader(bs), c)
+		if err != nil {
+			return nil, err
+		}
+		var entries []*ArchiveEntry
+		for {
+			entry, err := ar.Read()
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			} else if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	t.Run("plaintext", func(t *testing.T) {
+		actual, err := read(t, write(t, nil, entries), nil)
+		require.NoError(t, err)
+		assertArchiveEntriesEqual(t, entries, actual)
+	})
+	t.Run("encrypted", func(t *testing.T) {
+		key := cryptutil.NewKey()
+		bs := write(t, key, entries)
+
+		actual, err := read(t, bs, key)
+		require.NoError(t, err)
+		assertArchiveEntriesEqual(t, entries, actual)
+
+		_, err = read(t, bs, nil)
+		assert.ErrorIs(t, err, ErrArchiveEncrypted)
+
+		_, err = read(t, bs, cryptutil.NewKey())
+		assert.Error(t, err, "should fail to decrypt with the wrong key")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := read(t, []byte("not an archive"), nil)
+		assert.Error(t, err)
+
+		bs := write(t, nil, entries)
+		_, err = read(t, bs[:len(bs)-1], nil)
+		assert.Error(t, err, "should fail on a truncated archive")
+	})
+	t.Run("truncated", func(t *testing.T) {
+		// an archive cut off at an entry boundary is missing its trailer
+		plaintext := write(t, nil, entries)
+		truncated := write(t, nil, entries[:1])
+		_, err := read(t, plaintext[:len(truncated)-2], nil)
+		assert.ErrorIs(t, err, ErrArchiveTruncated)
+
+		actual, err := read(t, write(t, nil, nil), nil)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+	t.Run("reordered", func(t *testing.T) {
+		key := cryptutil.NewKey()
+		bs := write(t, key, entries)
+
+		// split the archive into its frames and swap the two records
+		header, frames := bs[:len(archiveMagic)+2], [][]byte{}
+		for rest := bs[len(header):]; len(rest) > 0; {
+			length, n := binary.Uvarint(rest)
+			frames = append(frames, rest[:n+int(length)])
+			rest = rest[n+int(length):]
+		}
+		require.Len(t, frames, len(entries)+1)
+		frames[1], frames[2] = frames[2], frames[1]
+
+		_, err := read(t, append(header, bytes.Join(frames, nil)...), key)
+		assert.Error(t, err, "should fail to decrypt reordered entries")
+
+		// dropping an entry shifts the trailer
+		_, err = read(t, append(header, bytes.Join(append(frames[:2:2], frames[3]), nil)...), key)
+		assert.Error(t, err, "should fail to decrypt with a missing entry")
+	})
+}
+
+func assertArchiveEntriesEqual(t *testing.T, expect, actual []*ArchiveEntry) {
+	t.Helper()
+
+	if assert.Len(t, actual, len(expect)) {
+		for i := range expect {
+			assert.True(t, proto.Equal(expect[i], actual[i]), "expected %v, got %v", expect[i], actual[i])
+		}
+	}
+}
@@ -50,6 +50,41 @@ func (mr *MockisSyncLatestResponse_ResponseMockRecorder) isSyncLatestResponse_Re
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isSyncLatestResponse_Response", reflect.TypeOf((*MockisSyncLatestResponse_Response)(nil).isSyncLatestResponse_Response))
 }
 
+// MockisArchiveEntry_Entry is a mock of isArchiveEntry_Entry interface.
+type MockisArchiveEntry_Entry struct {
+	ctrl     *gomock.Controller
+	recorder *MockisArchiveEntry_EntryMockRecorder
+}
+
+// MockisArchiveEntry_EntryMockRecorder is the mock recorder for MockisArchiveEntry_Entry.
+type MockisArchiveEntry_EntryMockRecorder struct {
+	mock *MockisArchiveEntry_Entry
+}
+
+// NewMockisArchiveEntry_Entry creates a new mock instance.
+func NewMockisArchiveEntry_Entry(ctrl *gomock.Controller) *MockisArchiveEntry_Entry {
+	mock := &MockisArchiveEntry_Entry{ctrl: ctrl}
+	mock.recorder = &MockisArchiveEntry_EntryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockisArchiveEntry_Entry) EXPECT() *MockisArchiveEntry_EntryMockRecorder {
+	return m.recorder
+}
+
+// isArchiveEntry_Entry mocks base method.
+func (m *MockisArchiveEntry_Entry) isArchiveEntry_Entry() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "isArchiveEntry_Entry")
+}
+
+// isArchiveEntry_Entry indicates an expected call of isArchiveEntry_Entry.
+func (mr *MockisArchiveEntry_EntryMockRecorder) isArchiveEntry_Entry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isArchiveEntry_Entry", reflect.TypeOf((*MockisArchiveEntry_Entry)(nil).isArchiveEntry_Entry))
+}
+
 // MockDataBrokerServiceClient is a mock of DataBrokerServiceClient interface.
 type MockDataBrokerServiceClient struct {
 	ctrl     *gomock.Controller
@@ -93,6 +128,26 @@ func (mr *MockDataBrokerServiceClientMockRecorder) AcquireLease(ctx, in interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).AcquireLease), varargs...)
 }
 
+// Export mocks base method.
+func (m *MockDataBrokerServiceClient) Export(ctx context.Context, in *databroker.ExportRequest, opts ...grpc.CallOption) (databroker.DataBrokerService_ExportClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Export", varargs...)
+	ret0, _ := ret[0].(databroker.DataBrokerService_ExportClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockDataBrokerServiceClientMockRecorder) Export(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).Export), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockDataBrokerServiceClient) Get(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +168,46 @@ func (mr *MockDataBrokerServiceClientMockRecorder) Get(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).Get), varargs...)
 }
 
+// GetOptions mocks base method.
+func (m *MockDataBrokerServiceClient) GetOptions(ctx context.Context, in *databroker.GetOptionsRequest, opts ...grpc.CallOption) (*databroker.GetOptionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOptions", varargs...)
+	ret0, _ := ret[0].(*databroker.GetOptionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptions indicates an expected call of GetOptions.
+func (mr *MockDataBrokerServiceClientMockRecorder) GetOptions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptions", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).GetOptions), varargs...)
+}
+
+// Import mocks base method.
+func (m *MockDataBrokerServiceClient) Import(ctx context.Context, opts ...grpc.CallOption) (databroker.DataBrokerService_ImportClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Import", varargs...)
+	ret0, _ := ret[0].(databroker.DataBrokerService_ImportClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockDataBrokerServiceClientMockRecorder) Import(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).Import), varargs...)
+}
+
 // Put mocks base method.
 func (m *MockDataBrokerServiceClient) Put(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +228,26 @@ func (mr *MockDataBrokerServiceClientMockRecorder) Put(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).Put), varargs...)
 }
 
+// PutMany mocks base method.
+func (m *MockDataBrokerServiceClient) PutMany(ctx context.Context, in *databroker.PutManyRequest, opts ...grpc.CallOption) (*databroker.PutManyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutMany", varargs...)
+	ret0, _ := ret[0].(*databroker.PutManyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMany indicates an expected call of PutMany.
+func (mr *MockDataBrokerServiceClientMockRecorder) PutMany(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMany", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).PutMany), varargs...)
+}
+
 // Query mocks base method.
 func (m *MockDataBrokerServiceClient) Query(ctx context.Context, in *databroker.QueryRequest, opts ...grpc.CallOption) (*databroker.QueryResponse, error) {
 	m.ctrl.T.Helper()
@@ -253,6 +368,266 @@ func (mr *MockDataBrokerServiceClientMockRecorder) SyncLatest(ctx, in interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncLatest", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).SyncLatest), varargs...)
 }
 
+// MockDataBrokerService_ExportClient is a mock of DataBrokerService_ExportClient interface.
+type MockDataBrokerService_ExportClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataBrokerService_ExportClientMockRecorder
+}
+
+// MockDataBrokerService_ExportClientMockRecorder is the mock recorder for MockDataBrokerService_ExportClient.
+type MockDataBrokerService_ExportClientMockRecorder struct {
+	mock *MockDataBrokerService_ExportClient
+}
+
+// NewMockDataBrokerService_ExportClient creates a new mock instance.
+func NewMockDataBrokerService_ExportClient(ctrl *gomock.Controller) *MockDataBrokerService_ExportClient {
+	mock := &MockDataBrokerService_ExportClient{ctrl: ctrl}
+	mock.recorder = &MockDataBrokerService_ExportClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataBrokerService_ExportClient) EXPECT() *MockDataBrokerService_ExportClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockDataBrokerService_ExportClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockDataBrokerService_ExportClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockDataBrokerService_ExportClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockDataBrokerService_ExportClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataBrokerService_ExportClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataBrokerService_ExportClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockDataBrokerService_ExportClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockDataBrokerService_ExportClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockDataBrokerService_ExportClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockDataBrokerService_ExportClient) Recv() (*databroker.ArchiveEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*databroker.ArchiveEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockDataBrokerService_ExportClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockDataBrokerService_ExportClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockDataBrokerService_ExportClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataBrokerService_ExportClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataBrokerService_ExportClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockDataBrokerService_ExportClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataBrokerService_ExportClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataBrokerService_ExportClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockDataBrokerService_ExportClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockDataBrokerService_ExportClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockDataBrokerService_ExportClient)(nil).Trailer))
+}
+
+// MockDataBrokerService_ImportClient is a mock of DataBrokerService_ImportClient interface.
+type MockDataBrokerService_ImportClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataBrokerService_ImportClientMockRecorder
+}
+
+// MockDataBrokerService_ImportClientMockRecorder is the mock recorder for MockDataBrokerService_ImportClient.
+type MockDataBrokerService_ImportClientMockRecorder struct {
+	mock *MockDataBrokerService_ImportClient
+}
+
+// NewMockDataBrokerService_ImportClient creates a new mock instance.
+func NewMockDataBrokerService_ImportClient(ctrl *gomock.Controller) *MockDataBrokerService_ImportClient {
+	mock := &MockDataBrokerService_ImportClient{ctrl: ctrl}
+	mock.recorder = &MockDataBrokerService_ImportClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataBrokerService_ImportClient) EXPECT() *MockDataBrokerService_ImportClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockDataBrokerService_ImportClient) CloseAndRecv() (*databroker.ImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*databroker.ImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockDataBrokerService_ImportClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockDataBrokerService_ImportClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockDataBrokerService_ImportClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockDataBrokerService_ImportClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockDataBrokerService_ImportClient) Send(arg0 *databroker.ArchiveEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockDataBrokerService_ImportClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockDataBrokerService_ImportClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockDataBrokerService_ImportClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockDataBrokerService_ImportClient)(nil).Trailer))
+}
+
 // MockDataBrokerService_SyncClient is a mock of DataBrokerService_SyncClient interface.
 type MockDataBrokerService_SyncClient struct {
 	ctrl     *gomock.Controller
@@ -537,6 +912,20 @@ func (mr *MockDataBrokerServiceServerMockRecorder) AcquireLease(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).AcquireLease), arg0, arg1)
 }
 
+// Export mocks base method.
+func (m *MockDataBrokerServiceServer) Export(arg0 *databroker.ExportRequest, arg1 databroker.DataBrokerService_ExportServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockDataBrokerServiceServerMockRecorder) Export(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).Export), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockDataBrokerServiceServer) Get(arg0 context.Context, arg1 *databroker.GetRequest) (*databroker.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -552,6 +941,35 @@ func (mr *MockDataBrokerServiceServerMockRecorder) Get(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).Get), arg0, arg1)
 }
 
+// GetOptions mocks base method.
+func (m *MockDataBrokerServiceServer) GetOptions(arg0 context.Context, arg1 *databroker.GetOptionsRequest) (*databroker.GetOptionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptions", arg0, arg1)
+	ret0, _ := ret[0].(*databroker.GetOptionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptions indicates an expected call of GetOptions.
+func (mr *MockDataBrokerServiceServerMockRecorder) GetOptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptions", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).GetOptions), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockDataBrokerServiceServer) Import(arg0 databroker.DataBrokerService_ImportServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockDataBrokerServiceServerMockRecorder) Import(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).Import), arg0)
+}
+
 // Put mocks base method.
 func (m *MockDataBrokerServiceServer) Put(arg0 context.Context, arg1 *databroker.PutRequest) (*databroker.PutResponse, error) {
 	m.ctrl.T.Helper()
@@ -567,6 +985,21 @@ func (mr *MockDataBrokerServiceServerMockRecorder) Put(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).Put), arg0, arg1)
 }
 
+// PutMany mocks base method.
+func (m *MockDataBrokerServiceServer) PutMany(arg0 context.Context, arg1 *databroker.PutManyRequest) (*databroker.PutManyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMany", arg0, arg1)
+	ret0, _ := ret[0].(*databroker.PutManyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMany indicates an expected call of PutMany.
+func (mr *MockDataBrokerServiceServerMockRecorder) PutMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMany", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).PutMany), arg0, arg1)
+}
+
 // Query mocks base method.
 func (m *MockDataBrokerServiceServer) Query(arg0 context.Context, arg1 *databroker.QueryRequest) (*databroker.QueryResponse, error) {
 	m.ctrl.T.Helper()
@@ -655,6 +1088,259 @@ func (mr *MockDataBrokerServiceServerMockRecorder) SyncLatest(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncLatest", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).SyncLatest), arg0, arg1)
 }
 
+// MockDataBrokerService_ExportServer is a mock of DataBrokerService_ExportServer interface.
+type MockDataBrokerService_ExportServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataBrokerService_ExportServerMockRecorder
+}
+
+// MockDataBrokerService_ExportServerMockRecorder is the mock recorder for MockDataBrokerService_ExportServer.
+type MockDataBrokerService_ExportServerMockRecorder struct {
+	mock *MockDataBrokerService_ExportServer
+}
+
+// NewMockDataBrokerService_ExportServer creates a new mock instance.
+func NewMockDataBrokerService_ExportServer(ctrl *gomock.Controller) *MockDataBrokerService_ExportServer {
+	mock := &MockDataBrokerService_ExportServer{ctrl: ctrl}
+	mock.recorder = &MockDataBrokerService_ExportServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataBrokerService_ExportServer) EXPECT() *MockDataBrokerService_ExportServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockDataBrokerService_ExportServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataBrokerService_ExportServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataBrokerService_ExportServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockDataBrokerService_ExportServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataBrokerService_ExportServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataBrokerService_ExportServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockDataBrokerService_ExportServer) Send(arg0 *databroker.ArchiveEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockDataBrokerService_ExportServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockDataBrokerService_ExportServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockDataBrokerService_ExportServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockDataBrokerService_ExportServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockDataBrokerService_ExportServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockDataBrokerService_ExportServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataBrokerService_ExportServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataBrokerService_ExportServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockDataBrokerService_ExportServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockDataBrokerService_ExportServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockDataBrokerService_ExportServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockDataBrokerService_ExportServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockDataBrokerService_ExportServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockDataBrokerService_ExportServer)(nil).SetTrailer), arg0)
+}
+
+// MockDataBrokerService_ImportServer is a mock of DataBrokerService_ImportServer interface.
+type MockDataBrokerService_ImportServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataBrokerService_ImportServerMockRecorder
+}
+
+// MockDataBrokerService_ImportServerMockRecorder is the mock recorder for MockDataBrokerService_ImportServer.
+type MockDataBrokerService_ImportServerMockRecorder struct {
+	mock *MockDataBrokerService_ImportServer
+}
+
+// NewMockDataBrokerService_ImportServer creates a new mock instance.
+func NewMockDataBrokerService_ImportServer(ctrl *gomock.Controller) *MockDataBrokerService_ImportServer {
+	mock := &MockDataBrokerService_ImportServer{ctrl: ctrl}
+	mock.recorder = &MockDataBrokerService_ImportServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataBrokerService_ImportServer) EXPECT() *MockDataBrokerService_ImportServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockDataBrokerService_ImportServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockDataBrokerService_ImportServer) Recv() (*databroker.ArchiveEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*databroker.ArchiveEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockDataBrokerService_ImportServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockDataBrokerService_ImportServer) SendAndClose(arg0 *databroker.ImportResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockDataBrokerService_ImportServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockDataBrokerService_ImportServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockDataBrokerService_ImportServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockDataBrokerService_ImportServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockDataBrokerService_ImportServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockDataBrokerService_ImportServer)(nil).SetTrailer), arg0)
+}
+
 // MockDataBrokerService_SyncServer is a mock of DataBrokerService_SyncServer interface.
 type MockDataBrokerService_SyncServer struct {
 	ctrl     *gomock.Controller
@@ -15,8 +15,10 @@ import (
 )
 
 type syncerConfig struct {
-	typeURL         string
+	typeURLs        []string
 	withFastForward bool
+	serverVersion   uint64
+	recordVersion   uint64
 }
 
 // A SyncerOption customizes the syncer configuration.
@@ -32,11 +34,29 @@ func getSyncerConfig(options ...SyncerOption) *syncerConfig {
 
 // WithTypeURL restricts the sync'd results to the given type.
 func WithTypeURL(typeURL string) SyncerOption {
+	return WithTypeURLs(typeURL)
+}
+
+// WithTypeURLs restricts the sync'd results to the given types.
+func WithTypeURLs(typeURLs ...string) SyncerOption {
 	return func(cfg *syncerConfig) {
-		cfg.typeURL = typeURL
+		cfg.typeURLs = typeURLs
 	}
 }
 
+// matchesType returns true if records of the type are sync'd.
+func (cfg *syncerConfig) matchesType(typeURL string) bool {
+	if len(cfg.typeURLs) == 0 {
+		return true
+	}
+	for _, t := range cfg.typeURLs {
+		if t == typeURL {
+			return true
+		}
+	}
+	return false
+}
+
 // WithFastForward in case updates are coming faster then Update can process them,
 // will skip older records to maintain an update rate.
 // Use for entries that represent a full state snapshot i.e. Config
@@ -46,6 +66,15 @@ func WithFastForward() SyncerOption {
 	}
 }
 
+// WithInitialVersions starts syncing the changes after the given versions instead of with the latest records. If
+// the server version no longer matches, the syncer starts over with the latest records.
+func WithInitialVersions(serverVersion, recordVersion uint64) SyncerOption {
+	return func(cfg *syncerConfig) {
+		cfg.serverVersion = serverVersion
+		cfg.recordVersion = recordVersion
+	}
+}
+
 // A SyncerHandler receives sync events from the Syncer.
 type SyncerHandler interface {
 	GetDataBrokerServiceClient() DataBrokerServiceClient
@@ -77,11 +106,15 @@ func NewSyncer(id string, handler SyncerHandler, options ...SyncerOption) *Synce
 
 	bo := backoff.NewExponentialBackOff()
 	bo.MaxElapsedTime = 0
+	cfg := getSyncerConfig(options...)
 	s := &Syncer{
-		cfg:     getSyncerConfig(options...),
+		cfg:     cfg,
 		handler: handler,
 		backoff: bo,
 
+		recordVersion: cfg.recordVersion,
+		serverVersion: cfg.serverVersion,
+
 		closeCtx:       closeCtx,
 		closeCtxCancel: closeCtxCancel,
 
@@ -129,13 +162,23 @@ func (syncer *Syncer) Run(ctx context.Context) error {
 
 func (syncer *Syncer) init(ctx context.Context) error {
 	log.Info(ctx).Msg("initial sync")
-	records, recordVersion, serverVersion, err := InitialSync(ctx, syncer.handler.GetDataBrokerServiceClient(), &SyncLatestRequest{
-		Type: syncer.cfg.typeURL,
-	})
+	// SyncLatest only filters by a single type, so records of other types are skipped here
+	req := new(SyncLatestRequest)
+	if len(syncer.cfg.typeURLs) == 1 {
+		req.Type = syncer.cfg.typeURLs[0]
+	}
+	records, recordVersion, serverVersion, err := InitialSync(ctx, syncer.handler.GetDataBrokerServiceClient(), req)
 	if err != nil {
 		log.Error(ctx).Err(err).Msg("error during initial sync")
 		return err
 	}
+	filtered := records[:0]
+	for _, record := range records {
+		if syncer.cfg.matchesType(record.GetType()) {
+			filtered = append(filtered, record)
+		}
+	}
+	records = filtered
 	syncer.backoff.Reset()
 
 	// reset the records as we have to sync latest
@@ -149,10 +192,12 @@ func (syncer *Syncer) init(ctx context.Context) error {
 }
 
 func (syncer *Syncer) sync(ctx context.Context) error {
-	stream, err := syncer.handler.GetDataBrokerServiceClient().Sync(ctx, &SyncRequest{
+	req := &SyncRequest{
 		ServerVersion: syncer.serverVersion,
 		RecordVersion: syncer.recordVersion,
-	})
+		Types:         syncer.cfg.typeURLs,
+	}
+	stream, err := syncer.handler.GetDataBrokerServiceClient().Sync(ctx, req)
 	if err != nil {
 		log.Error(ctx).Err(err).Msg("error during sync")
 		return err
@@ -174,14 +219,17 @@ func (syncer *Syncer) sync(ctx context.Context) error {
 		rec := res.GetRecord()
 		log.Debug(logCtxRec(ctx, rec)).Msg("syncer got record")
 
-		if syncer.recordVersion != res.GetRecord().GetVersion()-1 {
+		// when filtering by type the server skips the versions of other types,
+		// so only the order of the versions can be checked
+		if (len(syncer.cfg.typeURLs) == 0 && syncer.recordVersion != res.GetRecord().GetVersion()-1) ||
+			(len(syncer.cfg.typeURLs) != 0 && syncer.recordVersion >= res.GetRecord().GetVersion()) {
 			log.Error(logCtxRec(ctx, rec)).Err(err).
 				Msg("aborted sync due to missing record")
 			syncer.serverVersion = 0
 			return fmt.Errorf("missing record version")
 		}
 		syncer.recordVersion = res.GetRecord().GetVersion()
-		if syncer.cfg.typeURL == "" || syncer.cfg.typeURL == res.GetRecord().GetType() {
+		if syncer.cfg.matchesType(res.GetRecord().GetType()) {
 			ctx := logCtxRec(ctx, rec)
 			syncer.handler.UpdateRecords(
 				context.WithValue(ctx, contextkeys.UpdateRecordsVersion, rec.GetVersion()),
@@ -202,6 +250,6 @@ func logCtxRec(ctx context.Context, rec *Record) context.Context {
 func (syncer *Syncer) logCtx(ctx context.Context) context.Context {
 	return log.WithContext(ctx, func(c zerolog.Context) zerolog.Context {
 		return c.Str("syncer_id", syncer.id).
-			Str("syncer_type", syncer.cfg.typeURL)
+			Strs("syncer_types", syncer.cfg.typeURLs)
 	})
 }
@@ -218,3 +218,215 @@ func TestSyncer(t *testing.T) {
 
 	assert.NoError(t, syncer.Close())
 }
+
+func TestSyncerTypeURL(t *testing.T) {
+	ctx := context.Background()
+	ctx, clearTimeout := context.WithTimeout(ctx, time.Second*10)
+	defer clearTimeout()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lis := bufconn.Listen(1)
+	r1 := &Record{Version: 1000, Type: "TYPE", Id: "r1"}
+	r2 := &Record{Version: 1003, Type: "TYPE", Id: "r2"}
+	r3 := &Record{Version: 1007, Type: "TYPE", Id: "r3"}
+
+	gs := grpc.NewServer()
+	RegisterDataBrokerServiceServer(gs, testServer{
+		sync: func(request *SyncRequest, server DataBrokerService_SyncServer) error {
+			assert.Equal(t, []string{"TYPE"}, request.GetTypes())
+			// other types are filtered by the server, so the versions have gaps
+			_ = server.Send(&SyncResponse{Record: r2})
+			_ = server.Send(&SyncResponse{Record: r3})
+			select {} // block forever
+		},
+		syncLatest: func(req *SyncLatestRequest, server DataBrokerService_SyncLatestServer) error {
+			assert.Equal(t, "TYPE", req.GetType())
+			_ = server.Send(&SyncLatestResponse{
+				Response: &SyncLatestResponse_Record{
+					Record: r1,
+				},
+			})
+			_ = server.Send(&SyncLatestResponse{
+				Response: &SyncLatestResponse_Versions{
+					Versions: &Versions{
+						LatestRecordVersion: r1.Version,
+						ServerVersion:       2000,
+					},
+				},
+			})
+			return nil
+		},
+	})
+	go func() { _ = gs.Serve(lis) }()
+
+	gc, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() { _ = gc.Close() }()
+
+	updateCh := make(chan []*Record)
+	syncer := NewSyncer("test", testSyncerHandler{
+		getDataBrokerServiceClient: func() DataBrokerServiceClient {
+			return NewDataBrokerServiceClient(gc)
+		},
+		clearRecords: func(ctx context.Context) {},
+		updateRecords: func(ctx context.Context, serverVersion uint64, records []*Record) {
+			updateCh <- records
+		},
+	}, WithTypeURL("TYPE"))
+	go func() { _ = syncer.Run(ctx) }()
+
+	for _, expect := range []string{
+		`[{"type": "TYPE", "id": "r1", "version": "1000"}]`,
+		`[{"type": "TYPE", "id": "r2", "version": "1003"}]`,
+		`[{"type": "TYPE", "id": "r3", "version": "1007"}]`,
+	} {
+		select {
+		case <-ctx.Done():
+			t.Fatal("expected call to update records")
+		case records := <-updateCh:
+			testutil.AssertProtoJSONEqual(t, expect, records)
+		}
+	}
+
+	_ = syncer.Close()
+}
+
+func TestSyncerTypeURLs(t *testing.T) {
+	ctx := context.Background()
+	ctx, clearTimeout := context.WithTimeout(ctx, time.Second*10)
+	defer clearTimeout()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lis := bufconn.Listen(1)
+	r1 := &Record{Version: 1000, Type: "TYPE1", Id: "r1"}
+	r2 := &Record{Version: 1001, Type: "OTHER", Id: "r2"}
+	r3 := &Record{Version: 1002, Type: "TYPE2", Id: "r3"}
+	r4 := &Record{Version: 1005, Type: "TYPE1", Id: "r4"}
+
+	gs := grpc.NewServer()
+	RegisterDataBrokerServiceServer(gs, testServer{
+		sync: func(request *SyncRequest, server DataBrokerService_SyncServer) error {
+			assert.Equal(t, []string{"TYPE1", "TYPE2"}, request.GetTypes())
+			_ = server.Send(&SyncResponse{Record: r4})
+			select {} // block forever
+		},
+		syncLatest: func(req *SyncLatestRequest, server DataBrokerService_SyncLatestServer) error {
+			// SyncLatest only supports a single type, so all the types are requested
+			assert.Equal(t, "", req.GetType())
+			for _, r := range []*Record{r1, r2, r3} {
+				_ = server.Send(&SyncLatestResponse{
+					Response: &SyncLatestResponse_Record{
+						Record: r,
+					},
+				})
+			}
+			_ = server.Send(&SyncLatestResponse{
+				Response: &SyncLatestResponse_Versions{
+					Versions: &Versions{
+						LatestRecordVersion: r3.Version,
+						ServerVersion:       2000,
+					},
+				},
+			})
+			return nil
+		},
+	})
+	go func() { _ = gs.Serve(lis) }()
+
+	gc, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() { _ = gc.Close() }()
+
+	updateCh := make(chan []*Record)
+	syncer := NewSyncer("test", testSyncerHandler{
+		getDataBrokerServiceClient: func() DataBrokerServiceClient {
+			return NewDataBrokerServiceClient(gc)
+		},
+		clearRecords: func(ctx context.Context) {},
+		updateRecords: func(ctx context.Context, serverVersion uint64, records []*Record) {
+			updateCh <- records
+		},
+	}, WithTypeURLs("TYPE1", "TYPE2"))
+	go func() { _ = syncer.Run(ctx) }()
+
+	for _, expect := range []string{
+		`[{"type": "TYPE1", "id": "r1", "version": "1000"}, {"type": "TYPE2", "id": "r3", "version": "1002"}]`,
+		`[{"type": "TYPE1", "id": "r4", "version": "1005"}]`,
+	} {
+		select {
+		case <-ctx.Done():
+			t.Fatal("expected call to update records")
+		case records := <-updateCh:
+			testutil.AssertProtoJSONEqual(t, expect, records)
+		}
+	}
+
+	_ = syncer.Close()
+}
+
+func TestSyncerInitialVersions(t *testing.T) {
+	ctx := context.Background()
+	ctx, clearTimeout := context.WithTimeout(ctx, time.Second*10)
+	defer clearTimeout()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lis := bufconn.Listen(1)
+	r2 := &Record{Version: 1001, Id: "r2"}
+
+	gs := grpc.NewServer()
+	RegisterDataBrokerServiceServer(gs, testServer{
+		sync: func(request *SyncRequest, server DataBrokerService_SyncServer) error {
+			assert.Equal(t, uint64(2000), request.GetServerVersion())
+			assert.Equal(t, uint64(1000), request.GetRecordVersion())
+			_ = server.Send(&SyncResponse{Record: r2})
+			select {} // block forever
+		},
+		syncLatest: func(req *SyncLatestRequest, server DataBrokerService_SyncLatestServer) error {
+			t.Error("unexpected call to sync latest")
+			return nil
+		},
+	})
+	go func() { _ = gs.Serve(lis) }()
+
+	gc, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() { _ = gc.Close() }()
+
+	updateCh := make(chan []*Record)
+	syncer := NewSyncer("test", testSyncerHandler{
+		getDataBrokerServiceClient: func() DataBrokerServiceClient {
+			return NewDataBrokerServiceClient(gc)
+		},
+		clearRecords: func(ctx context.Context) {
+			t.Error("unexpected call to clear records")
+		},
+		updateRecords: func(ctx context.Context, serverVersion uint64, records []*Record) {
+			updateCh <- records
+		},
+	}, WithInitialVersions(2000, 1000))
+	go func() { _ = syncer.Run(ctx) }()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("expected call to update records")
+	case records := <-updateCh:
+		testutil.AssertProtoJSONEqual(t, `[{"id": "r2", "version": "1001"}]`, records)
+	}
+
+	_ = syncer.Close()
+}
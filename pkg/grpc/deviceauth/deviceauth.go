@@ -0,0 +1,79 @@
+// Package deviceauth contains protobuf types for OAuth 2.0 device authorization requests.
+package deviceauth
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// Count returns the number of device authorizations in the databroker. Expired device authorizations are removed
+// by the databroker, so they aren't counted.
+func Count(ctx context.Context, client databroker.DataBrokerServiceClient) (int64, error) {
+	any := protoutil.NewAny(new(DeviceAuthorization))
+	res, err := client.Query(ctx, &databroker.QueryRequest{
+		Type:  any.GetTypeUrl(),
+		Limit: 1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.GetTotalCount(), nil
+}
+
+// Delete deletes a device authorization from the databroker. The delete only succeeds if version matches the
+// version of the stored record.
+func Delete(ctx context.Context, client databroker.DataBrokerServiceClient, id string, version uint64) error {
+	any := protoutil.NewAny(new(DeviceAuthorization))
+	_, err := client.Put(ctx, &databroker.PutRequest{
+		Record: &databroker.Record{
+			Version:   version,
+			Type:      any.GetTypeUrl(),
+			Id:        id,
+			Data:      any,
+			DeletedAt: timestamppb.Now(),
+		},
+		CheckVersion: true,
+	})
+	return err
+}
+
+// Get gets a device authorization from the databroker, along with the version of its record.
+func Get(ctx context.Context, client databroker.DataBrokerServiceClient, id string) (*DeviceAuthorization, uint64, error) {
+	any := protoutil.NewAny(new(DeviceAuthorization))
+	res, err := client.Get(ctx, &databroker.GetRequest{
+		Type: any.GetTypeUrl(),
+		Id:   id,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var da DeviceAuthorization
+	err = res.GetRecord().GetData().UnmarshalTo(&da)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error unmarshaling device authorization from databroker: %w", err)
+	}
+	return &da, res.GetRecord().GetVersion(), nil
+}
+
+// Put saves a device authorization in the databroker. The write only succeeds if version matches the version of
+// the stored record, or is 0 and the record doesn't exist. The record expires with the device authorization.
+func Put(ctx context.Context, client databroker.DataBrokerServiceClient, da *DeviceAuthorization, version uint64) error {
+	any := protoutil.NewAny(da)
+	_, err := client.Put(ctx, &databroker.PutRequest{
+		Record: &databroker.Record{
+			Version:   version,
+			Type:      any.GetTypeUrl(),
+			Id:        da.GetId(),
+			Data:      any,
+			ExpiresAt: da.GetExpiresAt(),
+		},
+		CheckVersion: true,
+	})
+	return err
+}
@@ -0,0 +1,206 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: deviceauth.proto
+
+package deviceauth
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A DeviceAuthorization is a pending OAuth 2.0 device authorization request
+// (RFC 8628). Its id is the normalized user code.
+type DeviceAuthorization struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// device_code_hash is the hash of the device code the client polls with.
+	DeviceCodeHash []byte                 `protobuf:"bytes,2,opt,name=device_code_hash,json=deviceCodeHash,proto3" json:"device_code_hash,omitempty"`
+	ClientId       string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// session_jwt is the signed session JWT issued once the user approves the
+	// request.
+	SessionJwt string `protobuf:"bytes,5,opt,name=session_jwt,json=sessionJwt,proto3" json:"session_jwt,omitempty"`
+	Denied     bool   `protobuf:"varint,6,opt,name=denied,proto3" json:"denied,omitempty"`
+}
+
+func (x *DeviceAuthorization) Reset() {
+	*x = DeviceAuthorization{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deviceauth_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceAuthorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceAuthorization) ProtoMessage() {}
+
+func (x *DeviceAuthorization) ProtoReflect() protoreflect.Message {
+	mi := &file_deviceauth_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceAuthorization.ProtoReflect.Descriptor instead.
+func (*DeviceAuthorization) Descriptor() ([]byte, []int) {
+	return file_deviceauth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeviceAuthorization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetDeviceCodeHash() []byte {
+	if x != nil {
+		return x.DeviceCodeHash
+	}
+	return nil
+}
+
+func (x *DeviceAuthorization) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *DeviceAuthorization) GetSessionJwt() string {
+	if x != nil {
+		return x.SessionJwt
+	}
+	return ""
+}
+
+func (x *DeviceAuthorization) GetDenied() bool {
+	if x != nil {
+		return x.Denied
+	}
+	return false
+}
+
+var File_deviceauth_proto protoreflect.FileDescriptor
+
+var file_deviceauth_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x61, 0x75, 0x74, 0x68, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xe0, 0x01, 0x0a, 0x13, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x77, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4a, 0x77, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65,
+	0x6e, 0x69, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x6e, 0x69,
+	0x65, 0x64, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x70, 0x6f, 0x6d, 0x65, 0x72, 0x69, 0x75, 0x6d, 0x2f, 0x70, 0x6f, 0x6d, 0x65, 0x72, 0x69,
+	0x75, 0x6d, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x61, 0x75, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_deviceauth_proto_rawDescOnce sync.Once
+	file_deviceauth_proto_rawDescData = file_deviceauth_proto_rawDesc
+)
+
+func file_deviceauth_proto_rawDescGZIP() []byte {
+	file_deviceauth_proto_rawDescOnce.Do(func() {
+		file_deviceauth_proto_rawDescData = protoimpl.X.CompressGZIP(file_deviceauth_proto_rawDescData)
+	})
+	return file_deviceauth_proto_rawDescData
+}
+
+var file_deviceauth_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_deviceauth_proto_goTypes = []interface{}{
+	(*DeviceAuthorization)(nil),   // 0: deviceauth.DeviceAuthorization
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_deviceauth_proto_depIdxs = []int32{
+	1, // 0: deviceauth.DeviceAuthorization.expires_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_deviceauth_proto_init() }
+func file_deviceauth_proto_init() {
+	if File_deviceauth_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_deviceauth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceAuthorization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_deviceauth_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_deviceauth_proto_goTypes,
+		DependencyIndexes: file_deviceauth_proto_depIdxs,
+		MessageInfos:      file_deviceauth_proto_msgTypes,
+	}.Build()
+	File_deviceauth_proto = out.File
+	file_deviceauth_proto_rawDesc = nil
+	file_deviceauth_proto_goTypes = nil
+	file_deviceauth_proto_depIdxs = nil
+}
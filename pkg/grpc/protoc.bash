@@ -92,6 +92,10 @@ _import_paths=$(join_by , "${_imports[@]}")
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./device/." \
   ./device/device.proto
 
+../../scripts/protoc -I ./deviceauth/ \
+  --go_out="$_import_paths,plugins=grpc,paths=source_relative:./deviceauth/." \
+  ./deviceauth/deviceauth.proto
+
 ../../scripts/protoc -I ./directory/ \
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./directory/." \
   ./directory/directory.proto
@@ -13,6 +13,9 @@ import (
 	"github.com/pomerium/pomerium/pkg/protoutil"
 )
 
+// queryPageSize is the number of sessions requested per page by Query.
+const queryPageSize = 100
+
 // Delete deletes a session from the databroker.
 func Delete(ctx context.Context, client databroker.DataBrokerServiceClient, sessionID string) error {
 	any := protoutil.NewAny(new(Session))
@@ -46,14 +49,49 @@ func Get(ctx context.Context, client databroker.DataBrokerServiceClient, session
 	return &s, nil
 }
 
-// Put sets a session in the databroker.
+// Query gets all the sessions matching a structured databroker query filter,
+// e.g. `id_token.subject = "USER_ID"`.
+func Query(ctx context.Context, client databroker.DataBrokerServiceClient, filter string) ([]*Session, error) {
+	any := protoutil.NewAny(new(Session))
+
+	var ss []*Session
+	var cursor string
+	for {
+		res, err := client.Query(ctx, &databroker.QueryRequest{
+			Type:   any.GetTypeUrl(),
+			Filter: filter,
+			Limit:  queryPageSize,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range res.GetRecords() {
+			var s Session
+			err = record.GetData().UnmarshalTo(&s)
+			if err != nil {
+				return nil, fmt.Errorf("error unmarshaling session from databroker: %w", err)
+			}
+			ss = append(ss, &s)
+		}
+
+		cursor = res.GetNextCursor()
+		if cursor == "" {
+			return ss, nil
+		}
+	}
+}
+
+// Put sets a session in the databroker. The record expires with the session.
 func Put(ctx context.Context, client databroker.DataBrokerServiceClient, s *Session) (*databroker.PutResponse, error) {
 	any := protoutil.NewAny(s)
 	res, err := client.Put(ctx, &databroker.PutRequest{
 		Record: &databroker.Record{
-			Type: any.GetTypeUrl(),
-			Id:   s.Id,
-			Data: any,
+			Type:      any.GetTypeUrl(),
+			Id:        s.Id,
+			Data:      any,
+			ExpiresAt: s.GetExpiresAt(),
 		},
 	})
 	return res, err
@@ -49,14 +49,15 @@ func Put(ctx context.Context, client databroker.DataBrokerServiceClient, u *User
 	return res.GetRecord(), nil
 }
 
-// PutServiceAccount sets a service account in the databroker.
+// PutServiceAccount sets a service account in the databroker. The record expires with the service account.
 func PutServiceAccount(ctx context.Context, client databroker.DataBrokerServiceClient, sa *ServiceAccount) (*databroker.Record, error) {
 	any := protoutil.NewAny(sa)
 	res, err := client.Put(ctx, &databroker.PutRequest{
 		Record: &databroker.Record{
-			Type: any.GetTypeUrl(),
-			Id:   sa.GetId(),
-			Data: any,
+			Type:      any.GetTypeUrl(),
+			Id:        sa.GetId(),
+			Data:      any,
+			ExpiresAt: sa.GetExpiresAt(),
 		},
 	})
 	if err != nil {
@@ -0,0 +1,79 @@
+package criteria
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+var clientCertificateBody = ast.Body{
+	ast.MustParseExpr(`input.is_valid_client_certificate == true`),
+	ast.MustParseExpr(`input.client_certificate.presented == true`),
+}
+
+// clientCertificateOperators maps the operators of the client certificate criterion to the matcher and field of the
+// client certificate in the input.
+var clientCertificateOperators = map[string]struct {
+	match matcher
+	field string
+}{
+	"common_name":        {matchString, "common_name"},
+	"fingerprint_sha256": {matchString, "fingerprint_sha256"},
+	"issuer":             {matchString, "issuer"},
+	"san_dns":            {matchStringList, "dns_names"},
+	"san_email":          {matchStringList, "email_addresses"},
+	"san_uri":            {matchStringList, "uris"},
+	"serial_number":      {matchString, "serial_number"},
+}
+
+type clientCertificateCriterion struct {
+	g *Generator
+}
+
+func (clientCertificateCriterion) DataType() CriterionDataType {
+	return generator.CriterionDataTypeUnknown
+}
+
+func (clientCertificateCriterion) Name() string {
+	return "client_certificate"
+}
+
+func (c clientCertificateCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	obj, ok := data.(parser.Object)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected object for client_certificate criterion, got: %T", data)
+	}
+
+	var body ast.Body
+	body = append(body, clientCertificateBody...)
+	for k, v := range obj {
+		op, ok := clientCertificateOperators[k]
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected field in client_certificate criterion: %s", k)
+		}
+
+		ref := ast.RefTerm(ast.VarTerm("input"), ast.StringTerm("client_certificate"), ast.StringTerm(op.field))
+		err := op.match(&body, ref, v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s for client_certificate criterion: %w", k, err)
+		}
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonClientCertificateOK, ReasonClientCertificateUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// ClientCertificate returns a Criterion which matches the attributes of a valid client certificate.
+func ClientCertificate(generator *Generator) Criterion {
+	return clientCertificateCriterion{g: generator}
+}
+
+func init() {
+	Register(ClientCertificate)
+}
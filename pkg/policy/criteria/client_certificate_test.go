@@ -0,0 +1,96 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCertificate(t *testing.T) {
+	cert := InputClientCertificate{
+		Presented:         true,
+		CommonName:        "service-a",
+		Issuer:            "CN=Example CA,O=Example",
+		SerialNumber:      "1a2b",
+		FingerprintSHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		DNSNames:          []string{"service-a.example.com"},
+		URIs:              []string{"spiffe://example.com/service-a"},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - client_certificate:
+        common_name:
+          is: service-a
+        issuer:
+          ends_with: O=Example
+        san_uri:
+          has: spiffe://example.com/service-a
+`, []dataBrokerRecord{}, Input{IsValidClientCertificate: true, ClientCertificate: cert})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonClientCertificateOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("fingerprint", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - client_certificate:
+        fingerprint_sha256:
+          in:
+            - e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+        serial_number:
+          is: 1a2b
+`, []dataBrokerRecord{}, Input{IsValidClientCertificate: true, ClientCertificate: cert})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonClientCertificateOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - client_certificate:
+        san_dns:
+          has: service-b.example.com
+`, []dataBrokerRecord{}, Input{IsValidClientCertificate: true, ClientCertificate: cert})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonClientCertificateUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("invalid certificate", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - client_certificate:
+        common_name:
+          is: service-a
+`, []dataBrokerRecord{}, Input{IsValidClientCertificate: false, ClientCertificate: cert})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonClientCertificateUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("no certificate", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - client_certificate: {}
+`, []dataBrokerRecord{}, Input{IsValidClientCertificate: true})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonClientCertificateUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := evaluate(t, `
+allow:
+  and:
+    - client_certificate:
+        subject:
+          is: service-a
+`, []dataBrokerRecord{}, Input{})
+		assert.Error(t, err)
+	})
+}
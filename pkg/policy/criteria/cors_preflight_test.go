@@ -14,9 +14,9 @@ allow:
     - cors_preflight: 1
 `, []dataBrokerRecord{}, Input{HTTP: InputHTTP{
 			Method: "OPTIONS",
-			Headers: map[string][]string{
-				"Access-Control-Request-Method": {"GET"},
-				"Origin":                        {"example.com"},
+			Headers: map[string]string{
+				"Access-Control-Request-Method": "GET",
+				"Origin":                        "example.com",
 			},
 		}})
 		require.NoError(t, err)
@@ -27,17 +27,31 @@ var testingNow = time.Date(2021, 5, 11, 13, 43, 0, 0, time.Local)
 
 type (
 	Input struct {
-		HTTP    InputHTTP    `json:"http"`
-		Session InputSession `json:"session"`
+		HTTP                     InputHTTP              `json:"http"`
+		Session                  InputSession           `json:"session"`
+		IsValidClientCertificate bool                   `json:"is_valid_client_certificate"`
+		ClientCertificate        InputClientCertificate `json:"client_certificate"`
 	}
 	InputHTTP struct {
-		Method  string              `json:"method"`
-		Path    string              `json:"path"`
-		Headers map[string][]string `json:"headers"`
+		Method  string            `json:"method"`
+		Path    string            `json:"path"`
+		URL     string            `json:"url"`
+		IP      string            `json:"ip"`
+		Headers map[string]string `json:"headers"`
 	}
 	InputSession struct {
 		ID string `json:"id"`
 	}
+	InputClientCertificate struct {
+		Presented         bool     `json:"presented"`
+		CommonName        string   `json:"common_name"`
+		Issuer            string   `json:"issuer"`
+		SerialNumber      string   `json:"serial_number"`
+		FingerprintSHA256 string   `json:"fingerprint_sha256"`
+		DNSNames          []string `json:"dns_names"`
+		EmailAddresses    []string `json:"email_addresses"`
+		URIs              []string `json:"uris"`
+	}
 )
 
 func generateRegoFromYAML(raw string) (string, error) {
@@ -0,0 +1,180 @@
+package criteria
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+const (
+	timeOperatorAfter    = "after"
+	timeOperatorBefore   = "before"
+	timeOperatorBetween  = "between"
+	timeOperatorTimezone = "timezone"
+)
+
+var dateOperatorLookup = map[string]struct{}{
+	timeOperatorAfter:    {},
+	timeOperatorBefore:   {},
+	timeOperatorBetween:  {},
+	timeOperatorTimezone: {},
+}
+
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+type dateCriterion struct {
+	g *Generator
+}
+
+func (dateCriterion) DataType() CriterionDataType {
+	return generator.CriterionDataTypeUnknown
+}
+
+func (dateCriterion) Name() string {
+	return "date"
+}
+
+func (c dateCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	obj, ok := data.(parser.Object)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected object for date criterion, got: %T", data)
+	}
+
+	for k := range obj {
+		_, ok := dateOperatorLookup[k]
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected field in date criterion: %s", k)
+		}
+	}
+
+	loc, err := getTimezone(obj, time.UTC)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid timezone for date criterion: %w", err)
+	}
+
+	after, before, err := getTimeBounds(obj)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid date criterion: %w", err)
+	}
+
+	body := ast.Body{
+		ast.MustParseExpr(`now := time.now_ns()`),
+	}
+	if after != nil {
+		t, err := parseDate(*after, loc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid date criterion: %w", err)
+		}
+		body = append(body, ast.GreaterThanEq.Expr(ast.VarTerm("now"), newNanosecondsTerm(t)))
+	}
+	if before != nil {
+		t, err := parseDate(*before, loc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid date criterion: %w", err)
+		}
+		body = append(body, ast.LessThan.Expr(ast.VarTerm("now"), newNanosecondsTerm(t)))
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonDateOK, ReasonDateUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// getTimezone returns the location for the timezone operator, or the default location if there is none.
+func getTimezone(obj parser.Object, defaultLocation *time.Location) (*time.Location, error) {
+	v, ok := obj[timeOperatorTimezone]
+	if !ok {
+		return defaultLocation, nil
+	}
+	s, ok := v.(parser.String)
+	if !ok {
+		return nil, fmt.Errorf("expected string, got: %T", v)
+	}
+	return time.LoadLocation(string(s))
+}
+
+// getTimeBounds returns the raw values of the bounds given by the after, before and between operators. At least one
+// bound is required.
+func getTimeBounds(obj parser.Object) (after, before *string, err error) {
+	get := func(name string, v parser.Value) (*string, error) {
+		s, ok := v.(parser.String)
+		if !ok {
+			return nil, fmt.Errorf("expected string for %s operator, got: %T", name, v)
+		}
+		raw := string(s)
+		return &raw, nil
+	}
+
+	if v, ok := obj[timeOperatorBetween]; ok {
+		if _, ok := obj[timeOperatorAfter]; ok {
+			return nil, nil, fmt.Errorf("the between operator can't be combined with the after operator")
+		}
+		if _, ok := obj[timeOperatorBefore]; ok {
+			return nil, nil, fmt.Errorf("the between operator can't be combined with the before operator")
+		}
+
+		arr, ok := v.(parser.Array)
+		if !ok || len(arr) != 2 {
+			return nil, nil, fmt.Errorf("expected array of two values for between operator, got: %v", v)
+		}
+		if after, err = get(timeOperatorBetween, arr[0]); err != nil {
+			return nil, nil, err
+		}
+		if before, err = get(timeOperatorBetween, arr[1]); err != nil {
+			return nil, nil, err
+		}
+		return after, before, nil
+	}
+
+	if v, ok := obj[timeOperatorAfter]; ok {
+		if after, err = get(timeOperatorAfter, v); err != nil {
+			return nil, nil, err
+		}
+	}
+	if v, ok := obj[timeOperatorBefore]; ok {
+		if before, err = get(timeOperatorBefore, v); err != nil {
+			return nil, nil, err
+		}
+	}
+	if after == nil && before == nil {
+		return nil, nil, fmt.Errorf("one of the after, before or between operators is required")
+	}
+	return after, before, nil
+}
+
+// parseDate parses an ISO-8601 date. Dates without a UTC offset are interpreted in the given location.
+func parseDate(raw string, loc *time.Location) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		t, err := time.ParseInLocation(layout, raw, loc)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected an ISO-8601 date, got: %s", raw)
+}
+
+func newNanosecondsTerm(t time.Time) *ast.Term {
+	return ast.NumberTerm(json.Number(strconv.FormatInt(t.UnixNano(), 10)))
+}
+
+// Date returns a Criterion which matches the time of the request against a date range.
+func Date(generator *Generator) Criterion {
+	return dateCriterion{g: generator}
+}
+
+func init() {
+	Register(Date)
+}
@@ -0,0 +1,82 @@
+package criteria
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - date:
+        after: %s
+        before: %s
+`, testingNow.Add(-time.Hour).Format(time.RFC3339), testingNow.Add(time.Hour).Format(time.RFC3339)),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonDateOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("between", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - date:
+        between: [%s, %s]
+`, testingNow.Add(-time.Hour).Format(time.RFC3339), testingNow.Add(time.Hour).Format(time.RFC3339)),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonDateOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("timezone", func(t *testing.T) {
+		loc, err := time.LoadLocation("Asia/Tokyo")
+		require.NoError(t, err)
+
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - date:
+        timezone: Asia/Tokyo
+        before: %s
+`, testingNow.In(loc).Add(time.Minute).Format("2006-01-02T15:04:05")),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonDateOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - date:
+        after: %s
+`, testingNow.Add(time.Hour).Format(time.RFC3339)),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonDateUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, policy := range []string{
+			`{ after: "yesterday" }`,
+			`{ timezone: "Nowhere/Special", after: "2021-01-01" }`,
+			`{ between: ["2021-01-01"] }`,
+			`{ between: ["2021-01-01", "2021-02-01"], after: "2021-01-01" }`,
+			`{ timezone: "UTC" }`,
+		} {
+			_, err := evaluate(t, `
+allow:
+  and:
+    - date: `+policy+`
+`, []dataBrokerRecord{}, Input{})
+			assert.Error(t, err, policy)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package criteria
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+const dayOfWeekOperatorDays = "days"
+
+var dayOfWeekOperatorLookup = map[string]struct{}{
+	dayOfWeekOperatorDays: {},
+	timeOperatorTimezone:  {},
+}
+
+type dayOfWeekCriterion struct {
+	g *Generator
+}
+
+func (dayOfWeekCriterion) DataType() CriterionDataType {
+	return generator.CriterionDataTypeUnknown
+}
+
+func (dayOfWeekCriterion) Name() string {
+	return "day_of_week"
+}
+
+func (c dayOfWeekCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	var raw parser.Value
+	loc := time.UTC
+	switch data := data.(type) {
+	case parser.String:
+		raw = data
+	case parser.Object:
+		for k := range data {
+			_, ok := dayOfWeekOperatorLookup[k]
+			if !ok {
+				return nil, nil, fmt.Errorf("unexpected field in day_of_week criterion: %s", k)
+			}
+		}
+
+		var err error
+		loc, err = getTimezone(data, time.UTC)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid timezone for day_of_week criterion: %w", err)
+		}
+
+		var ok bool
+		raw, ok = data[dayOfWeekOperatorDays]
+		if !ok {
+			return nil, nil, fmt.Errorf("days are required for day_of_week criterion")
+		}
+	default:
+		return nil, nil, fmt.Errorf("expected string or object for day_of_week criterion, got: %T", data)
+	}
+
+	s, ok := raw.(parser.String)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected string for day_of_week criterion days, got: %T", raw)
+	}
+	days, err := parseDaysOfWeek(string(s))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid day_of_week criterion: %w", err)
+	}
+
+	var terms []*ast.Term
+	for _, day := range days {
+		terms = append(terms, ast.StringTerm(day.String()))
+	}
+
+	body := ast.Body{
+		ast.MustParseExpr(fmt.Sprintf(`day := time.weekday([time.now_ns(), %q])`, loc.String())),
+		ast.Assign.Expr(ast.VarTerm("days"), ast.SetTerm(terms...)),
+		ast.MustParseExpr(`days[day]`),
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonDayOfWeekOK, ReasonDayOfWeekUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// parseDaysOfWeek parses `*`, or a comma-separated list of days and ranges of days, like `mon-wed,fri`. Ranges may
+// wrap around the end of the week, like `fri-mon`.
+func parseDaysOfWeek(raw string) ([]time.Weekday, error) {
+	if strings.TrimSpace(raw) == "*" {
+		return []time.Weekday{
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+		}, nil
+	}
+
+	seen := map[time.Weekday]bool{}
+	var days []time.Weekday
+	add := func(day time.Weekday) {
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	for _, part := range strings.Split(raw, ",") {
+		bounds := strings.Split(part, "-")
+		switch len(bounds) {
+		case 1:
+			day, err := parseDayOfWeek(bounds[0])
+			if err != nil {
+				return nil, err
+			}
+			add(day)
+		case 2:
+			start, err := parseDayOfWeek(bounds[0])
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseDayOfWeek(bounds[1])
+			if err != nil {
+				return nil, err
+			}
+			for day := start; ; day = (day + 1) % 7 {
+				add(day)
+				if day == end {
+					break
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid range of days: %s", part)
+		}
+	}
+	return days, nil
+}
+
+func parseDayOfWeek(raw string) (time.Weekday, error) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if normalized == name || normalized == name[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day of week: %s", raw)
+}
+
+// DayOfWeek returns a Criterion which matches the day of the week of the request against a list of days.
+func DayOfWeek(generator *Generator) Criterion {
+	return dayOfWeekCriterion{g: generator}
+}
+
+func init() {
+	Register(DayOfWeek)
+}
@@ -0,0 +1,76 @@
+package criteria
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDayOfWeek(t *testing.T) {
+	loc, err := time.LoadLocation("Pacific/Auckland")
+	require.NoError(t, err)
+	today := testingNow.In(loc).Weekday()
+
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - day_of_week:
+        timezone: Pacific/Auckland
+        days: %s-%s
+`, (today+6)%7, (today+1)%7),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonDayOfWeekOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("all", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - day_of_week: "*"
+`, []dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonDayOfWeekOK}, M{}}, res["allow"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - day_of_week:
+        timezone: Pacific/Auckland
+        days: %s,%s
+`, (today+1)%7, (today+2)%7),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonDayOfWeekUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := evaluate(t, `
+allow:
+  and:
+    - day_of_week: mon-someday
+`, []dataBrokerRecord{}, Input{})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseDaysOfWeek(t *testing.T) {
+	for _, tc := range []struct {
+		raw    string
+		expect []time.Weekday
+	}{
+		{"mon", []time.Weekday{time.Monday}},
+		{"Monday,wed", []time.Weekday{time.Monday, time.Wednesday}},
+		{"mon-wed,fri", []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Friday}},
+		{"fri-mon", []time.Weekday{time.Friday, time.Saturday, time.Sunday, time.Monday}},
+	} {
+		actual, err := parseDaysOfWeek(tc.raw)
+		assert.NoError(t, err, tc.raw)
+		assert.Equal(t, tc.expect, actual, tc.raw)
+	}
+}
@@ -0,0 +1,55 @@
+package criteria
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+type httpHeaderCriterion struct {
+	g *Generator
+}
+
+func (httpHeaderCriterion) DataType() CriterionDataType {
+	return CriterionDataTypeStringMatcher
+}
+
+func (httpHeaderCriterion) Name() string {
+	return "http_header"
+}
+
+func (c httpHeaderCriterion) GenerateRule(subPath string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	if subPath == "" {
+		return nil, nil, fmt.Errorf("a header name is required for the http_header criterion, e.g. http_header/X-Tenant")
+	}
+
+	// headers are passed to the policy with canonical names, so header names are case-insensitive
+	body := ast.Body{
+		ast.Assign.Expr(ast.VarTerm("value"), ast.RefTerm(
+			ast.VarTerm("input"), ast.StringTerm("http"), ast.StringTerm("headers"),
+			ast.StringTerm(http.CanonicalHeaderKey(subPath)),
+		)),
+	}
+	err := matchString(&body, ast.VarTerm("value"), data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonHTTPHeaderOK, ReasonHTTPHeaderUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// HTTPHeader returns a Criterion which matches the value of an HTTP header.
+func HTTPHeader(generator *Generator) Criterion {
+	return httpHeaderCriterion{g: generator}
+}
+
+func init() {
+	Register(HTTPHeader)
+}
@@ -0,0 +1,53 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHeader(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_header/x-tenant:
+        is: acme
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{Headers: map[string]string{"X-Tenant": "acme"}}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonHTTPHeaderOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_header/X-Tenant:
+        starts_with: acme
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{Headers: map[string]string{"X-Tenant": "example"}}})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonHTTPHeaderUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("missing", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_header/X-Tenant:
+        is: acme
+`, []dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonHTTPHeaderUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("no name", func(t *testing.T) {
+		_, err := evaluate(t, `
+allow:
+  and:
+    - http_header:
+        is: acme
+`, []dataBrokerRecord{}, Input{})
+		assert.Error(t, err)
+	})
+}
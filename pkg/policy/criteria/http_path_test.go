@@ -29,4 +29,28 @@ allow:
 		require.Equal(t, A{false, A{ReasonHTTPPathUnauthorized}, M{}}, res["allow"])
 		require.Equal(t, A{false, A{}}, res["deny"])
 	})
+	t.Run("matches", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_path:
+        matches: ^/api/v[0-9]+/admin
+        not_ends_with: /public
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{Path: "/api/v2/admin/users"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonHTTPPathOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("case insensitive", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_path:
+        case_insensitive: true
+        in: [/Admin, /Users]
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{Path: "/ADMIN"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonHTTPPathOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
 }
@@ -0,0 +1,60 @@
+package criteria
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+var httpQueryBody = ast.Body{
+	ast.MustParseExpr(`url := input.http.url`),
+	ast.MustParseExpr(`idx := indexof(url, "?")`),
+	ast.MustParseExpr(`idx >= 0`),
+	ast.MustParseExpr(`query := urlquery.decode_object(substring(url, idx + 1, -1))`),
+	ast.MustParseExpr(`value := query[name][_]`),
+}
+
+type httpQueryCriterion struct {
+	g *Generator
+}
+
+func (httpQueryCriterion) DataType() CriterionDataType {
+	return CriterionDataTypeStringMatcher
+}
+
+func (httpQueryCriterion) Name() string {
+	return "http_query"
+}
+
+func (c httpQueryCriterion) GenerateRule(subPath string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	if subPath == "" {
+		return nil, nil, fmt.Errorf("a parameter name is required for the http_query criterion, e.g. http_query/tenant")
+	}
+
+	body := ast.Body{
+		ast.Assign.Expr(ast.VarTerm("name"), ast.StringTerm(subPath)),
+	}
+	body = append(body, httpQueryBody...)
+	// if the parameter is given more than once, any of the values may match
+	err := matchString(&body, ast.VarTerm("value"), data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonHTTPQueryOK, ReasonHTTPQueryUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// HTTPQuery returns a Criterion which matches the value of a query string parameter.
+func HTTPQuery(generator *Generator) Criterion {
+	return httpQueryCriterion{g: generator}
+}
+
+func init() {
+	Register(HTTPQuery)
+}
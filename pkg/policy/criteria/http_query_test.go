@@ -0,0 +1,64 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPQuery(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_query/tenant:
+        is: acme corp
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{URL: "https://from.example.com/path?x=1&tenant=acme+corp"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonHTTPQueryOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("multiple values", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_query/tenant:
+        ends_with: corp
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{URL: "https://from.example.com/path?tenant=example&tenant=acme-corp"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonHTTPQueryOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_query/tenant:
+        contains: acme
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{URL: "https://from.example.com/path?tenant=example"}})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonHTTPQueryUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("no query", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - http_query/tenant:
+        is: acme
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{URL: "https://from.example.com/path"}})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonHTTPQueryUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("no name", func(t *testing.T) {
+		_, err := evaluate(t, `
+allow:
+  and:
+    - http_query:
+        is: acme
+`, []dataBrokerRecord{}, Input{})
+		assert.Error(t, err)
+	})
+}
@@ -2,12 +2,21 @@ package criteria
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/open-policy-agent/opa/ast"
 
 	"github.com/pomerium/pomerium/pkg/policy/parser"
 )
 
+const (
+	// matcherOperatorCaseInsensitive is a flag which makes every other operator of a matcher case-insensitive.
+	matcherOperatorCaseInsensitive = "case_insensitive"
+	// matcherOperatorNotPrefix negates an operator, e.g. not_is.
+	matcherOperatorNotPrefix = "not_"
+)
+
 type matcher func(*ast.Body, *ast.Term, parser.Value) error
 
 func matchString(dst *ast.Body, left *ast.Term, right parser.Value) error {
@@ -16,21 +25,53 @@ func matchString(dst *ast.Body, left *ast.Term, right parser.Value) error {
 		return fmt.Errorf("expected object for string matcher, got: %T", right)
 	}
 
+	caseInsensitive, err := getCaseInsensitive(obj)
+	if err != nil {
+		return err
+	}
+	if caseInsensitive {
+		left = ast.Lower.Call(left)
+	}
+
+	start := len(*dst)
 	lookup := map[string]matcher{
 		"contains":    matchStringContains,
 		"ends_with":   matchStringEndsWith,
+		"in":          matchStringIn,
 		"is":          matchStringIs,
+		"matches":     matchStringMatches,
 		"starts_with": matchStringStartsWith,
 	}
 	for k, v := range obj {
-		f, ok := lookup[k]
+		if k == matcherOperatorCaseInsensitive {
+			continue
+		}
+
+		name := strings.TrimPrefix(k, matcherOperatorNotPrefix)
+		f, ok := lookup[name]
 		if !ok {
 			return fmt.Errorf("unknown string matcher operator: %s", k)
 		}
-		err := f(dst, left, v)
+
+		if caseInsensitive {
+			v, err = lowerValue(name, v)
+			if err != nil {
+				return err
+			}
+		}
+
+		var body ast.Body
+		err := f(&body, left, v)
 		if err != nil {
 			return err
 		}
+		if name != k {
+			negate(body)
+		}
+		*dst = append(*dst, body...)
+	}
+	if len(*dst) == start {
+		return fmt.Errorf("string matcher requires an operator")
 	}
 	return nil
 }
@@ -45,11 +86,41 @@ func matchStringEndsWith(dst *ast.Body, left *ast.Term, right parser.Value) erro
 	return nil
 }
 
+func matchStringIn(dst *ast.Body, left *ast.Term, right parser.Value) error {
+	arr, ok := right.(parser.Array)
+	if !ok {
+		return fmt.Errorf("expected array for in operator, got: %T", right)
+	}
+
+	var terms []*ast.Term
+	for _, v := range arr {
+		terms = append(terms, ast.NewTerm(v.RegoValue()))
+	}
+	*dst = append(*dst, ast.NewExpr(ast.RefTerm(ast.SetTerm(terms...), left)))
+	return nil
+}
+
 func matchStringIs(dst *ast.Body, left *ast.Term, right parser.Value) error {
 	*dst = append(*dst, ast.Equal.Expr(left, ast.NewTerm(right.RegoValue())))
 	return nil
 }
 
+func matchStringMatches(dst *ast.Body, left *ast.Term, right parser.Value) error {
+	s, ok := right.(parser.String)
+	if !ok {
+		return fmt.Errorf("expected string for matches operator, got: %T", right)
+	}
+
+	// rego uses the same regular expression syntax as go, so the expression is validated here
+	_, err := regexp.Compile(string(s))
+	if err != nil {
+		return fmt.Errorf("invalid regular expression for matches operator: %w", err)
+	}
+
+	*dst = append(*dst, ast.RegexMatch.Expr(ast.StringTerm(string(s)), left))
+	return nil
+}
+
 func matchStringStartsWith(dst *ast.Body, left *ast.Term, right parser.Value) error {
 	*dst = append(*dst, ast.StartsWith.Expr(left, ast.NewTerm(right.RegoValue())))
 	return nil
@@ -61,28 +132,62 @@ func matchStringList(dst *ast.Body, left *ast.Term, right parser.Value) error {
 		return fmt.Errorf("expected object for string list matcher, got: %T", right)
 	}
 
+	caseInsensitive, err := getCaseInsensitive(obj)
+	if err != nil {
+		return err
+	}
+
+	// every list operator matches if any value in the list matches the string operator
+	start := len(*dst)
 	lookup := map[string]matcher{
-		"has": matchStringListHas,
+		"has":     matchStringIs,
+		"in":      matchStringIn,
+		"matches": matchStringMatches,
 	}
 	for k, v := range obj {
-		f, ok := lookup[k]
+		if k == matcherOperatorCaseInsensitive {
+			continue
+		}
+
+		name := strings.TrimPrefix(k, matcherOperatorNotPrefix)
+		f, ok := lookup[name]
 		if !ok {
 			return fmt.Errorf("unknown string list matcher operator: %s", k)
 		}
-		err := f(dst, left, v)
+
+		if caseInsensitive {
+			v, err = lowerValue(name, v)
+			if err != nil {
+				return err
+			}
+		}
+
+		var body ast.Body
+		err := matchStringListAny(&body, left, v, f, caseInsensitive)
 		if err != nil {
 			return err
 		}
+		if name != k {
+			negate(body)
+		}
+		*dst = append(*dst, body...)
+	}
+	if len(*dst) == start {
+		return fmt.Errorf("string list matcher requires an operator")
 	}
 	return nil
 }
 
-func matchStringListHas(dst *ast.Body, left *ast.Term, right parser.Value) error {
+func matchStringListAny(dst *ast.Body, left *ast.Term, right parser.Value, f matcher, caseInsensitive bool) error {
 	body := ast.Body{
 		ast.MustParseExpr("some v"),
 		ast.Equality.Expr(ast.VarTerm("v"), ast.RefTerm(left, ast.VarTerm("$0"))),
 	}
-	err := matchStringIs(&body, ast.VarTerm("v"), right)
+	v := ast.VarTerm("v")
+	if caseInsensitive {
+		v = ast.Lower.Call(v)
+	}
+	err := f(&body, v, right)
 	if err != nil {
 		return err
 	}
@@ -97,3 +202,44 @@ func matchStringListHas(dst *ast.Body, left *ast.Term, right parser.Value) error
 	))
 	return nil
 }
+
+func getCaseInsensitive(obj parser.Object) (bool, error) {
+	v, ok := obj[matcherOperatorCaseInsensitive]
+	if !ok {
+		return false, nil
+	}
+	b, ok := v.(parser.Boolean)
+	if !ok {
+		return false, fmt.Errorf("expected boolean for %s, got: %T", matcherOperatorCaseInsensitive, v)
+	}
+	return bool(b), nil
+}
+
+// lowerValue converts the string, or strings, of an operator to lowercase.
+func lowerValue(operator string, v parser.Value) (parser.Value, error) {
+	switch v := v.(type) {
+	case parser.String:
+		if operator == "matches" {
+			return parser.String("(?i)" + string(v)), nil
+		}
+		return parser.String(strings.ToLower(string(v))), nil
+	case parser.Array:
+		lowered := make(parser.Array, len(v))
+		for i := range v {
+			var err error
+			lowered[i], err = lowerValue(operator, v[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return lowered, nil
+	}
+	return nil, fmt.Errorf("expected string for case-insensitive %s operator, got: %T", operator, v)
+}
+
+// negate negates the expressions generated for an operator. Every operator generates a single expression.
+func negate(body ast.Body) {
+	for _, expr := range body {
+		expr.Negated = true
+	}
+}
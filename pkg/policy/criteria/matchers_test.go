@@ -50,6 +50,79 @@ func TestStringMatcher(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, `startswith(example, "test")`, str(body))
 	})
+	t.Run("matches", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"matches": parser.String("^/api/v[0-9]+/admin"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `regex.match("^/api/v[0-9]+/admin", example)`, str(body))
+	})
+	t.Run("invalid regular expression", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"matches": parser.String("[a-z"),
+		})
+		assert.Error(t, err)
+	})
+	t.Run("in", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"in": parser.Array{parser.String("a"), parser.String("b")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"a", "b"}[example]`, str(body))
+	})
+	t.Run("not_is", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"not_is": parser.String("test"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `not example == "test"`, str(body))
+	})
+	t.Run("not_in", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"not_in": parser.Array{parser.String("a")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `not {"a"}[example]`, str(body))
+	})
+	t.Run("case_insensitive", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"case_insensitive": parser.Boolean(true),
+			"starts_with":      parser.String("TEST"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `startswith(lower(example), "test")`, str(body))
+
+		body = nil
+		err = matchString(&body, ast.VarTerm("example"), parser.Object{
+			"case_insensitive": parser.Boolean(true),
+			"matches":          parser.String("^A"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `regex.match("(?i)^A", lower(example))`, str(body))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"not_like": parser.String("test"),
+		})
+		assert.Error(t, err)
+	})
+	t.Run("no operator", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"case_insensitive": parser.Boolean(true),
+		})
+		assert.Error(t, err, "should not match everything")
+
+		err = matchString(&body, ast.VarTerm("example"), parser.Object{})
+		assert.Error(t, err)
+	})
 }
 
 func TestStringListMatcher(t *testing.T) {
@@ -66,4 +139,45 @@ func TestStringListMatcher(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, `count([true | some v; v = example[_]; v == "test"]) > 0`, str(body))
 	})
+	t.Run("not_has", func(t *testing.T) {
+		var body ast.Body
+		err := matchStringList(&body, ast.VarTerm("example"), parser.Object{
+			"not_has": parser.String("test"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `not count([true | some v; v = example[_]; v == "test"]) > 0`, str(body))
+	})
+	t.Run("case_insensitive", func(t *testing.T) {
+		var body ast.Body
+		err := matchStringList(&body, ast.VarTerm("example"), parser.Object{
+			"case_insensitive": parser.Boolean(true),
+			"has":              parser.String("TEST"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `count([true | some v; v = example[_]; lower(v) == "test"]) > 0`, str(body))
+	})
+	t.Run("in", func(t *testing.T) {
+		var body ast.Body
+		err := matchStringList(&body, ast.VarTerm("example"), parser.Object{
+			"in": parser.Array{parser.String("a"), parser.String("b")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `count([true | some v; v = example[_]; {"a", "b"}[v]]) > 0`, str(body))
+	})
+	t.Run("matches", func(t *testing.T) {
+		var body ast.Body
+		err := matchStringList(&body, ast.VarTerm("example"), parser.Object{
+			"case_insensitive": parser.Boolean(true),
+			"not_matches":      parser.String("^ADMIN-"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `not count([true | some v; v = example[_]; regex.match("(?i)^ADMIN-", lower(v))]) > 0`, str(body))
+	})
+	t.Run("no operator", func(t *testing.T) {
+		var body ast.Body
+		err := matchStringList(&body, ast.VarTerm("example"), parser.Object{
+			"case_insensitive": parser.Boolean(true),
+		})
+		assert.Error(t, err, "should not match everything")
+	})
 }
@@ -1,6 +1,9 @@
 package criteria
 
-import "sort"
+import (
+	"encoding/json"
+	"sort"
+)
 
 // A Reason is a reason for why a policy criterion passes or fails.
 type Reason string
@@ -10,7 +13,13 @@ const (
 	ReasonAccept                               = "accept"
 	ReasonClaimOK                              = "claim-ok"
 	ReasonClaimUnauthorized                    = "claim-unauthorized"
+	ReasonClientCertificateOK                  = "client-certificate-ok"
+	ReasonClientCertificateUnauthorized        = "client-certificate-unauthorized"
 	ReasonCORSRequest                          = "cors-request"
+	ReasonDateOK                               = "date-ok"
+	ReasonDateUnauthorized                     = "date-unauthorized"
+	ReasonDayOfWeekOK                          = "day-of-week-ok"
+	ReasonDayOfWeekUnauthorized                = "day-of-week-unauthorized"
 	ReasonDeviceOK                             = "device-ok"
 	ReasonDeviceUnauthenticated                = "device-unauthenticated"
 	ReasonDeviceUnauthorized                   = "device-unauthorized"
@@ -20,16 +29,24 @@ const (
 	ReasonEmailUnauthorized                    = "email-unauthorized"
 	ReasonGroupsOK                             = "groups-ok"
 	ReasonGroupsUnauthorized                   = "groups-unauthorized"
+	ReasonHTTPHeaderOK                         = "http-header-ok"
+	ReasonHTTPHeaderUnauthorized               = "http-header-unauthorized"
 	ReasonHTTPMethodOK                         = "http-method-ok"
 	ReasonHTTPMethodUnauthorized               = "http-method-unauthorized"
 	ReasonHTTPPathOK                           = "http-path-ok"
 	ReasonHTTPPathUnauthorized                 = "http-path-unauthorized"
+	ReasonHTTPQueryOK                          = "http-query-ok"
+	ReasonHTTPQueryUnauthorized                = "http-query-unauthorized"
 	ReasonInvalidClientCertificate             = "invalid-client-certificate"
 	ReasonNonCORSRequest                       = "non-cors-request"
 	ReasonNonPomeriumRoute                     = "non-pomerium-route"
 	ReasonPomeriumRoute                        = "pomerium-route"
 	ReasonReject                               = "reject"
 	ReasonRouteNotFound                        = "route-not-found"
+	ReasonSourceIPOK                           = "source-ip-ok"
+	ReasonSourceIPUnauthorized                 = "source-ip-unauthorized"
+	ReasonTimeOfDayOK                          = "time-of-day-ok"
+	ReasonTimeOfDayUnauthorized                = "time-of-day-unauthorized"
 	ReasonUserOK                               = "user-ok"
 	ReasonUserUnauthenticated                  = "user-unauthenticated" // user needs to log in
 	ReasonUserUnauthorized                     = "user-unauthorized"    // user does not have access
@@ -69,6 +86,15 @@ func (rs Reasons) Strings() []string {
 	return arr
 }
 
+// MarshalJSON marshals the reason collection as a sorted array of strings.
+func (rs Reasons) MarshalJSON() ([]byte, error) {
+	arr := rs.Strings()
+	if arr == nil {
+		arr = []string{}
+	}
+	return json.Marshal(arr)
+}
+
 // Union merges two reason collections together.
 func (rs Reasons) Union(other Reasons) Reasons {
 	merged := make(Reasons)
@@ -0,0 +1,92 @@
+package criteria
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+type sourceIPCriterion struct {
+	g *Generator
+}
+
+func (sourceIPCriterion) DataType() CriterionDataType {
+	return generator.CriterionDataTypeUnknown
+}
+
+func (sourceIPCriterion) Name() string {
+	return "source_ip"
+}
+
+func (c sourceIPCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	var values []parser.Value
+	switch data := data.(type) {
+	case parser.String:
+		values = append(values, data)
+	case parser.Array:
+		values = data
+	default:
+		return nil, nil, fmt.Errorf("expected string or array of strings for source_ip criterion, got: %T", data)
+	}
+
+	var cidrs []*ast.Term
+	for _, v := range values {
+		s, ok := v.(parser.String)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected string for source_ip criterion, got: %T", v)
+		}
+		cidr, err := parseCIDR(string(s))
+		if err != nil {
+			return nil, nil, err
+		}
+		cidrs = append(cidrs, ast.StringTerm(cidr))
+	}
+
+	body := ast.Body{
+		ast.MustParseExpr(`ip := input.http.ip`),
+		ast.MustParseExpr(`ip != ""`),
+		ast.Assign.Expr(ast.VarTerm("cidrs"), ast.ArrayTerm(cidrs...)),
+		ast.MustParseExpr(`net.cidr_contains(cidrs[_], ip)`),
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonSourceIPOK, ReasonSourceIPUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// parseCIDR parses an IP address or CIDR range and returns it as a CIDR range. IP addresses are treated as a range
+// containing only that address.
+func parseCIDR(raw string) (string, error) {
+	if !strings.Contains(raw, "/") {
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP address for source_ip criterion: %s", raw)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4.String() + "/32", nil
+		}
+		return ip.String() + "/128", nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR for source_ip criterion: %s", raw)
+	}
+	return ipNet.String(), nil
+}
+
+// SourceIP returns a Criterion which matches the client IP address against a list of CIDR ranges.
+func SourceIP(generator *Generator) Criterion {
+	return sourceIPCriterion{g: generator}
+}
+
+func init() {
+	Register(SourceIP)
+}
@@ -0,0 +1,84 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceIP(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - source_ip:
+        - 10.0.0.0/8
+        - 2001:db8::/32
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{IP: "10.1.2.3"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonSourceIPOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("ipv6", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - source_ip:
+        - 10.0.0.0/8
+        - 2001:db8::/32
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{IP: "2001:db8::1"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonSourceIPOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("single address", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - source_ip: 192.0.2.1
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{IP: "192.0.2.1"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonSourceIPOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - source_ip:
+        - 10.0.0.0/8
+        - 2001:db8::/32
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{IP: "192.0.2.1"}})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonSourceIPUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("missing", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - source_ip: 10.0.0.0/8
+`, []dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonSourceIPUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("deny", func(t *testing.T) {
+		res, err := evaluate(t, `
+deny:
+  or:
+    - source_ip: 192.0.2.0/24
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{IP: "192.0.2.1"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonSourceIPOK}, M{}}, res["deny"])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := evaluate(t, `
+allow:
+  and:
+    - source_ip: 10.0.0.0/33
+`, []dataBrokerRecord{}, Input{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,120 @@
+package criteria
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+var timeOfDayLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"3:04:05PM",
+	"3:04PM",
+	"3PM",
+}
+
+type timeOfDayCriterion struct {
+	g *Generator
+}
+
+func (timeOfDayCriterion) DataType() CriterionDataType {
+	return generator.CriterionDataTypeUnknown
+}
+
+func (timeOfDayCriterion) Name() string {
+	return "time_of_day"
+}
+
+func (c timeOfDayCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	obj, ok := data.(parser.Object)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected object for time_of_day criterion, got: %T", data)
+	}
+
+	for k := range obj {
+		_, ok := dateOperatorLookup[k]
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected field in time_of_day criterion: %s", k)
+		}
+	}
+
+	if _, ok := obj[timeOperatorTimezone]; !ok {
+		return nil, nil, fmt.Errorf("timezone is required for time_of_day criterion")
+	}
+	loc, err := getTimezone(obj, time.UTC)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid timezone for time_of_day criterion: %w", err)
+	}
+
+	after, before, err := getTimeBounds(obj)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time_of_day criterion: %w", err)
+	}
+
+	body := ast.Body{
+		ast.MustParseExpr(fmt.Sprintf(`clock := time.clock([time.now_ns(), %q])`, loc.String())),
+		ast.MustParseExpr(`seconds := ((clock[0] * 60) + clock[1]) * 60 + clock[2]`),
+	}
+
+	var afterSeconds, beforeSeconds int
+	if after != nil {
+		if afterSeconds, err = parseTimeOfDay(*after); err != nil {
+			return nil, nil, fmt.Errorf("invalid time_of_day criterion: %w", err)
+		}
+	}
+	if before != nil {
+		if beforeSeconds, err = parseTimeOfDay(*before); err != nil {
+			return nil, nil, fmt.Errorf("invalid time_of_day criterion: %w", err)
+		}
+	}
+
+	switch {
+	case after != nil && before != nil && afterSeconds > beforeSeconds:
+		// the range spans midnight, so shift the times of day so the range starts at midnight
+		body = append(body, ast.MustParseExpr(fmt.Sprintf(`((seconds - %d) + %d) %% %d < %d`,
+			afterSeconds, secondsPerDay, secondsPerDay, beforeSeconds-afterSeconds+secondsPerDay)))
+	default:
+		if after != nil {
+			body = append(body, ast.GreaterThanEq.Expr(ast.VarTerm("seconds"), ast.IntNumberTerm(afterSeconds)))
+		}
+		if before != nil {
+			body = append(body, ast.LessThan.Expr(ast.VarTerm("seconds"), ast.IntNumberTerm(beforeSeconds)))
+		}
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonTimeOfDayOK, ReasonTimeOfDayUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// parseTimeOfDay parses a time of day, in either 24-hour or 12-hour format, and returns the number of seconds since
+// midnight.
+func parseTimeOfDay(raw string) (int, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(raw, " ", ""))
+	for _, layout := range timeOfDayLayouts {
+		t, err := time.Parse(layout, normalized)
+		if err == nil {
+			return (t.Hour()*60+t.Minute())*60 + t.Second(), nil
+		}
+	}
+	return 0, fmt.Errorf("expected a time of day, got: %s", raw)
+}
+
+// TimeOfDay returns a Criterion which matches the time of day of the request against a time range.
+func TimeOfDay(generator *Generator) Criterion {
+	return timeOfDayCriterion{g: generator}
+}
+
+func init() {
+	Register(TimeOfDay)
+}
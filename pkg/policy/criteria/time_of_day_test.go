@@ -0,0 +1,82 @@
+package criteria
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	require.NoError(t, err)
+	now := testingNow.In(loc)
+
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - time_of_day:
+        timezone: America/Phoenix
+        after: "%s"
+        before: "%s"
+`, now.Add(-time.Minute).Format("15:04"), now.Add(time.Minute).Format("3:04PM")),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonTimeOfDayOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("overnight", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - time_of_day:
+        timezone: America/Phoenix
+        between: ["%s", "%s"]
+`, now.Add(time.Minute).Format("15:04"), now.Add(2*time.Minute).Format("15:04")),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonTimeOfDayUnauthorized}, M{}}, res["allow"])
+
+		res, err = evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - time_of_day:
+        timezone: America/Phoenix
+        between: ["%s", "%s"]
+`, now.Add(2*time.Minute).Format("15:04"), now.Add(time.Minute).Format("15:04")),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonTimeOfDayOK}, M{}}, res["allow"],
+			"should match a range spanning midnight")
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, fmt.Sprintf(`
+allow:
+  and:
+    - time_of_day:
+        timezone: America/Phoenix
+        before: "%s"
+`, now.Add(-time.Minute).Format("15:04:05")),
+			[]dataBrokerRecord{}, Input{})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonTimeOfDayUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, policy := range []string{
+			`{ after: "9:00" }`,
+			`{ timezone: "UTC", after: "25:00" }`,
+			`{ timezone: "UTC", between: ["9:00", "noon"] }`,
+		} {
+			_, err := evaluate(t, `
+allow:
+  and:
+    - time_of_day: `+policy+`
+`, []dataBrokerRecord{}, Input{})
+			assert.Error(t, err, policy)
+		}
+	})
+}
@@ -29,14 +29,18 @@ var (
 	}
 )
 
-func (g *Generator) generateAndRule(dst *ast.RuleSet, policyCriteria []parser.Criterion) (*ast.Rule, error) {
+func (g *Generator) generateAndRule(
+	dst *ast.RuleSet,
+	policyCriteria []parser.Criterion,
+	tree *TreeOperator,
+) (*ast.Rule, error) {
 	rule := g.NewRule("and")
 
 	if len(policyCriteria) == 0 {
 		return rule, nil
 	}
 
-	terms, err := g.generateCriterionRules(dst, policyCriteria)
+	terms, err := g.generateCriterionRules(dst, policyCriteria, tree)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +55,11 @@ func (g *Generator) generateAndRule(dst *ast.RuleSet, policyCriteria []parser.Cr
 	return rule, nil
 }
 
-func (g *Generator) generateNotRule(dst *ast.RuleSet, policyCriteria []parser.Criterion) (*ast.Rule, error) {
+func (g *Generator) generateNotRule(
+	dst *ast.RuleSet,
+	policyCriteria []parser.Criterion,
+	tree *TreeOperator,
+) (*ast.Rule, error) {
 	rule := g.NewRule("not")
 
 	if len(policyCriteria) == 0 {
@@ -60,7 +68,7 @@ func (g *Generator) generateNotRule(dst *ast.RuleSet, policyCriteria []parser.Cr
 
 	// NOT => (NOT A) AND (NOT B)
 
-	terms, err := g.generateCriterionRules(dst, policyCriteria)
+	terms, err := g.generateCriterionRules(dst, policyCriteria, tree)
 	if err != nil {
 		return nil, err
 	}
@@ -75,14 +83,18 @@ func (g *Generator) generateNotRule(dst *ast.RuleSet, policyCriteria []parser.Cr
 	return rule, nil
 }
 
-func (g *Generator) generateOrRule(dst *ast.RuleSet, policyCriteria []parser.Criterion) (*ast.Rule, error) {
+func (g *Generator) generateOrRule(
+	dst *ast.RuleSet,
+	policyCriteria []parser.Criterion,
+	tree *TreeOperator,
+) (*ast.Rule, error) {
 	rule := g.NewRule("or")
 
 	if len(policyCriteria) == 0 {
 		return rule, nil
 	}
 
-	terms, err := g.generateCriterionRules(dst, policyCriteria)
+	terms, err := g.generateCriterionRules(dst, policyCriteria, tree)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +109,11 @@ func (g *Generator) generateOrRule(dst *ast.RuleSet, policyCriteria []parser.Cri
 	return rule, nil
 }
 
-func (g *Generator) generateNorRule(dst *ast.RuleSet, policyCriteria []parser.Criterion) (*ast.Rule, error) {
+func (g *Generator) generateNorRule(
+	dst *ast.RuleSet,
+	policyCriteria []parser.Criterion,
+	tree *TreeOperator,
+) (*ast.Rule, error) {
 	rule := g.NewRule("nor")
 
 	if len(policyCriteria) == 0 {
@@ -106,7 +122,7 @@ func (g *Generator) generateNorRule(dst *ast.RuleSet, policyCriteria []parser.Cr
 
 	// NOR => (NOT A) OR (NOT B)
 
-	terms, err := g.generateCriterionRules(dst, policyCriteria)
+	terms, err := g.generateCriterionRules(dst, policyCriteria, tree)
 	if err != nil {
 		return nil, err
 	}
@@ -121,21 +137,40 @@ func (g *Generator) generateNorRule(dst *ast.RuleSet, policyCriteria []parser.Cr
 	return rule, nil
 }
 
-func (g *Generator) generateCriterionRules(dst *ast.RuleSet, policyCriteria []parser.Criterion) ([]*ast.Term, error) {
+func (g *Generator) generateCriterionRules(
+	dst *ast.RuleSet,
+	policyCriteria []parser.Criterion,
+	tree *TreeOperator,
+) ([]*ast.Term, error) {
 	var terms []*ast.Term
 	for _, policyCriterion := range policyCriteria {
-		criterion, ok := g.criteria[policyCriterion.Name]
-		if !ok {
-			return nil, fmt.Errorf("unknown policy criterion: %s", policyCriterion.Name)
+		var mainRule *ast.Rule
+		if policyCriterion.Name == parser.PolicyRefCriterionName {
+			var err error
+			mainRule, err = g.generatePolicyRefRule(dst, policyCriterion.Data)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			criterion, ok := g.criteria[policyCriterion.Name]
+			if !ok {
+				return nil, fmt.Errorf("unknown policy criterion: %s", policyCriterion.Name)
+			}
+			var additionalRules []*ast.Rule
+			var err error
+			mainRule, additionalRules, err = criterion.GenerateRule(policyCriterion.SubPath, policyCriterion.Data)
+			if err != nil {
+				return nil, fmt.Errorf("error generating criterion rules: %w", err)
+			}
+			*dst = dst.Merge(additionalRules)
 		}
-		mainRule, additionalRules, err := criterion.GenerateRule(policyCriterion.SubPath, policyCriterion.Data)
-		if err != nil {
-			return nil, fmt.Errorf("error generating criterion rules: %w", err)
-		}
-		*dst = dst.Merge(additionalRules)
 		dst.Add(mainRule)
 
 		terms = append(terms, ast.VarTerm(string(mainRule.Head.Name)))
+		tree.Criteria = append(tree.Criteria, TreeCriterion{
+			Criterion: policyCriterion,
+			RuleName:  string(mainRule.Head.Name),
+		})
 	}
 	return terms, nil
 }
@@ -13,8 +13,10 @@ import (
 
 // A Generator generates a rego script from a policy.
 type Generator struct {
-	ids      map[string]int
-	criteria map[string]Criterion
+	ids           map[string]int
+	criteria      map[string]Criterion
+	namedPolicies map[string]*parser.Policy
+	policyRefs    []string
 }
 
 // An Option configures the Generator.
@@ -28,6 +30,13 @@ func WithCriterion(criterionConstructor CriterionConstructor) Option {
 	}
 }
 
+// WithNamedPolicies sets the named policies which can be referenced by the policy_ref criterion.
+func WithNamedPolicies(namedPolicies map[string]*parser.Policy) Option {
+	return func(g *Generator) {
+		g.namedPolicies = namedPolicies
+	}
+}
+
 // New creates a new Generator.
 func New(options ...Option) *Generator {
 	g := &Generator{
@@ -46,8 +55,20 @@ func (g *Generator) GetCriterion(name string) (Criterion, bool) {
 	return c, ok
 }
 
+// GetNamedPolicy gets a named policy which can be referenced by the policy_ref criterion.
+func (g *Generator) GetNamedPolicy(name string) (*parser.Policy, bool) {
+	p, ok := g.namedPolicies[name]
+	return p, ok
+}
+
 // Generate generates the rego module from a policy.
 func (g *Generator) Generate(policy *parser.Policy) (*ast.Module, error) {
+	mod, _, err := g.GenerateWithTree(policy)
+	return mod, err
+}
+
+// GenerateWithTree generates the rego module from a policy, along with a Tree describing the generated rules.
+func (g *Generator) GenerateWithTree(policy *parser.Policy) (*ast.Module, *Tree, error) {
 	rs := ast.NewRuleSet()
 	rs.Add(ast.MustParseRule(`default allow = [false, set()]`))
 	rs.Add(ast.MustParseRule(`default deny = [false, set()]`))
@@ -57,41 +78,11 @@ func (g *Generator) Generate(policy *parser.Policy) (*ast.Module, error) {
 	rs.Add(rules.MergeWithAnd())
 	rs.Add(rules.MergeWithOr())
 
+	tree := new(Tree)
 	for _, action := range []parser.Action{parser.ActionAllow, parser.ActionDeny} {
-		var terms []*ast.Term
-		for _, policyRule := range policy.Rules {
-			if policyRule.Action != action {
-				continue
-			}
-
-			if len(policyRule.And) > 0 {
-				subRule, err := g.generateAndRule(&rs, policyRule.And)
-				if err != nil {
-					return nil, err
-				}
-				terms = append(terms, ast.VarTerm(string(subRule.Head.Name)))
-			}
-			if len(policyRule.Or) > 0 {
-				subRule, err := g.generateOrRule(&rs, policyRule.Or)
-				if err != nil {
-					return nil, err
-				}
-				terms = append(terms, ast.VarTerm(string(subRule.Head.Name)))
-			}
-			if len(policyRule.Not) > 0 {
-				subRule, err := g.generateNotRule(&rs, policyRule.Not)
-				if err != nil {
-					return nil, err
-				}
-				terms = append(terms, ast.VarTerm(string(subRule.Head.Name)))
-			}
-			if len(policyRule.Nor) > 0 {
-				subRule, err := g.generateNorRule(&rs, policyRule.Nor)
-				if err != nil {
-					return nil, err
-				}
-				terms = append(terms, ast.VarTerm(string(subRule.Head.Name)))
-			}
+		terms, err := g.generateActionRules(&rs, policy, action, tree)
+		if err != nil {
+			return nil, nil, err
 		}
 		if len(terms) > 0 {
 			rule := &ast.Rule{
@@ -130,7 +121,50 @@ func (g *Generator) Generate(policy *parser.Policy) (*ast.Module, error) {
 		return false
 	})
 
-	return mod, nil
+	return mod, tree, nil
+}
+
+// generateActionRules generates the rules for the logical operators of every rule in a policy with the given action,
+// returning the terms for the operator rules.
+func (g *Generator) generateActionRules(
+	rs *ast.RuleSet,
+	policy *parser.Policy,
+	action parser.Action,
+	tree *Tree,
+) ([]*ast.Term, error) {
+	var terms []*ast.Term
+	for _, policyRule := range policy.Rules {
+		if policyRule.Action != action {
+			continue
+		}
+
+		treeRule := TreeRule{Action: action}
+		for _, conditional := range []struct {
+			operator string
+			criteria []parser.Criterion
+			generate func(*ast.RuleSet, []parser.Criterion, *TreeOperator) (*ast.Rule, error)
+		}{
+			{"and", policyRule.And, g.generateAndRule},
+			{"or", policyRule.Or, g.generateOrRule},
+			{"not", policyRule.Not, g.generateNotRule},
+			{"nor", policyRule.Nor, g.generateNorRule},
+		} {
+			if len(conditional.criteria) == 0 {
+				continue
+			}
+
+			treeOperator := TreeOperator{Operator: conditional.operator}
+			subRule, err := conditional.generate(rs, conditional.criteria, &treeOperator)
+			if err != nil {
+				return nil, err
+			}
+			terms = append(terms, ast.VarTerm(string(subRule.Head.Name)))
+			treeOperator.RuleName = string(subRule.Head.Name)
+			treeRule.Operators = append(treeRule.Operators, treeOperator)
+		}
+		tree.Rules = append(tree.Rules, treeRule)
+	}
+	return terms, nil
 }
 
 // NewRuleFromTemplate creates a new rule from a template rule.
@@ -238,3 +238,71 @@ else = [false, reasons, additional_data] {
 }
 `, string(format.MustAst(mod)))
 }
+
+func TestGenerateWithTree(t *testing.T) {
+	g := New(WithCriterion(func(g *Generator) Criterion {
+		return NewCriterionFunc(CriterionDataTypeUnused, "accept", func(subPath string, data parser.Value) (rule *ast.Rule, additionalRules []*ast.Rule, err error) {
+			rule = g.NewRule("accept")
+			rule.Body = append(rule.Body, ast.MustParseExpr("1 == 1"))
+			return rule, nil, nil
+		})
+	}))
+
+	_, tree, err := g.GenerateWithTree(&parser.Policy{
+		Rules: []parser.Rule{
+			{
+				Action: parser.ActionDeny,
+				Not: []parser.Criterion{
+					{Name: "accept", Data: parser.String("x")},
+				},
+			},
+			{
+				Action: parser.ActionAllow,
+				And: []parser.Criterion{
+					{Name: "accept"},
+				},
+				Or: []parser.Criterion{
+					{Name: "accept"},
+					{Name: "accept"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Tree{
+		Rules: []TreeRule{
+			{
+				Action: parser.ActionAllow,
+				Operators: []TreeOperator{
+					{
+						Operator: "and",
+						RuleName: "and_0",
+						Criteria: []TreeCriterion{
+							{Criterion: parser.Criterion{Name: "accept"}, RuleName: "accept_0"},
+						},
+					},
+					{
+						Operator: "or",
+						RuleName: "or_0",
+						Criteria: []TreeCriterion{
+							{Criterion: parser.Criterion{Name: "accept"}, RuleName: "accept_1"},
+							{Criterion: parser.Criterion{Name: "accept"}, RuleName: "accept_2"},
+						},
+					},
+				},
+			},
+			{
+				Action: parser.ActionDeny,
+				Operators: []TreeOperator{
+					{
+						Operator: "not",
+						RuleName: "not_0",
+						Criteria: []TreeCriterion{
+							{Criterion: parser.Criterion{Name: "accept", Data: parser.String("x")}, RuleName: "accept_3"},
+						},
+					},
+				},
+			},
+		},
+	}, tree)
+}
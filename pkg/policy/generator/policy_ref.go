@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+// generatePolicyRefRule generates a criterion rule for a reference to a named policy. The rules of the named policy
+// are inlined, and the criterion matches if any of its allow rules match and none of its deny rules match.
+func (g *Generator) generatePolicyRefRule(dst *ast.RuleSet, data parser.Value) (*ast.Rule, error) {
+	name, ok := data.(parser.String)
+	if !ok {
+		return nil, fmt.Errorf("expected string for %s, got: %T", parser.PolicyRefCriterionName, data)
+	}
+	policy, ok := g.GetNamedPolicy(string(name))
+	if !ok {
+		return nil, fmt.Errorf("unknown named policy in %s: %s", parser.PolicyRefCriterionName, string(name))
+	}
+
+	// named policies may reference other named policies, but not themselves
+	for _, ref := range g.policyRefs {
+		if ref == string(name) {
+			return nil, fmt.Errorf("cycle in %s: %s -> %s",
+				parser.PolicyRefCriterionName, strings.Join(g.policyRefs, " -> "), string(name))
+		}
+	}
+	g.policyRefs = append(g.policyRefs, string(name))
+	defer func() { g.policyRefs = g.policyRefs[:len(g.policyRefs)-1] }()
+
+	// the tree of a named policy isn't explained, so it is discarded
+	tree := new(Tree)
+	allowTerms, err := g.generateActionRules(dst, policy, parser.ActionAllow, tree)
+	if err != nil {
+		return nil, err
+	}
+	denyTerms, err := g.generateActionRules(dst, policy, parser.ActionDeny, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	allowRule := &ast.Rule{
+		Head: &ast.Head{
+			Value: ast.ArrayTerm(ast.BooleanTerm(false), ast.SetTerm()),
+		},
+		Body: ast.Body{
+			ast.NewExpr(ast.BooleanTerm(true)),
+		},
+	}
+	if len(allowTerms) > 0 {
+		allowRule.Head.Value = ast.VarTerm("v")
+		allowRule.Body = append(ast.Body{
+			ast.Assign.Expr(ast.VarTerm("results"), ast.ArrayTerm(allowTerms...)),
+		}, orBody...)
+	}
+	if len(denyTerms) == 0 {
+		rule := g.NewRule(parser.PolicyRefCriterionName)
+		rule.Head.Value = allowRule.Head.Value
+		rule.Body = allowRule.Body
+		return rule, nil
+	}
+
+	// a matching deny rule takes precedence, so its reasons are used
+	rule := g.NewRule(parser.PolicyRefCriterionName)
+	rule.Head.Value = ast.VarTerm("v")
+	rule.Body = ast.Body{
+		ast.Assign.Expr(ast.VarTerm("results"), ast.ArrayTerm(denyTerms...)),
+		ast.MustParseExpr(`normalized := [normalize_criterion_result(x)|x:=results[i]]`),
+		ast.MustParseExpr(`deny := merge_with_or(normalized)`),
+		ast.MustParseExpr(`deny[0]`),
+		ast.MustParseExpr(`v := array.concat([false], array.slice(deny, 1, count(deny)))`),
+	}
+	rule.Else = allowRule
+	return rule, nil
+}
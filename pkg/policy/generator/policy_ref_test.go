@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/format"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+func TestPolicyRef(t *testing.T) {
+	newTestGenerator := func(namedPolicies map[string]*parser.Policy) *Generator {
+		newCriterion := func(name, reason string, result bool) CriterionConstructor {
+			return func(g *Generator) Criterion {
+				return NewCriterionFunc(CriterionDataTypeUnused, name, func(subPath string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+					rule := g.NewRule(name)
+					rule.Head.Value = ast.ArrayTerm(ast.BooleanTerm(result), ast.SetTerm(ast.StringTerm(reason)))
+					rule.Body = ast.Body{ast.NewExpr(ast.BooleanTerm(true))}
+					return rule, nil, nil
+				})
+			}
+		}
+		return New(
+			WithCriterion(newCriterion("accept", "accept", true)),
+			WithCriterion(newCriterion("reject", "reject", false)),
+			WithNamedPolicies(namedPolicies),
+		)
+	}
+	evaluate := func(t *testing.T, g *Generator, p *parser.Policy) (allow, deny interface{}) {
+		t.Helper()
+
+		mod, err := g.Generate(p)
+		require.NoError(t, err)
+		bs, err := format.Ast(mod)
+		require.NoError(t, err)
+
+		rs, err := rego.New(
+			rego.Module("policy.rego", string(bs)),
+			rego.Query("allow = data.pomerium.policy.allow; deny = data.pomerium.policy.deny"),
+		).Eval(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rs, 1)
+		return rs[0].Bindings["allow"], rs[0].Bindings["deny"]
+	}
+	ref := func(action parser.Action, name string) *parser.Policy {
+		return &parser.Policy{Rules: []parser.Rule{{
+			Action: action,
+			And:    []parser.Criterion{{Name: parser.PolicyRefCriterionName, Data: parser.String(name)}},
+		}}}
+	}
+
+	t.Run("allow", func(t *testing.T) {
+		g := newTestGenerator(map[string]*parser.Policy{
+			"a": {Rules: []parser.Rule{{Action: parser.ActionAllow, Or: []parser.Criterion{{Name: "accept"}}}}},
+		})
+		allow, deny := evaluate(t, g, ref(parser.ActionAllow, "a"))
+		assert.Equal(t, []interface{}{true, []interface{}{"accept"}, map[string]interface{}{}}, allow)
+		assert.Equal(t, []interface{}{false, []interface{}{}}, deny)
+	})
+	t.Run("nested", func(t *testing.T) {
+		g := newTestGenerator(map[string]*parser.Policy{
+			"a": ref(parser.ActionAllow, "b"),
+			"b": {Rules: []parser.Rule{{Action: parser.ActionAllow, Or: []parser.Criterion{{Name: "accept"}}}}},
+		})
+		allow, _ := evaluate(t, g, ref(parser.ActionAllow, "a"))
+		assert.Equal(t, []interface{}{true, []interface{}{"accept"}, map[string]interface{}{}}, allow)
+	})
+	t.Run("deny takes precedence", func(t *testing.T) {
+		g := newTestGenerator(map[string]*parser.Policy{
+			"a": {Rules: []parser.Rule{
+				{Action: parser.ActionAllow, Or: []parser.Criterion{{Name: "accept"}}},
+				{Action: parser.ActionDeny, Or: []parser.Criterion{{Name: "accept"}}},
+			}},
+		})
+		allow, _ := evaluate(t, g, ref(parser.ActionAllow, "a"))
+		assert.Equal(t, []interface{}{false, []interface{}{"accept"}, map[string]interface{}{}}, allow)
+	})
+	t.Run("no match", func(t *testing.T) {
+		g := newTestGenerator(map[string]*parser.Policy{
+			"a": {Rules: []parser.Rule{
+				{Action: parser.ActionAllow, Or: []parser.Criterion{{Name: "reject"}}},
+				{Action: parser.ActionDeny, Or: []parser.Criterion{{Name: "reject"}}},
+			}},
+		})
+		allow, _ := evaluate(t, g, ref(parser.ActionAllow, "a"))
+		assert.Equal(t, []interface{}{false, []interface{}{"reject"}, map[string]interface{}{}}, allow)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		g := newTestGenerator(nil)
+		_, err := g.Generate(ref(parser.ActionAllow, "a"))
+		assert.EqualError(t, err, "unknown named policy in policy_ref: a")
+	})
+	t.Run("cycle", func(t *testing.T) {
+		g := newTestGenerator(map[string]*parser.Policy{
+			"a": ref(parser.ActionAllow, "b"),
+			"b": ref(parser.ActionDeny, "a"),
+		})
+		_, err := g.Generate(ref(parser.ActionAllow, "a"))
+		assert.EqualError(t, err, "cycle in policy_ref: a -> b -> a")
+	})
+}
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+// A Tree relates the rego rules generated for a policy back to the rules, logical operators and criteria of the
+// policy, so that the result of evaluating each generated rule can be explained.
+type Tree struct {
+	Rules []TreeRule
+}
+
+// A TreeRule describes the rego rules generated for a policy rule.
+type TreeRule struct {
+	Action    parser.Action
+	Operators []TreeOperator
+}
+
+// A TreeOperator describes the rego rule generated for a logical operator ("and", "or", "not" or "nor").
+type TreeOperator struct {
+	Operator string
+	RuleName string
+	Criteria []TreeCriterion
+}
+
+// A TreeCriterion describes the rego rule generated for a criterion.
+type TreeCriterion struct {
+	Criterion parser.Criterion
+	RuleName  string
+}
@@ -0,0 +1,115 @@
+package linter
+
+import (
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+// A result is what is statically known about whether a criterion, operator or rule matches.
+type result int
+
+const (
+	resultUnknown result = iota
+	resultAlways
+	resultNever
+)
+
+func (r result) invert() result {
+	switch r {
+	case resultAlways:
+		return resultNever
+	case resultNever:
+		return resultAlways
+	}
+	return resultUnknown
+}
+
+// getCriterionResult returns whether a criterion always or never matches. Only the accept and reject criteria are
+// known ahead of time.
+func getCriterionResult(c parser.Criterion) result {
+	switch c.Name {
+	case "accept":
+		return resultAlways
+	case "reject":
+		return resultNever
+	}
+	return resultUnknown
+}
+
+// combineAnd combines results when all of them must match.
+func combineAnd(results []result) result {
+	combined := resultAlways
+	for _, r := range results {
+		switch r {
+		case resultNever:
+			return resultNever
+		case resultUnknown:
+			combined = resultUnknown
+		}
+	}
+	return combined
+}
+
+// combineOr combines results when any of them must match.
+func combineOr(results []result) result {
+	combined := resultNever
+	for _, r := range results {
+		switch r {
+		case resultAlways:
+			return resultAlways
+		case resultUnknown:
+			combined = resultUnknown
+		}
+	}
+	return combined
+}
+
+// combineNot combines results when none of them must match.
+func combineNot(results []result) result {
+	return combineAnd(invertAll(results))
+}
+
+// combineNor combines results when any of them must not match.
+func combineNor(results []result) result {
+	return combineOr(invertAll(results))
+}
+
+func invertAll(results []result) []result {
+	inverted := make([]result, 0, len(results))
+	for _, r := range results {
+		inverted = append(inverted, r.invert())
+	}
+	return inverted
+}
+
+// multiValuedCriteria are string matcher criteria which match any one of several values, so requiring different
+// values at once is not a conflict.
+var multiValuedCriteria = map[string]bool{
+	"http_query": true,
+}
+
+// hasConflictingCriteria returns true if the criteria require the same single value to be equal to different
+// strings, e.g. `http_method: { is: GET }` and `http_method: { is: POST }`, which can never both match.
+func hasConflictingCriteria(cs []parser.Criterion) bool {
+	values := map[string]string{}
+	for _, c := range cs {
+		if multiValuedCriteria[c.Name] {
+			continue
+		}
+
+		obj, ok := c.Data.(parser.Object)
+		if !ok || len(obj) != 1 {
+			continue
+		}
+		is, ok := obj["is"].(parser.String)
+		if !ok {
+			continue
+		}
+
+		key := c.Name + "/" + c.SubPath
+		if v, ok := values[key]; ok && v != string(is) {
+			return true
+		}
+		values[key] = string(is)
+	}
+	return false
+}
@@ -0,0 +1,351 @@
+// Package linter checks Pomerium Policy Language policies for mistakes which would otherwise only surface at
+// runtime, like unknown criteria, invalid criterion data, rules which can never match and duplicate rules.
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+// A Severity indicates how serious a problem is.
+type Severity string
+
+// Severities
+const (
+	// SeverityError indicates the policy is invalid and cannot be used.
+	SeverityError Severity = "error"
+	// SeverityWarning indicates the policy is valid, but probably doesn't do what was intended.
+	SeverityWarning Severity = "warning"
+)
+
+// A Problem is a mistake found in a policy. Line and Column are only set when the policy was linted from source.
+type Problem struct {
+	Severity Severity
+	Line     int
+	Column   int
+	Message  string
+}
+
+// String converts the problem to a string.
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.Severity, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", p.Line, p.Column, p.Severity, p.Message)
+}
+
+// A Linter checks policies for mistakes.
+type Linter struct {
+	g           *generator.Generator
+	knownGroups map[string]struct{}
+}
+
+// An Option customizes the Linter.
+type Option func(*Linter)
+
+// WithKnownGroups sets the ids, names and emails of the groups in the directory. The groups criteria are checked for
+// groups which don't exist. Without known groups, groups criteria aren't checked, as the directory is only available
+// at runtime.
+func WithKnownGroups(groups []string) Option {
+	return func(l *Linter) {
+		l.knownGroups = make(map[string]struct{}, len(groups))
+		for _, group := range groups {
+			l.knownGroups[group] = struct{}{}
+		}
+	}
+}
+
+// New creates a new Linter. Criteria are looked up using the given generator.
+func New(g *generator.Generator, options ...Option) *Linter {
+	l := &Linter{g: g}
+	for _, o := range options {
+		o(l)
+	}
+	return l
+}
+
+// Lint checks a policy for mistakes.
+func (l *Linter) Lint(p *parser.Policy) []Problem {
+	return l.lint(p, nil)
+}
+
+// LintYAML parses a raw YAML or JSON policy and checks it for mistakes, with the position of each problem set. An
+// error is returned if the policy cannot be parsed.
+func (l *Linter) LintYAML(r io.Reader) ([]Problem, error) {
+	var node yaml.Node
+	err := yaml.NewDecoder(r).Decode(&node)
+	if err != nil {
+		return nil, err
+	}
+	return l.LintYAMLNode(&node)
+}
+
+// LintYAMLNode checks the policy in a YAML node for mistakes, with the position of each problem set. This can be used
+// to lint a policy embedded in a larger document.
+func (l *Linter) LintYAMLNode(node *yaml.Node) ([]Problem, error) {
+	var obj interface{}
+	err := node.Decode(&obj)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	p, err := parser.ParseJSON(bytes.NewReader(bs))
+	if err != nil {
+		return nil, err
+	}
+	return l.lint(p, indexPolicyNode(node)), nil
+}
+
+func (l *Linter) lint(p *parser.Policy, positions []rulePosition) []Problem {
+	var problems []Problem
+	report := func(pos position, severity Severity, format string, args ...interface{}) {
+		problems = append(problems, Problem{
+			Severity: severity,
+			Line:     pos.line,
+			Column:   pos.column,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	results := make([]result, len(p.Rules))
+	seen := map[string]int{}
+	for i, rule := range p.Rules {
+		rulePos := getRulePosition(positions, i)
+
+		var operators []result
+		for _, op := range []struct {
+			name     string
+			criteria []parser.Criterion
+			combine  func([]result) result
+		}{
+			{"and", rule.And, combineAnd},
+			{"or", rule.Or, combineOr},
+			{"not", rule.Not, combineNot},
+			{"nor", rule.Nor, combineNor},
+		} {
+			if len(op.criteria) == 0 {
+				continue
+			}
+
+			criterionResults := make([]result, 0, len(op.criteria))
+			criterionKeys := map[string]struct{}{}
+			for j, c := range op.criteria {
+				criterionPos := rulePos.getCriterionPosition(op.name, j)
+				key := c.String()
+				if _, ok := criterionKeys[key]; ok {
+					report(criterionPos, SeverityWarning, "duplicate criterion %s in %q", key, op.name)
+				}
+				criterionKeys[key] = struct{}{}
+
+				if err := l.checkCriterion(c); err != nil {
+					report(criterionPos, SeverityError, "%v", err)
+				} else {
+					for _, group := range l.getUnknownGroups(c) {
+						report(criterionPos, SeverityWarning, "%s criterion refers to unknown group %q", c.Name, group)
+					}
+				}
+				criterionResults = append(criterionResults, getCriterionResult(c))
+			}
+
+			r := op.combine(criterionResults)
+			if op.name == "and" && hasConflictingCriteria(op.criteria) {
+				report(rulePos.getOperatorPosition(op.name), SeverityWarning,
+					"%q requires a single value to be equal to different strings, so it can never match", op.name)
+				r = resultNever
+			}
+			operators = append(operators, r)
+		}
+
+		// a rule matches if any of its operators match
+		results[i] = combineOr(operators)
+		if len(operators) == 0 {
+			results[i] = resultNever
+		}
+
+		key := rule.String()
+		if j, ok := seen[key]; ok {
+			report(rulePos.position, SeverityWarning, "duplicate %s rule, the same as rule %d", rule.Action, j+1)
+		} else {
+			seen[key] = i
+		}
+	}
+
+	allowRules := 0
+	for _, rule := range p.Rules {
+		if rule.Action == parser.ActionAllow {
+			allowRules++
+		}
+	}
+	for i, rule := range p.Rules {
+		rulePos := getRulePosition(positions, i)
+		switch {
+		case results[i] == resultNever:
+			report(rulePos.position, SeverityWarning, "%s rule can never match", rule.Action)
+		case results[i] == resultAlways && rule.Action == parser.ActionDeny:
+			report(rulePos.position, SeverityWarning, "deny rule always matches, so every request is denied")
+		case results[i] == resultAlways && rule.Action == parser.ActionAllow && allowRules > 1:
+			report(rulePos.position, SeverityWarning, "allow rule always matches, so the other allow rules have no effect")
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+	return problems
+}
+
+// checkCriterion checks that the criterion is known and that its data is valid.
+func (l *Linter) checkCriterion(c parser.Criterion) error {
+	if c.Name == parser.PolicyRefCriterionName {
+		return l.checkPolicyRef(c, nil)
+	}
+
+	criterion, ok := l.g.GetCriterion(c.Name)
+	if !ok {
+		return fmt.Errorf("unknown criterion %q", c.Name)
+	}
+
+	matcherOperator := ""
+	switch criterion.DataType() {
+	case criteria.CriterionDataTypeStringMatcher:
+		matcherOperator = "is"
+	case criteria.CriterionDataTypeStringListMatcher:
+		matcherOperator = "has"
+	}
+	obj, ok := c.Data.(parser.Object)
+	if matcherOperator != "" && !ok {
+		return fmt.Errorf("%s criterion expects a %s object, got %s, e.g. { %s: %s }",
+			c.Name, criterion.DataType(), getValueTypeName(c.Data), matcherOperator, c.Data)
+	}
+	// a matcher without an operator would match nothing, or with only case_insensitive, anything
+	if matcherOperator != "" && !hasMatcherOperator(obj) {
+		return fmt.Errorf("%s criterion requires a %s operator, e.g. { %s: ... }",
+			c.Name, criterion.DataType(), matcherOperator)
+	}
+
+	if _, _, err := criterion.GenerateRule(c.SubPath, c.Data); err != nil {
+		// most criteria already name themselves in their errors
+		if strings.Contains(err.Error(), c.Name) {
+			return err
+		}
+		return fmt.Errorf("invalid %s criterion: %w", c.Name, err)
+	}
+	return nil
+}
+
+// checkPolicyRef checks that a policy_ref criterion refers to a known named policy, and that the named policy doesn't
+// refer back to itself.
+func (l *Linter) checkPolicyRef(c parser.Criterion, stack []string) error {
+	name, ok := c.Data.(parser.String)
+	if !ok {
+		return fmt.Errorf("%s criterion expects the name of a policy, got %s", c.Name, getValueTypeName(c.Data))
+	}
+	for _, ref := range stack {
+		if ref == string(name) {
+			return fmt.Errorf("cycle in %s: %s -> %s", c.Name, strings.Join(stack, " -> "), string(name))
+		}
+	}
+	p, ok := l.g.GetNamedPolicy(string(name))
+	if !ok {
+		return fmt.Errorf("unknown named policy %q", string(name))
+	}
+
+	stack = append(stack, string(name))
+	for _, ref := range p.PolicyRefs() {
+		err := l.checkPolicyRef(parser.Criterion{Name: c.Name, Data: parser.String(ref)}, stack)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getUnknownGroups returns the groups compared against by a groups criterion which aren't known groups.
+func (l *Linter) getUnknownGroups(c parser.Criterion) []string {
+	obj, ok := c.Data.(parser.Object)
+	if l.knownGroups == nil || c.Name != "groups" || !ok {
+		return nil
+	}
+
+	caseInsensitive := obj["case_insensitive"] == parser.Boolean(true)
+	known := l.knownGroups
+	if caseInsensitive {
+		known = make(map[string]struct{}, len(l.knownGroups))
+		for group := range l.knownGroups {
+			known[strings.ToLower(group)] = struct{}{}
+		}
+	}
+
+	var groups []string
+	for k, v := range obj {
+		var values parser.Array
+		switch strings.TrimPrefix(k, "not_") {
+		case "has":
+			values = parser.Array{v}
+		case "in":
+			values, _ = v.(parser.Array)
+		}
+		for _, value := range values {
+			if group, ok := value.(parser.String); ok {
+				groups = append(groups, string(group))
+			}
+		}
+	}
+	sort.Strings(groups)
+
+	var unknown []string
+	for _, group := range groups {
+		key := group
+		if caseInsensitive {
+			key = strings.ToLower(group)
+		}
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, group)
+		}
+	}
+	return unknown
+}
+
+// hasMatcherOperator returns true if a matcher object has an operator other than case_insensitive.
+func hasMatcherOperator(obj parser.Object) bool {
+	for k := range obj {
+		if k != "case_insensitive" {
+			return true
+		}
+	}
+	return false
+}
+
+func getValueTypeName(v parser.Value) string {
+	switch v.(type) {
+	case parser.Array:
+		return "an array"
+	case parser.Boolean:
+		return "a boolean"
+	case parser.Null:
+		return "null"
+	case parser.Number:
+		return "a number"
+	case parser.Object:
+		return "an object"
+	case parser.String:
+		return "a string"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,211 @@
+package linter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+func newTestLinter() *Linter {
+	var gOpts []generator.Option
+	for _, ctor := range criteria.All() {
+		gOpts = append(gOpts, generator.WithCriterion(ctor))
+	}
+	return New(generator.New(gOpts...))
+}
+
+func TestLinter(t *testing.T) {
+	l := newTestLinter()
+	lint := func(t *testing.T, src string) []string {
+		problems, err := l.LintYAML(strings.NewReader(src))
+		require.NoError(t, err)
+		var strs []string
+		for _, p := range problems {
+			strs = append(strs, p.String())
+		}
+		return strs
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, lint(t, `
+allow:
+  or:
+    - email:
+        is: user@example.com
+    - groups:
+        has: admins
+deny:
+  and:
+    - http_path:
+        starts_with: /admin
+`))
+	})
+	t.Run("unknown criterion", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`4:7: error: unknown criterion "emial"`,
+		}, lint(t, `
+allow:
+  or:
+    - emial:
+        is: user@example.com
+`))
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`4:7: error: email criterion expects a string_matcher object, got a string, e.g. { is: "user@example.com" }`,
+			`5:7: error: invalid day_of_week criterion: invalid day of week: someday`,
+			`6:7: error: groups criterion expects a string_list_matcher object, got a string, e.g. { has: "admins" }`,
+		}, lint(t, `
+allow:
+  or:
+    - email: user@example.com
+    - day_of_week: someday
+    - groups: admins
+`))
+	})
+	t.Run("matcher without an operator", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`4:7: error: email criterion requires a string_matcher operator, e.g. { is: ... }`,
+			`6:7: error: groups criterion requires a string_list_matcher operator, e.g. { has: ... }`,
+		}, lint(t, `
+allow:
+  or:
+    - email: {}
+    - user: { is: user-1 }
+    - groups:
+        case_insensitive: true
+`))
+	})
+	t.Run("never matches", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`2:3: warning: allow rule can never match`,
+			`3:5: warning: "and" requires a single value to be equal to different strings, so it can never match`,
+			`8:3: warning: allow rule can never match`,
+			`9:3: warning: deny rule can never match`,
+		}, lint(t, `
+- allow:
+    and:
+      - http_method:
+          is: GET
+      - http_method:
+          is: POST
+- allow: {}
+- deny:
+    not:
+      - accept: true
+`))
+	})
+	t.Run("always matches", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`2:3: warning: allow rule always matches, so the other allow rules have no effect`,
+			`7:3: warning: deny rule always matches, so every request is denied`,
+		}, lint(t, `
+- allow:
+    or:
+      - accept: true
+      - email:
+          is: user@example.com
+  deny:
+    nor:
+      - reject: true
+- allow:
+    and:
+      - domain:
+          is: example.com
+`))
+	})
+	t.Run("duplicates", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`5:9: warning: duplicate criterion {"domain":{"is":"example.com"}} in "or"`,
+			`7:3: warning: duplicate allow rule, the same as rule 1`,
+			`10:9: warning: duplicate criterion {"domain":{"is":"example.com"}} in "or"`,
+		}, lint(t, `
+- allow:
+    or:
+      - domain: { is: example.com }
+      - domain: { is: example.com }
+      - domain: { is: example.org }
+- allow:
+    or:
+      - domain: { is: example.com }
+      - domain: { is: example.com }
+      - domain: { is: example.org }
+`))
+	})
+	t.Run("unknown groups", func(t *testing.T) {
+		src := `
+allow:
+  or:
+    - groups:
+        has: admins
+    - groups:
+        not_has: Contractors
+        case_insensitive: true
+    - groups:
+        in: [admins, editors, auditors]
+    - email:
+        is: editors
+`
+		l := New(newTestLinter().g, WithKnownGroups([]string{"admins", "contractors", "group-id"}))
+		problems, err := l.LintYAML(strings.NewReader(src))
+		require.NoError(t, err)
+		var strs []string
+		for _, p := range problems {
+			strs = append(strs, p.String())
+		}
+		assert.Equal(t, []string{
+			`9:7: warning: groups criterion refers to unknown group "auditors"`,
+			`9:7: warning: groups criterion refers to unknown group "editors"`,
+		}, strs)
+
+		assert.Empty(t, lint(t, src), "groups should not be checked without known groups")
+	})
+	t.Run("without positions", func(t *testing.T) {
+		problems := l.Lint(&parser.Policy{Rules: []parser.Rule{{
+			Action: parser.ActionAllow,
+			And:    []parser.Criterion{{Name: "unknown"}},
+		}}})
+		assert.Equal(t, []Problem{{Severity: SeverityError, Message: `unknown criterion "unknown"`}}, problems)
+	})
+	t.Run("policy refs", func(t *testing.T) {
+		var gOpts []generator.Option
+		for _, ctor := range criteria.All() {
+			gOpts = append(gOpts, generator.WithCriterion(ctor))
+		}
+		gOpts = append(gOpts, generator.WithNamedPolicies(map[string]*parser.Policy{
+			"admins": {Rules: []parser.Rule{{
+				Action: parser.ActionAllow,
+				Or:     []parser.Criterion{{Name: "groups", Data: parser.Object{"has": parser.String("admins")}}},
+			}}},
+			"loop": {Rules: []parser.Rule{{
+				Action: parser.ActionAllow,
+				Or:     []parser.Criterion{{Name: parser.PolicyRefCriterionName, Data: parser.String("loop")}},
+			}}},
+		}))
+		l := New(generator.New(gOpts...))
+		problems, err := l.LintYAML(strings.NewReader(`
+allow:
+  or:
+    - policy_ref: admins
+    - policy_ref: editors
+    - policy_ref: loop
+    - policy_ref: { is: admins }
+`))
+		require.NoError(t, err)
+		var strs []string
+		for _, p := range problems {
+			strs = append(strs, p.String())
+		}
+		assert.Equal(t, []string{
+			`5:7: error: unknown named policy "editors"`,
+			`6:7: error: cycle in policy_ref: loop -> loop`,
+			`7:7: error: policy_ref criterion expects the name of a policy, got an object`,
+		}, strs)
+	})
+}
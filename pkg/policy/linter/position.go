@@ -0,0 +1,113 @@
+package linter
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+// A position is a line and column in the policy source.
+type position struct {
+	line, column int
+}
+
+// A rulePosition is the position of a rule and of its operators and criteria.
+type rulePosition struct {
+	position
+	operators map[string]position
+	criteria  map[string][]position
+}
+
+func (rp rulePosition) getOperatorPosition(operator string) position {
+	if pos, ok := rp.operators[operator]; ok {
+		return pos
+	}
+	return rp.position
+}
+
+func (rp rulePosition) getCriterionPosition(operator string, idx int) position {
+	if idx < len(rp.criteria[operator]) {
+		return rp.criteria[operator][idx]
+	}
+	return rp.getOperatorPosition(operator)
+}
+
+func getRulePosition(positions []rulePosition, idx int) rulePosition {
+	if idx < len(positions) {
+		return positions[idx]
+	}
+	return rulePosition{}
+}
+
+// indexPolicyNode returns the position of every rule in a YAML policy, in the same order as the rules are returned
+// by the parser.
+func indexPolicyNode(node *yaml.Node) []rulePosition {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var positions []rulePosition
+		for _, item := range node.Content {
+			positions = append(positions, indexRulesNode(item)...)
+		}
+		return positions
+	case yaml.MappingNode:
+		return indexRulesNode(node)
+	}
+	return nil
+}
+
+// indexRulesNode returns the position of every rule in an object of actions. As in the parser, the rules are sorted
+// by action.
+func indexRulesNode(node *yaml.Node) []rulePosition {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	type actionNode struct {
+		action    parser.Action
+		key, rule *yaml.Node
+	}
+	var actions []actionNode
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		actions = append(actions, actionNode{
+			action: parser.Action(node.Content[i].Value),
+			key:    node.Content[i],
+			rule:   node.Content[i+1],
+		})
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].action < actions[j].action
+	})
+
+	positions := make([]rulePosition, 0, len(actions))
+	for _, a := range actions {
+		rp := rulePosition{
+			position:  getNodePosition(a.key),
+			operators: map[string]position{},
+			criteria:  map[string][]position{},
+		}
+		for i := 0; a.rule.Kind == yaml.MappingNode && i+1 < len(a.rule.Content); i += 2 {
+			operator, criteria := a.rule.Content[i], a.rule.Content[i+1]
+			rp.operators[operator.Value] = getNodePosition(operator)
+			for _, criterion := range criteria.Content {
+				pos := getNodePosition(criterion)
+				// use the position of the criterion name
+				if criterion.Kind == yaml.MappingNode && len(criterion.Content) > 0 {
+					pos = getNodePosition(criterion.Content[0])
+				}
+				rp.criteria[operator.Value] = append(rp.criteria[operator.Value], pos)
+			}
+		}
+		positions = append(positions, rp)
+	}
+	return positions
+}
+
+func getNodePosition(node *yaml.Node) position {
+	return position{line: node.Line, column: node.Column}
+}
@@ -45,6 +45,29 @@ func PolicyFromValue(v Value) (*Policy, error) {
 	}, nil
 }
 
+// PolicyRefs returns the names of the named policies referenced by the policy's policy_ref criteria, in the order
+// they are first referenced.
+func (p *Policy) PolicyRefs() []string {
+	var names []string
+	seen := map[string]struct{}{}
+	for _, r := range p.Rules {
+		for _, criteria := range [][]Criterion{r.And, r.Or, r.Not, r.Nor} {
+			for _, c := range criteria {
+				name, ok := c.Data.(String)
+				if c.Name != PolicyRefCriterionName || !ok {
+					continue
+				}
+				if _, ok := seen[string(name)]; ok {
+					continue
+				}
+				seen[string(name)] = struct{}{}
+				names = append(names, string(name))
+			}
+		}
+	}
+	return names
+}
+
 // A Rule is a policy rule with a corresponding action ("allow" or "deny"),
 // and conditionals to determine if the rule matches or not.
 type Rule struct {
@@ -204,6 +227,10 @@ type Criterion struct {
 	Data    Value
 }
 
+// PolicyRefCriterionName is the name of the criterion which references a named policy by name, e.g.
+// `policy_ref: engineering`. It matches if the named policy allows the request.
+const PolicyRefCriterionName = "policy_ref"
+
 // CriteriaFromValue converts a Value into Criteria. Only Arrays are supported.
 func CriteriaFromValue(v Value) ([]Criterion, error) {
 	switch t := v.(type) {
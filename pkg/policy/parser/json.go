@@ -319,3 +319,8 @@ func (Null) RegoValue() ast.Value {
 func (Null) String() string {
 	return "null"
 }
+
+// MarshalJSON marshals the null as JSON.
+func (Null) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
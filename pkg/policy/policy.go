@@ -8,6 +8,7 @@ import (
 
 	"github.com/pomerium/pomerium/pkg/policy/criteria"
 	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/linter"
 	"github.com/pomerium/pomerium/pkg/policy/parser"
 )
 
@@ -17,8 +18,17 @@ type (
 	Criterion = generator.Criterion
 	// A CriterionConstructor is a function which returns a Criterion for a Generator.
 	CriterionConstructor = generator.CriterionConstructor
+	// A Tree relates the rego rules generated for a policy back to the policy.
+	Tree = generator.Tree
+	// An Option customizes the generation of rego from a policy.
+	Option = generator.Option
 )
 
+// WithNamedPolicies sets the named policies which can be referenced by the policy_ref criterion.
+func WithNamedPolicies(namedPolicies map[string]*parser.Policy) Option {
+	return generator.WithNamedPolicies(namedPolicies)
+}
+
 // GenerateRegoFromReader generates a rego script from raw Pomerium Policy Language.
 func GenerateRegoFromReader(r io.Reader) (string, error) {
 	ppl, err := parser.ParseYAML(r)
@@ -30,21 +40,37 @@ func GenerateRegoFromReader(r io.Reader) (string, error) {
 
 // GenerateRegoFromPolicy generates a rego script from a Pomerium Policy Language policy.
 func GenerateRegoFromPolicy(p *parser.Policy) (string, error) {
-	var gOpts []generator.Option
-	for _, ctor := range criteria.All() {
-		gOpts = append(gOpts, generator.WithCriterion(ctor))
-	}
-	g := generator.New(gOpts...)
+	script, _, err := GenerateRegoAndTreeFromPolicy(p)
+	return script, err
+}
 
-	mod, err := g.Generate(p)
+// GenerateRegoAndTreeFromPolicy generates a rego script from a Pomerium Policy Language policy, along with a Tree
+// relating the generated rego rules back to the policy.
+func GenerateRegoAndTreeFromPolicy(p *parser.Policy, options ...Option) (string, *Tree, error) {
+	mod, tree, err := NewGenerator(options...).GenerateWithTree(p)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	bs, err := format.Ast(mod)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return string(bs), err
+	return string(bs), tree, err
+}
+
+// NewLinter creates a new Linter for Pomerium Policy Language policies which knows all the pre-defined criteria.
+func NewLinter(options ...Option) *linter.Linter {
+	return linter.New(NewGenerator(options...))
+}
+
+// NewGenerator creates a new Generator which knows all the pre-defined criteria.
+func NewGenerator(options ...Option) *generator.Generator {
+	var gOpts []generator.Option
+	for _, ctor := range criteria.All() {
+		gOpts = append(gOpts, generator.WithCriterion(ctor))
+	}
+	gOpts = append(gOpts, options...)
+	return generator.New(gOpts...)
 }
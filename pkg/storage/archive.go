@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+const importBatchSize = 64
+
+// Export calls fn with the options of every record type and the latest version of every record matching the filter.
+// The options of a record type are exported before any of its records. Options are exported for the types of the
+// exported records, and for every type matching the filter with options set, even if it has no records.
+func Export(ctx context.Context, backend Backend, filter SyncFilter, fn func(entry *databroker.ArchiveEntry) error) error {
+	all, _, err := backend.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	allOptions, err := backend.GetAllOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var recordTypes []string
+	seen := map[string]struct{}{}
+	addType := func(recordType string) {
+		if _, ok := seen[recordType]; !ok {
+			seen[recordType] = struct{}{}
+			recordTypes = append(recordTypes, recordType)
+		}
+	}
+
+	var records []*databroker.Record
+	for _, record := range all {
+		if !filter.Matches(record) {
+			continue
+		}
+		records = append(records, record)
+		addType(record.GetType())
+	}
+
+	var optionTypes []string
+	for recordType := range allOptions {
+		if len(filter.Types) == 0 || containsString(filter.Types, recordType) {
+			optionTypes = append(optionTypes, recordType)
+		}
+	}
+	sort.Strings(optionTypes)
+	for _, recordType := range optionTypes {
+		addType(recordType)
+	}
+
+	for _, recordType := range recordTypes {
+		options, err := backend.GetOptions(ctx, recordType)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&databroker.ArchiveEntry{
+			Entry: &databroker.ArchiveEntry_Options{
+				Options: &databroker.ArchiveOptions{
+					Type:    recordType,
+					Options: options,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, record := range records {
+		err = fn(&databroker.ArchiveEntry{
+			Entry: &databroker.ArchiveEntry_Record{
+				Record: record,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Import restores the options and records returned by next, which should return io.EOF once there are no more
+// entries. Records keep their ids, types and modification times, but are assigned new versions. The number of
+// imported records is returned.
+func Import(
+	ctx context.Context,
+	backend Backend,
+	next func() (*databroker.ArchiveEntry, error),
+) (serverVersion, recordCount uint64, err error) {
+	var batch []*databroker.Record
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		serverVersion, err = backend.PutMany(ctx, batch, PutOptions{KeepModifiedAt: true})
+		if err != nil {
+			return fmt.Errorf("error importing records: %w", err)
+		}
+		recordCount += uint64(len(batch))
+		batch = nil
+		return nil
+	}
+
+	for {
+		entry, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return serverVersion, recordCount, err
+		}
+
+		switch entry := entry.GetEntry().(type) {
+		case *databroker.ArchiveEntry_Options:
+			// the options may affect how the records are stored, so write any records before them first
+			err = flush()
+			if err != nil {
+				return serverVersion, recordCount, err
+			}
+
+			options := entry.Options.GetOptions()
+			if options == nil {
+				options = new(databroker.Options)
+			}
+
+			err = backend.SetOptions(ctx, entry.Options.GetType(), options)
+			if err != nil {
+				return serverVersion, recordCount, fmt.Errorf("error importing options: %w", err)
+			}
+		case *databroker.ArchiveEntry_Record:
+			record := entry.Record
+			record.Version = 0
+			record.DeletedAt = nil
+			batch = append(batch, record)
+
+			if len(batch) >= importBatchSize {
+				err = flush()
+				if err != nil {
+					return serverVersion, recordCount, err
+				}
+			}
+		}
+	}
+
+	err = flush()
+	return serverVersion, recordCount, err
+}
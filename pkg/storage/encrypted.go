@@ -2,13 +2,17 @@ package storage
 
 import (
 	"context"
-	"crypto/cipher"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/protoutil"
@@ -47,25 +51,45 @@ func (e *encryptedRecordStream) Err() error {
 	return e.err
 }
 
+const (
+	reencryptLeaseName = "pomerium/databroker-reencrypt"
+	reencryptLeaseTTL  = time.Minute
+	reencryptInterval  = time.Hour
+	// the lease is renewed after this many records have been re-encrypted
+	reencryptRenewCount = 64
+)
+
 type encryptedBackend struct {
 	underlying Backend
-	cipher     cipher.AEAD
+	keyring    *cryptutil.Keyring
+
+	closeOnce sync.Once
+	closed    chan struct{}
 }
 
-// NewEncryptedBackend creates a new encrypted backend.
-func NewEncryptedBackend(secret []byte, underlying Backend) (Backend, error) {
-	c, err := cryptutil.NewAEADCipher(secret)
+// NewEncryptedBackend creates a new encrypted backend. Records are encrypted with the secret. Records encrypted with
+// any of the decryption keys can still be read, and are re-encrypted with the secret in the background.
+func NewEncryptedBackend(secret []byte, underlying Backend, decryptionKeys ...[]byte) (Backend, error) {
+	keyring, err := cryptutil.NewKeyring(secret, decryptionKeys...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &encryptedBackend{
+	e := &encryptedBackend{
 		underlying: underlying,
-		cipher:     c,
-	}, nil
+		keyring:    keyring,
+		closed:     make(chan struct{}),
+	}
+	if keyring.Len() > 1 {
+		go e.runReencryption(context.Background())
+	}
+	return e, nil
 }
 
 func (e *encryptedBackend) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closed)
+	})
 	return e.underlying.Close()
 }
 
@@ -95,10 +119,29 @@ func (e *encryptedBackend) GetAll(ctx context.Context) ([]*databroker.Record, *d
 	return records, versions, nil
 }
 
+func (e *encryptedBackend) GetAllOfType(ctx context.Context, recordType, idPrefix string) ([]*databroker.Record, error) {
+	// ids aren't encrypted, so the prefix can be matched by the underlying backend
+	records, err := e.underlying.GetAllOfType(ctx, recordType, idPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		records[i], err = e.decryptRecord(records[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
 func (e *encryptedBackend) GetOptions(ctx context.Context, recordType string) (*databroker.Options, error) {
 	return e.underlying.GetOptions(ctx, recordType)
 }
 
+func (e *encryptedBackend) GetAllOptions(ctx context.Context) (map[string]*databroker.Options, error) {
+	return e.underlying.GetAllOptions(ctx)
+}
+
 func (e *encryptedBackend) Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
 	return e.underlying.Lease(ctx, leaseName, leaseID, ttl)
 }
@@ -123,12 +166,38 @@ func (e *encryptedBackend) Put(ctx context.Context, record *databroker.Record) (
 	return serverVersion, nil
 }
 
+func (e *encryptedBackend) PutMany(ctx context.Context, records []*databroker.Record, options PutOptions) (uint64, error) {
+	newRecords := make([]*databroker.Record, len(records))
+	for i, record := range records {
+		encrypted, err := e.encrypt(record.GetData())
+		if err != nil {
+			return 0, err
+		}
+
+		newRecords[i] = proto.Clone(record).(*databroker.Record)
+		newRecords[i].Data = encrypted
+	}
+
+	serverVersion, err := e.underlying.PutMany(ctx, newRecords, options)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, record := range records {
+		record.ModifiedAt = newRecords[i].ModifiedAt
+		record.Version = newRecords[i].Version
+	}
+
+	return serverVersion, nil
+}
+
 func (e *encryptedBackend) SetOptions(ctx context.Context, recordType string, options *databroker.Options) error {
 	return e.underlying.SetOptions(ctx, recordType, options)
 }
 
-func (e *encryptedBackend) Sync(ctx context.Context, serverVersion, recordVersion uint64) (RecordStream, error) {
-	stream, err := e.underlying.Sync(ctx, serverVersion, recordVersion)
+func (e *encryptedBackend) Sync(ctx context.Context, serverVersion, recordVersion uint64, filter SyncFilter) (RecordStream, error) {
+	// types and ids aren't encrypted, so the underlying backend can filter them
+	stream, err := e.underlying.Sync(ctx, serverVersion, recordVersion, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +219,7 @@ func (e *encryptedBackend) decryptRecord(in *databroker.Record) (out *databroker
 		Id:         in.Id,
 		Data:       data,
 		ModifiedAt: in.ModifiedAt,
+		ExpiresAt:  in.ExpiresAt,
 		DeletedAt:  in.DeletedAt,
 	}, nil
 }
@@ -165,7 +235,7 @@ func (e *encryptedBackend) decrypt(in *anypb.Any) (out *anypb.Any, err error) {
 		return nil, err
 	}
 
-	plaintext, err := cryptutil.Decrypt(e.cipher, encrypted.Value, nil)
+	plaintext, err := e.keyring.Decrypt(encrypted.Value)
 	if err != nil {
 		return nil, err
 	}
@@ -185,9 +255,106 @@ func (e *encryptedBackend) encrypt(in *anypb.Any) (out *anypb.Any, err error) {
 		return nil, err
 	}
 
-	encrypted := cryptutil.Encrypt(e.cipher, plaintext, nil)
+	encrypted := e.keyring.Encrypt(plaintext)
 	out = protoutil.NewAny(&wrapperspb.BytesValue{
 		Value: encrypted,
 	})
 	return out, nil
 }
+
+// isActive returns true if the data was encrypted with the active key.
+func (e *encryptedBackend) isActive(in *anypb.Any) bool {
+	var encrypted wrapperspb.BytesValue
+	err := in.UnmarshalTo(&encrypted)
+	if err != nil {
+		return false
+	}
+	return e.keyring.IsActive(encrypted.Value)
+}
+
+func (e *encryptedBackend) runReencryption(ctx context.Context) {
+	ticker := time.NewTicker(reencryptInterval)
+	defer ticker.Stop()
+
+	for {
+		err := e.reencrypt(ctx)
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("storage: error re-encrypting records")
+		}
+
+		select {
+		case <-e.closed:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reencrypt re-encrypts every record which wasn't encrypted with the active key. A lease is used so only one
+// instance re-encrypts records at a time.
+func (e *encryptedBackend) reencrypt(ctx context.Context) error {
+	leaseID := uuid.NewString()
+	acquired, err := e.underlying.Lease(ctx, reencryptLeaseName, leaseID, reencryptLeaseTTL)
+	if err != nil {
+		return err
+	} else if !acquired {
+		return nil
+	}
+	defer func() { _, _ = e.underlying.Lease(ctx, reencryptLeaseName, leaseID, 0) }()
+
+	records, _, err := e.underlying.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var count int
+	for _, record := range records {
+		if record.GetData() == nil || e.isActive(record.GetData()) {
+			continue
+		}
+
+		select {
+		case <-e.closed:
+			return nil
+		default:
+		}
+
+		data, err := e.decrypt(record.GetData())
+		if err != nil {
+			log.Warn(ctx).Err(err).
+				Str("type", record.GetType()).
+				Str("id", record.GetId()).
+				Msg("storage: unable to decrypt record for re-encryption")
+			continue
+		}
+
+		record.Data, err = e.encrypt(data)
+		if err != nil {
+			return err
+		}
+
+		// only re-encrypt the record if it hasn't changed in the meantime
+		_, err = e.underlying.PutMany(ctx, []*databroker.Record{record}, PutOptions{
+			CheckVersions:  true,
+			KeepModifiedAt: true,
+		})
+		if err != nil && !errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+
+		count++
+		if count%reencryptRenewCount == 0 {
+			acquired, err = e.underlying.Lease(ctx, reencryptLeaseName, leaseID, reencryptLeaseTTL)
+			if err != nil {
+				return err
+			} else if !acquired {
+				return fmt.Errorf("storage: lost re-encryption lease")
+			}
+		}
+	}
+
+	if count > 0 {
+		log.Info(ctx).Int("count", count).Msg("storage: re-encrypted records")
+	}
+	return nil
+}
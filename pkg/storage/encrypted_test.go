@@ -3,9 +3,14 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -93,3 +98,118 @@ func TestEncryptedBackend(t *testing.T) {
 		assert.Equal(t, any.TypeUrl, records[0].Type, "record type should be preserved")
 	}
 }
+
+func TestEncryptedBackendKeyRotation(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	m := map[string]*databroker.Record{}
+	leaseHolder := ""
+	backend := &mockBackend{
+		put: func(ctx context.Context, record *databroker.Record) (uint64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			record.ModifiedAt = timestamppb.Now()
+			record.Version = uint64(len(m) + 1)
+			m[record.GetId()] = proto.Clone(record).(*databroker.Record)
+			return 0, nil
+		},
+		putMany: func(ctx context.Context, records []*databroker.Record, options PutOptions) (uint64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, record := range records {
+				if options.CheckVersions && m[record.GetId()].GetVersion() != record.GetVersion() {
+					return 0, ErrVersionConflict
+				}
+				record.Version++
+				m[record.GetId()] = proto.Clone(record).(*databroker.Record)
+			}
+			return 0, nil
+		},
+		get: func(ctx context.Context, recordType, id string) (*databroker.Record, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			record, ok := m[id]
+			if !ok {
+				return nil, ErrNotFound
+			}
+			return proto.Clone(record).(*databroker.Record), nil
+		},
+		getAll: func(ctx context.Context) ([]*databroker.Record, *databroker.Versions, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			var records []*databroker.Record
+			for _, record := range m {
+				records = append(records, proto.Clone(record).(*databroker.Record))
+			}
+			return records, &databroker.Versions{}, nil
+		},
+		lease: func(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case ttl <= 0 && leaseHolder == leaseID:
+				leaseHolder = ""
+				return false, nil
+			case leaseHolder == "" || leaseHolder == leaseID:
+				leaseHolder = leaseID
+				return true, nil
+			}
+			return false, nil
+		},
+	}
+
+	oldKey, newKey := cryptutil.NewKey(), cryptutil.NewKey()
+
+	old, err := NewEncryptedBackend(oldKey, backend)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = old.Put(ctx, &databroker.Record{
+			Id:   fmt.Sprint(i),
+			Data: protoutil.NewAny(wrapperspb.String(fmt.Sprint("VALUE-", i))),
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = NewEncryptedBackend(newKey, backend)
+	require.NoError(t, err)
+	record, err := backend.Get(ctx, "", "0")
+	require.NoError(t, err)
+	modifiedAt := record.GetModifiedAt()
+
+	e, err := NewEncryptedBackend(newKey, backend, oldKey)
+	require.NoError(t, err)
+	defer func() { _ = e.Close() }()
+
+	record, err = e.Get(ctx, "", "0")
+	require.NoError(t, err, "should decrypt records encrypted with a decryption key")
+	assert.True(t, proto.Equal(wrapperspb.String("VALUE-0"), mustUnmarshalAny(t, record.GetData())))
+
+	assert.Eventually(t, func() bool {
+		records, _, _ := backend.GetAll(ctx)
+		for _, record := range records {
+			if !e.(*encryptedBackend).isActive(record.GetData()) {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond, "should re-encrypt every record with the active key")
+
+	onlyNew, err := NewEncryptedBackend(newKey, backend)
+	require.NoError(t, err)
+	record, err = onlyNew.Get(ctx, "", "0")
+	require.NoError(t, err, "should decrypt re-encrypted records with the new key")
+	assert.True(t, proto.Equal(wrapperspb.String("VALUE-0"), mustUnmarshalAny(t, record.GetData())))
+	assert.True(t, proto.Equal(modifiedAt, record.GetModifiedAt()), "should keep the modification time")
+
+	_, err = old.Get(ctx, "", "0")
+	assert.Error(t, err, "should not decrypt re-encrypted records with the old key")
+}
+
+func mustUnmarshalAny(t *testing.T, any *anypb.Any) proto.Message {
+	t.Helper()
+
+	msg, err := any.UnmarshalNew()
+	require.NoError(t, err)
+	return msg
+}
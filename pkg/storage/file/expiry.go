@@ -0,0 +1,59 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+// the file backend is meant for small deployments, so expired records are found by scanning every record
+const recordExpiryInterval = 10 * time.Second
+
+// removeExpiredRecords deletes every record which expired before now.
+func (backend *Backend) removeExpiredRecords(ctx context.Context, now time.Time) {
+	var expired []*databroker.Record
+	err := backend.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(recordsBucket).ForEach(func(recordType, _ []byte) error {
+			options, err := getOptions(tx, string(recordType))
+			if err != nil {
+				return err
+			}
+
+			return tx.Bucket(recordsBucket).Bucket(recordType).ForEach(func(_, raw []byte) error {
+				record, err := decodeRecord(raw)
+				if err != nil {
+					log.Warn(ctx).Err(err).Msg("file: invalid record detected")
+					return nil
+				}
+
+				if expiresAt, ok := storage.GetRecordExpiry(record, options); ok && !expiresAt.After(now) {
+					expired = append(expired, record)
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("file: error finding expired records")
+		return
+	}
+
+	for _, record := range expired {
+		deleted := proto.Clone(record).(*databroker.Record)
+		deleted.DeletedAt = timestamppb.New(now)
+
+		// only delete the record if it hasn't changed since it was checked
+		_, err = backend.PutMany(ctx, []*databroker.Record{deleted}, storage.PutOptions{CheckVersions: true})
+		if err != nil && !errors.Is(err, storage.ErrVersionConflict) {
+			log.Error(ctx).Err(err).Msg("file: error removing expired record")
+		}
+	}
+}
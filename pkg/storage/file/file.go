@@ -0,0 +1,619 @@
+// Package file implements the storage.Backend interface using a single local file.
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/signal"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+var (
+	metaBucket    = []byte("meta")
+	recordsBucket = []byte("records")
+	orderBucket   = []byte("order")
+	changesBucket = []byte("changes")
+	optionsBucket = []byte("options")
+
+	serverVersionKey       = []byte("server_version")
+	latestRecordVersionKey = []byte("latest_record_version")
+)
+
+type lease struct {
+	id     string
+	expiry time.Time
+}
+
+// Backend implements the storage.Backend on top of a bbolt database file.
+//
+// What's stored:
+//
+// - meta: the server version and the latest record version. Both survive restarts, so existing Sync clients can
+//   resume after a reboot.
+// - records.{recordType}: the latest version of every record. The key is the record id, the value the protobuf record.
+// - order.{recordType}: the record ids in insertion order. The key is the record version, the value the record id.
+// - changes: every change made to a record. The key is the record version, the value the protobuf record.
+// - options: the options for a record type. The key is the record type, the value the protobuf options.
+//
+// Leases are only held in memory, since only a single process can open the database file.
+type Backend struct {
+	cfg *config
+
+	db       *bolt.DB
+	onChange *signal.Signal
+
+	mu     sync.Mutex
+	leases map[string]*lease
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// New creates a new file storage backend.
+func New(path string, options ...Option) (*Backend, error) {
+	cfg := getConfig(options...)
+
+	if path == "" {
+		return nil, fmt.Errorf("file: path is required")
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("file: error creating directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: cfg.openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("file: error opening database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{metaBucket, recordsBucket, orderBucket, changesBucket, optionsBucket} {
+			_, err := tx.CreateBucketIfNotExists(name)
+			if err != nil {
+				return err
+			}
+		}
+
+		meta := tx.Bucket(metaBucket)
+		if meta.Get(serverVersionKey) == nil {
+			return meta.Put(serverVersionKey, encodeVersion(cryptutil.NewRandomUInt64()))
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("file: error initializing database: %w", err)
+	}
+
+	backend := &Backend{
+		cfg:      cfg,
+		db:       db,
+		onChange: signal.New(),
+		leases:   make(map[string]*lease),
+		closed:   make(chan struct{}),
+	}
+	if cfg.expiry != 0 {
+		go func() {
+			ctx := context.Background()
+			ticker := time.NewTicker(time.Minute)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-backend.closed:
+					return
+				case <-ticker.C:
+				}
+
+				backend.removeChangesBefore(ctx, time.Now().Add(-cfg.expiry))
+			}
+		}()
+	}
+	go func() {
+		ctx := context.Background()
+		ticker := time.NewTicker(recordExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-backend.closed:
+				return
+			case <-ticker.C:
+			}
+
+			backend.removeExpiredRecords(ctx, time.Now())
+		}
+	}()
+	return backend, nil
+}
+
+// Close closes the database file.
+func (backend *Backend) Close() error {
+	var err error
+	backend.closeOnce.Do(func() {
+		close(backend.closed)
+		err = backend.db.Close()
+	})
+	return err
+}
+
+// Get gets a record from the database file.
+func (backend *Backend) Get(ctx context.Context, recordType, id string) (record *databroker.Record, err error) {
+	_, span := trace.StartSpan(ctx, "databroker.file.Get")
+	defer span.End()
+
+	err = backend.db.View(func(tx *bolt.Tx) error {
+		records := tx.Bucket(recordsBucket).Bucket([]byte(recordType))
+		if records == nil {
+			return storage.ErrNotFound
+		}
+
+		raw := records.Get([]byte(id))
+		if raw == nil {
+			return storage.ErrNotFound
+		}
+
+		record, err = decodeRecord(raw)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetAll gets all the records from the database file.
+func (backend *Backend) GetAll(ctx context.Context) (records []*databroker.Record, versions *databroker.Versions, err error) {
+	_, span := trace.StartSpan(ctx, "databroker.file.GetAll")
+	defer span.End()
+
+	err = backend.db.View(func(tx *bolt.Tx) error {
+		versions = getVersions(tx)
+
+		return tx.Bucket(orderBucket).ForEach(func(recordType, _ []byte) error {
+			records = append(records, getRecordsOfType(ctx, tx, recordType, nil)...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, versions, nil
+}
+
+// GetAllOfType gets all the records of the given type whose id starts with idPrefix from the database file.
+func (backend *Backend) GetAllOfType(ctx context.Context, recordType, idPrefix string) (records []*databroker.Record, err error) {
+	_, span := trace.StartSpan(ctx, "databroker.file.GetAllOfType")
+	defer span.End()
+
+	err = backend.db.View(func(tx *bolt.Tx) error {
+		records = getRecordsOfType(ctx, tx, []byte(recordType), []byte(idPrefix))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetOptions gets the options for the given record type.
+func (backend *Backend) GetOptions(ctx context.Context, recordType string) (options *databroker.Options, err error) {
+	err = backend.db.View(func(tx *bolt.Tx) error {
+		options, err = getOptions(tx, recordType)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// GetAllOptions gets the options of every record type with options set.
+func (backend *Backend) GetAllOptions(ctx context.Context) (map[string]*databroker.Options, error) {
+	all := map[string]*databroker.Options{}
+	err := backend.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(optionsBucket).ForEach(func(k, v []byte) error {
+			options := new(databroker.Options)
+			if err := proto.Unmarshal(v, options); err != nil {
+				return err
+			}
+			all[string(k)] = options
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Lease acquires or renews a lease.
+func (backend *Backend) Lease(_ context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	l, ok := backend.leases[leaseName]
+	// if there is no lease, or its expired, acquire a new one.
+	if !ok || l.expiry.Before(time.Now()) {
+		if ttl <= 0 {
+			return false, nil
+		}
+		backend.leases[leaseName] = &lease{
+			id:     leaseID,
+			expiry: time.Now().Add(ttl),
+		}
+		return true, nil
+	}
+
+	// if the lease doesn't match, we can't acquire it
+	if l.id != leaseID {
+		return false, nil
+	}
+
+	// release the lease
+	if ttl <= 0 {
+		delete(backend.leases, leaseName)
+		return false, nil
+	}
+
+	// update the expiry (renew the lease)
+	l.expiry = time.Now().Add(ttl)
+	return true, nil
+}
+
+// Put puts a record into the database file.
+func (backend *Backend) Put(ctx context.Context, record *databroker.Record) (serverVersion uint64, err error) {
+	return backend.PutMany(ctx, []*databroker.Record{record}, storage.PutOptions{})
+}
+
+// PutMany puts multiple records into the database file in a single transaction.
+func (backend *Backend) PutMany(ctx context.Context, records []*databroker.Record, options storage.PutOptions) (serverVersion uint64, err error) {
+	_, span := trace.StartSpan(ctx, "databroker.file.PutMany")
+	defer span.End()
+
+	for _, record := range records {
+		if record == nil {
+			return 0, fmt.Errorf("records cannot be nil")
+		}
+	}
+
+	err = backend.db.Update(func(tx *bolt.Tx) error {
+		versions := getVersions(tx)
+		serverVersion = versions.GetServerVersion()
+
+		if options.CheckVersions {
+			err := storage.CheckVersions(records, versions.GetLatestRecordVersion()+1, func(recordType, recordID string) (uint64, error) {
+				return getRecordVersion(tx, recordType, recordID)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		now := timestamppb.Now()
+		recordTypes := map[string]struct{}{}
+		for _, record := range records {
+			err := putRecord(tx, record, options.GetModifiedAt(record, now))
+			if err != nil {
+				return err
+			}
+			recordTypes[record.GetType()] = struct{}{}
+		}
+
+		for recordType := range recordTypes {
+			err := enforceOptions(tx, recordType)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return serverVersion, err
+	}
+
+	backend.onChange.Broadcast(ctx)
+	return serverVersion, nil
+}
+
+// SetOptions sets the options for the given record type.
+func (backend *Backend) SetOptions(ctx context.Context, recordType string, options *databroker.Options) error {
+	_, span := trace.StartSpan(ctx, "databroker.file.SetOptions")
+	defer span.End()
+
+	bs, err := proto.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	err = backend.db.Update(func(tx *bolt.Tx) error {
+		err := tx.Bucket(optionsBucket).Put([]byte(recordType), bs)
+		if err != nil {
+			return err
+		}
+
+		// possibly re-enforce options
+		return enforceOptions(tx, recordType)
+	})
+	if err != nil {
+		return err
+	}
+
+	backend.onChange.Broadcast(ctx)
+	return nil
+}
+
+// Sync returns a record stream of any records changed after the specified recordVersion.
+func (backend *Backend) Sync(ctx context.Context, serverVersion, recordVersion uint64, filter storage.SyncFilter) (storage.RecordStream, error) {
+	var current uint64
+	err := backend.db.View(func(tx *bolt.Tx) error {
+		current = getVersions(tx).GetServerVersion()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if serverVersion != current {
+		return nil, storage.ErrInvalidServerVersion
+	}
+
+	return newRecordStream(ctx, backend, recordVersion, filter), nil
+}
+
+// getSince returns the changes after version which match the filter, along with the latest version checked.
+func (backend *Backend) getSince(version uint64, filter storage.SyncFilter) ([]*databroker.Record, uint64, error) {
+	var records []*databroker.Record
+	latest := version
+	err := backend.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(changesBucket).Cursor()
+		for k, v := c.Seek(encodeVersion(version + 1)); k != nil; k, v = c.Next() {
+			record, err := decodeRecord(v)
+			if err != nil {
+				return err
+			}
+			latest = record.GetVersion()
+			if filter.Matches(record) {
+				records = append(records, record)
+			}
+		}
+		return nil
+	})
+	return records, latest, err
+}
+
+func (backend *Backend) removeChangesBefore(ctx context.Context, cutoff time.Time) {
+	err := backend.db.Update(func(tx *bolt.Tx) error {
+		changes := tx.Bucket(changesBucket)
+
+		// collect the keys first, as deleting while iterating a cursor skips entries
+		var keys [][]byte
+		c := changes.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			record, err := decodeRecord(v)
+			if err == nil && !record.GetModifiedAt().AsTime().Before(cutoff) {
+				// nothing left to remove
+				break
+			}
+			keys = append(keys, k)
+		}
+
+		for _, k := range keys {
+			err := changes.Delete(k)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("file: error removing changes")
+	}
+}
+
+// getRecordsOfType returns the records of the given type in insertion order.
+func getRecordsOfType(ctx context.Context, tx *bolt.Tx, recordType, idPrefix []byte) []*databroker.Record {
+	typeRecords := tx.Bucket(recordsBucket).Bucket(recordType)
+	typeOrder := tx.Bucket(orderBucket).Bucket(recordType)
+	if typeRecords == nil || typeOrder == nil {
+		return nil
+	}
+
+	var records []*databroker.Record
+	_ = typeOrder.ForEach(func(_, id []byte) error {
+		// only decode the records with a matching id
+		if !bytes.HasPrefix(id, idPrefix) {
+			return nil
+		}
+		record, err := decodeRecord(typeRecords.Get(id))
+		if err != nil {
+			log.Warn(ctx).Err(err).Msg("file: invalid record detected")
+			return nil
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records
+}
+
+func getVersions(tx *bolt.Tx) *databroker.Versions {
+	meta := tx.Bucket(metaBucket)
+	return &databroker.Versions{
+		ServerVersion:       decodeVersion(meta.Get(serverVersionKey)),
+		LatestRecordVersion: decodeVersion(meta.Get(latestRecordVersionKey)),
+	}
+}
+
+func getOptions(tx *bolt.Tx, recordType string) (*databroker.Options, error) {
+	options := new(databroker.Options)
+	raw := tx.Bucket(optionsBucket).Get([]byte(recordType))
+	if raw == nil {
+		// treat no options as an empty set of options
+		return options, nil
+	}
+
+	err := proto.Unmarshal(raw, options)
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// getRecordVersion returns the version of a record, or 0 if the record doesn't exist.
+func getRecordVersion(tx *bolt.Tx, recordType, recordID string) (uint64, error) {
+	typeRecords := tx.Bucket(recordsBucket).Bucket([]byte(recordType))
+	if typeRecords == nil {
+		return 0, nil
+	}
+
+	raw := typeRecords.Get([]byte(recordID))
+	if raw == nil {
+		return 0, nil
+	}
+
+	record, err := decodeRecord(raw)
+	if err != nil {
+		return 0, err
+	}
+	return record.GetVersion(), nil
+}
+
+// putRecord stores the record and a record change under the next record version. The record's version and modified
+// timestamp are updated.
+func putRecord(tx *bolt.Tx, record *databroker.Record, modifiedAt *timestamppb.Timestamp) error {
+	meta := tx.Bucket(metaBucket)
+	version := decodeVersion(meta.Get(latestRecordVersionKey)) + 1
+	err := meta.Put(latestRecordVersionKey, encodeVersion(version))
+	if err != nil {
+		return err
+	}
+
+	record.ModifiedAt = modifiedAt
+	record.Version = version
+
+	bs, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	typeRecords, err := tx.Bucket(recordsBucket).CreateBucketIfNotExists([]byte(record.GetType()))
+	if err != nil {
+		return err
+	}
+	typeOrder, err := tx.Bucket(orderBucket).CreateBucketIfNotExists([]byte(record.GetType()))
+	if err != nil {
+		return err
+	}
+
+	// remove the previous version from the insertion order
+	if raw := typeRecords.Get([]byte(record.GetId())); raw != nil {
+		previous, err := decodeRecord(raw)
+		if err != nil {
+			return err
+		}
+		err = typeOrder.Delete(encodeVersion(previous.GetVersion()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if record.GetDeletedAt() != nil {
+		err = typeRecords.Delete([]byte(record.GetId()))
+	} else {
+		err = typeRecords.Put([]byte(record.GetId()), bs)
+		if err == nil {
+			err = typeOrder.Put(encodeVersion(version), []byte(record.GetId()))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(changesBucket).Put(encodeVersion(version), bs)
+}
+
+// enforceOptions enforces the options for the given record type.
+func enforceOptions(tx *bolt.Tx, recordType string) error {
+	options, err := getOptions(tx, recordType)
+	if err != nil {
+		return err
+	}
+
+	// nothing to do if capacity isn't set
+	if options.Capacity == nil {
+		return nil
+	}
+
+	typeRecords := tx.Bucket(recordsBucket).Bucket([]byte(recordType))
+	typeOrder := tx.Bucket(orderBucket).Bucket([]byte(recordType))
+	if typeRecords == nil || typeOrder == nil {
+		return nil
+	}
+
+	// find the oldest records that exceed the capacity
+	var records []*databroker.Record
+	c := typeOrder.Cursor()
+	n := uint64(0)
+	for k, id := c.Last(); k != nil; k, id = c.Prev() {
+		n++
+		if n <= options.GetCapacity() {
+			continue
+		}
+
+		record, err := decodeRecord(typeRecords.Get(id))
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	// delete the oldest records first
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		record.DeletedAt = timestamppb.Now()
+		err = putRecord(tx, record, record.DeletedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeRecord(raw []byte) (*databroker.Record, error) {
+	if raw == nil {
+		return nil, storage.ErrNotFound
+	}
+
+	record := new(databroker.Record)
+	err := proto.Unmarshal(raw, record)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func encodeVersion(version uint64) []byte {
+	bs := make([]byte, 8)
+	binary.BigEndian.PutUint64(bs, version)
+	return bs
+}
+
+func decodeVersion(raw []byte) uint64 {
+	if len(raw) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(raw)
+}
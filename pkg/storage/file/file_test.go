@@ -0,0 +1,441 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+func newTestBackend(t *testing.T, options ...Option) (*Backend, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "databroker.db")
+	backend, err := New(path, options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+	return backend, path
+}
+
+func TestBackend(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+	_, versions, err := backend.GetAll(ctx)
+	require.NoError(t, err)
+	serverVersion := versions.GetServerVersion()
+
+	t.Run("get missing record", func(t *testing.T) {
+		record, err := backend.Get(ctx, "TYPE", "abcd")
+		require.Error(t, err)
+		assert.Nil(t, record)
+	})
+	t.Run("get record", func(t *testing.T) {
+		data := new(anypb.Any)
+		sv, err := backend.Put(ctx, &databroker.Record{
+			Type: "TYPE",
+			Id:   "abcd",
+			Data: data,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, serverVersion, sv)
+		record, err := backend.Get(ctx, "TYPE", "abcd")
+		require.NoError(t, err)
+		if assert.NotNil(t, record) {
+			assert.True(t, proto.Equal(data, record.Data))
+			assert.Nil(t, record.DeletedAt)
+			assert.Equal(t, "abcd", record.Id)
+			assert.NotNil(t, record.ModifiedAt)
+			assert.Equal(t, "TYPE", record.Type)
+			assert.Equal(t, uint64(1), record.Version)
+		}
+	})
+	t.Run("delete record", func(t *testing.T) {
+		sv, err := backend.Put(ctx, &databroker.Record{
+			Type:      "TYPE",
+			Id:        "abcd",
+			DeletedAt: timestamppb.Now(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, serverVersion, sv)
+		record, err := backend.Get(ctx, "TYPE", "abcd")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+		assert.Nil(t, record)
+	})
+	t.Run("get all records", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			sv, err := backend.Put(ctx, &databroker.Record{
+				Type: "TYPE",
+				Id:   fmt.Sprint(i),
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, serverVersion, sv)
+		}
+		records, versions, err := backend.GetAll(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, records, 1000)
+		assert.Equal(t, uint64(1002), versions.LatestRecordVersion)
+	})
+	t.Run("get all records of type", func(t *testing.T) {
+		sv, err := backend.Put(ctx, &databroker.Record{
+			Type: "OTHER",
+			Id:   "1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, serverVersion, sv)
+		records, err := backend.GetAllOfType(ctx, "OTHER", "")
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, "OTHER", records[0].GetType())
+		}
+		records, err = backend.GetAllOfType(ctx, "TYPE", "")
+		assert.NoError(t, err)
+		assert.Len(t, records, 1000)
+		records, err = backend.GetAllOfType(ctx, "TYPE", "99")
+		assert.NoError(t, err)
+		assert.Len(t, records, 11, "only 99 and 990-999 should match")
+		records, err = backend.GetAllOfType(ctx, "MISSING", "")
+		assert.NoError(t, err)
+		assert.Len(t, records, 0)
+	})
+}
+
+func TestPutMany(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+
+	records := []*databroker.Record{
+		{Type: "TYPE", Id: "a"},
+		{Type: "TYPE", Id: "b"},
+		{Type: "OTHER", Id: "c"},
+	}
+	_, err := backend.PutMany(ctx, records, storage.PutOptions{})
+	require.NoError(t, err)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.GetVersion(), "should assign consecutive versions")
+	}
+
+	t.Run("check versions", func(t *testing.T) {
+		_, err := backend.PutMany(ctx, []*databroker.Record{
+			{Type: "TYPE", Id: "a", Version: 1},
+			{Type: "TYPE", Id: "b", Version: 1},
+			{Type: "TYPE", Id: "d", Version: 0},
+		}, storage.PutOptions{CheckVersions: true})
+		assert.ErrorIs(t, err, storage.ErrVersionConflict)
+
+		record, err := backend.Get(ctx, "TYPE", "a")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), record.GetVersion(), "should not write any record on conflict")
+		_, err = backend.Get(ctx, "TYPE", "d")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		_, err = backend.PutMany(ctx, []*databroker.Record{
+			{Type: "TYPE", Id: "a", Version: 1},
+			{Type: "TYPE", Id: "b", Version: 2},
+			{Type: "TYPE", Id: "d", Version: 0},
+		}, storage.PutOptions{CheckVersions: true})
+		assert.NoError(t, err)
+
+		record, err = backend.Get(ctx, "TYPE", "d")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), record.GetVersion())
+	})
+}
+
+func TestRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "databroker.db")
+
+	backend, err := New(path)
+	require.NoError(t, err)
+	serverVersion, err := backend.Put(ctx, &databroker.Record{
+		Type: "TYPE",
+		Id:   "1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, backend.SetOptions(ctx, "TYPE", &databroker.Options{
+		Capacity: proto.Uint64(2),
+	}))
+	require.NoError(t, backend.Close())
+
+	backend, err = New(path)
+	require.NoError(t, err)
+	defer func() { _ = backend.Close() }()
+
+	records, versions, err := backend.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, serverVersion, versions.GetServerVersion(), "should preserve the server version")
+	assert.Equal(t, uint64(1), versions.GetLatestRecordVersion(), "should preserve the record version")
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "1", records[0].GetId())
+	}
+
+	options, err := backend.GetOptions(ctx, "TYPE")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), options.GetCapacity())
+
+	_, err = backend.Put(ctx, &databroker.Record{
+		Type: "TYPE",
+		Id:   "2",
+	})
+	require.NoError(t, err)
+
+	stream, err := backend.Sync(ctx, serverVersion, 1, storage.SyncFilter{})
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+	require.True(t, stream.Next(false))
+	assert.Equal(t, "2", stream.Record().GetId())
+	assert.Equal(t, uint64(2), stream.Record().GetVersion())
+	assert.False(t, stream.Next(false))
+	assert.NoError(t, stream.Err())
+
+	_, err = backend.Sync(ctx, serverVersion+1, 0, storage.SyncFilter{})
+	assert.ErrorIs(t, err, storage.ErrInvalidServerVersion)
+}
+
+func TestExpiry(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t, WithExpiry(0))
+
+	var serverVersion uint64
+	for i := 0; i < 1000; i++ {
+		sv, err := backend.Put(ctx, &databroker.Record{
+			Type: "TYPE",
+			Id:   fmt.Sprint(i),
+		})
+		assert.NoError(t, err)
+		serverVersion = sv
+	}
+	stream, err := backend.Sync(ctx, serverVersion, 0, storage.SyncFilter{})
+	require.NoError(t, err)
+	var records []*databroker.Record
+	for stream.Next(false) {
+		records = append(records, stream.Record())
+	}
+	_ = stream.Close()
+	require.Len(t, records, 1000)
+
+	backend.removeChangesBefore(ctx, time.Now().Add(time.Second))
+
+	stream, err = backend.Sync(ctx, serverVersion, 0, storage.SyncFilter{})
+	require.NoError(t, err)
+	records = nil
+	for stream.Next(false) {
+		records = append(records, stream.Record())
+	}
+	_ = stream.Close()
+	require.Len(t, records, 0)
+}
+
+func TestRecordExpiry(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+	now := time.Now()
+	serverVersion, err := backend.PutMany(ctx, []*databroker.Record{
+		{Type: "TYPE", Id: "expired", ExpiresAt: timestamppb.New(now.Add(-time.Minute))},
+		{Type: "TYPE", Id: "active", ExpiresAt: timestamppb.New(now.Add(time.Minute))},
+		{Type: "OTHER", Id: "ttl"},
+	}, storage.PutOptions{})
+	require.NoError(t, err)
+	require.NoError(t, backend.SetOptions(ctx, "OTHER", &databroker.Options{
+		Ttl: durationpb.New(time.Second),
+	}))
+
+	backend.removeExpiredRecords(ctx, now)
+
+	_, err = backend.Get(ctx, "TYPE", "expired")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = backend.Get(ctx, "TYPE", "active")
+	assert.NoError(t, err)
+	_, err = backend.Get(ctx, "OTHER", "ttl")
+	assert.NoError(t, err, "should not expire before the ttl")
+
+	backend.removeExpiredRecords(ctx, now.Add(time.Hour))
+
+	_, err = backend.Get(ctx, "TYPE", "active")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = backend.Get(ctx, "OTHER", "ttl")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	stream, err := backend.Sync(ctx, serverVersion, 3, storage.SyncFilter{})
+	require.NoError(t, err)
+	var deleted []string
+	for stream.Next(false) {
+		if stream.Record().GetDeletedAt() != nil {
+			deleted = append(deleted, stream.Record().GetId())
+		}
+	}
+	_ = stream.Close()
+	assert.ElementsMatch(t, []string{"expired", "active", "ttl"}, deleted, "expirations should be synced as deletions")
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+	_, versions, err := backend.GetAll(ctx)
+	require.NoError(t, err)
+
+	stream, err := backend.Sync(ctx, versions.GetServerVersion(), 0, storage.SyncFilter{})
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		for i := 0; i < 1000; i++ {
+			assert.True(t, stream.Next(true))
+			assert.Nil(t, stream.Err())
+			assert.Equal(t, "TYPE", stream.Record().GetType())
+			assert.Equal(t, fmt.Sprint(i), stream.Record().GetId())
+			assert.Equal(t, uint64(i+1), stream.Record().GetVersion())
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		for i := 0; i < 1000; i++ {
+			_, err := backend.Put(ctx, &databroker.Record{
+				Type: "TYPE",
+				Id:   fmt.Sprint(i),
+			})
+			assert.NoError(t, err)
+		}
+		return nil
+	})
+	require.NoError(t, eg.Wait())
+}
+
+func TestStreamFilter(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+	_, v, err := backend.GetAll(ctx)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		prefix := "odd-"
+		if i%2 == 0 {
+			prefix = "even-"
+		}
+		for _, recordType := range []string{"A", "B"} {
+			_, err := backend.Put(ctx, &databroker.Record{
+				Type: recordType,
+				Id:   prefix + fmt.Sprint(i),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	stream, err := backend.Sync(ctx, v.GetServerVersion(), 0, storage.SyncFilter{
+		Types:      []string{"B"},
+		IDPrefixes: []string{"even-"},
+	})
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	var versions []uint64
+	for stream.Next(false) {
+		assert.Equal(t, "B", stream.Record().GetType())
+		assert.Contains(t, stream.Record().GetId(), "even-")
+		versions = append(versions, stream.Record().GetVersion())
+	}
+	assert.NoError(t, stream.Err())
+	assert.Equal(t, []uint64{2, 6, 10, 14, 18}, versions)
+}
+
+func TestStreamClose(t *testing.T) {
+	ctx := context.Background()
+	t.Run("by backend", func(t *testing.T) {
+		backend, _ := newTestBackend(t)
+		_, versions, err := backend.GetAll(ctx)
+		require.NoError(t, err)
+		stream, err := backend.Sync(ctx, versions.GetServerVersion(), 0, storage.SyncFilter{})
+		require.NoError(t, err)
+		require.NoError(t, backend.Close())
+		assert.False(t, stream.Next(true))
+		assert.Equal(t, storage.ErrStreamClosed, stream.Err())
+	})
+	t.Run("by stream", func(t *testing.T) {
+		backend, _ := newTestBackend(t)
+		_, versions, err := backend.GetAll(ctx)
+		require.NoError(t, err)
+		stream, err := backend.Sync(ctx, versions.GetServerVersion(), 0, storage.SyncFilter{})
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+		assert.False(t, stream.Next(true))
+		assert.Equal(t, storage.ErrStreamClosed, stream.Err())
+	})
+	t.Run("by context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		backend, _ := newTestBackend(t)
+		_, versions, err := backend.GetAll(ctx)
+		require.NoError(t, err)
+		stream, err := backend.Sync(ctx, versions.GetServerVersion(), 0, storage.SyncFilter{})
+		require.NoError(t, err)
+		cancel()
+		assert.False(t, stream.Next(true))
+		assert.Equal(t, context.Canceled, stream.Err())
+	})
+}
+
+func TestCapacity(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+
+	err := backend.SetOptions(ctx, "EXAMPLE", &databroker.Options{
+		Capacity: proto.Uint64(3),
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err = backend.Put(ctx, &databroker.Record{
+			Type: "EXAMPLE",
+			Id:   fmt.Sprint(i),
+		})
+		require.NoError(t, err)
+	}
+
+	records, _, err := backend.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	var ids []string
+	for _, r := range records {
+		ids = append(ids, r.GetId())
+	}
+	assert.Equal(t, []string{"7", "8", "9"}, ids, "should contain recent records")
+}
+
+func TestLease(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+
+	{
+		ok, err := backend.Lease(ctx, "test", "a", time.Second*30)
+		require.NoError(t, err)
+		assert.True(t, ok, "expected a to acquire the lease")
+	}
+	{
+		ok, err := backend.Lease(ctx, "test", "b", time.Second*30)
+		require.NoError(t, err)
+		assert.False(t, ok, "expected b to fail to acquire the lease")
+	}
+	{
+		ok, err := backend.Lease(ctx, "test", "a", 0)
+		require.NoError(t, err)
+		assert.False(t, ok, "expected a to clear the lease")
+	}
+	{
+		ok, err := backend.Lease(ctx, "test", "b", time.Second*30)
+		require.NoError(t, err)
+		assert.True(t, ok, "expected b to to acquire the lease")
+	}
+}
@@ -0,0 +1,37 @@
+package file
+
+import (
+	"time"
+)
+
+type config struct {
+	expiry      time.Duration
+	openTimeout time.Duration
+}
+
+// Option customizes a Backend.
+type Option func(*config)
+
+// WithExpiry sets the expiry for changes.
+func WithExpiry(expiry time.Duration) Option {
+	return func(cfg *config) {
+		cfg.expiry = expiry
+	}
+}
+
+// WithOpenTimeout sets the amount of time to wait for the lock on the database file.
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.openTimeout = timeout
+	}
+}
+
+func getConfig(options ...Option) *config {
+	cfg := new(config)
+	WithExpiry(time.Hour * 24)(cfg)
+	WithOpenTimeout(time.Second * 10)(cfg)
+	for _, o := range options {
+		o(cfg)
+	}
+	return cfg
+}
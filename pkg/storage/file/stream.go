@@ -0,0 +1,132 @@
+package file
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+type recordStream struct {
+	ctx     context.Context
+	backend *Backend
+
+	changed       chan context.Context
+	ready         []*databroker.Record
+	recordVersion uint64
+	filter        storage.SyncFilter
+	err           error
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newRecordStream(ctx context.Context, backend *Backend, recordVersion uint64, filter storage.SyncFilter) *recordStream {
+	stream := &recordStream{
+		ctx:     ctx,
+		backend: backend,
+
+		changed:       backend.onChange.Bind(),
+		recordVersion: recordVersion,
+		filter:        filter,
+
+		closed: make(chan struct{}),
+	}
+	// if the backend is closed, close the stream
+	go func() {
+		select {
+		case <-stream.closed:
+		case <-backend.closed:
+			_ = stream.Close()
+		}
+	}()
+	return stream
+}
+
+func (stream *recordStream) fill() {
+	var ready []*databroker.Record
+	var latest uint64
+	ready, latest, stream.err = stream.backend.getSince(stream.recordVersion, stream.filter)
+	if stream.err == nil {
+		// update the local version to the last change checked,
+		// so changes which don't match the filter aren't checked again
+		stream.ready, stream.recordVersion = ready, latest
+	}
+}
+
+func (stream *recordStream) Close() error {
+	stream.closeOnce.Do(func() {
+		stream.backend.onChange.Unbind(stream.changed)
+		close(stream.closed)
+	})
+	return nil
+}
+
+func (stream *recordStream) Next(wait bool) bool {
+	if len(stream.ready) > 0 {
+		stream.ready = stream.ready[1:]
+	}
+	if len(stream.ready) > 0 {
+		return true
+	}
+
+	for {
+		select {
+		case <-stream.closed:
+			return false
+		default:
+		}
+
+		stream.fill()
+		if stream.err != nil {
+			return false
+		}
+		if len(stream.ready) > 0 {
+			return true
+		}
+
+		if wait {
+			select {
+			case <-stream.ctx.Done():
+				return false
+			case <-stream.closed:
+				return false
+			case <-stream.changed:
+				// query for records again
+			}
+		} else {
+			return false
+		}
+	}
+}
+
+func (stream *recordStream) Record() *databroker.Record {
+	var r *databroker.Record
+	if len(stream.ready) > 0 {
+		r = stream.ready[0]
+	}
+	return r
+}
+
+func (stream *recordStream) Err() error {
+	select {
+	case <-stream.ctx.Done():
+		return stream.ctx.Err()
+	default:
+	}
+
+	select {
+	case <-stream.backend.closed:
+		return storage.ErrStreamClosed
+	default:
+	}
+
+	select {
+	case <-stream.closed:
+		return storage.ErrStreamClosed
+	default:
+	}
+
+	return stream.err
+}
@@ -4,6 +4,7 @@ package inmemory
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -48,26 +49,46 @@ type Backend struct {
 	closeOnce   sync.Once
 	closed      chan struct{}
 
-	mu       sync.RWMutex
-	lookup   map[string]*RecordCollection
-	capacity map[string]*uint64
-	changes  *btree.BTree
-	leases   map[string]*lease
+	mu          sync.RWMutex
+	lookup      map[string]*RecordCollection
+	options     map[string]*databroker.Options
+	changes     *btree.BTree
+	expirations *btree.BTree
+	expiresAt   map[recordKey]time.Time
+	leases      map[string]*lease
 }
 
 // New creates a new in-memory backend storage.
 func New(options ...Option) *Backend {
 	cfg := getConfig(options...)
+	if cfg.serverVersion == 0 {
+		cfg.serverVersion = cryptutil.NewRandomUInt64()
+	}
 	backend := &Backend{
 		cfg:           cfg,
 		onChange:      signal.New(),
-		serverVersion: cryptutil.NewRandomUInt64(),
+		serverVersion: cfg.serverVersion,
 		closed:        make(chan struct{}),
 		lookup:        make(map[string]*RecordCollection),
-		capacity:      map[string]*uint64{},
+		options:       map[string]*databroker.Options{},
 		changes:       btree.New(cfg.degree),
+		expirations:   btree.New(cfg.degree),
+		expiresAt:     map[recordKey]time.Time{},
 		leases:        make(map[string]*lease),
 	}
+	go func() {
+		ticker := time.NewTicker(recordExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-backend.closed:
+				return
+			case <-ticker.C:
+			}
+
+			backend.removeExpiredRecords(context.Background(), time.Now())
+		}
+	}()
 	if cfg.expiry != 0 {
 		go func() {
 			ticker := time.NewTicker(time.Second)
@@ -118,8 +139,10 @@ func (backend *Backend) Close() error {
 		defer backend.mu.Unlock()
 
 		backend.lookup = map[string]*RecordCollection{}
-		backend.capacity = map[string]*uint64{}
+		backend.options = map[string]*databroker.Options{}
 		backend.changes = btree.New(backend.cfg.degree)
+		backend.expirations = btree.New(backend.cfg.degree)
+		backend.expiresAt = map[recordKey]time.Time{}
 	})
 	return nil
 }
@@ -159,17 +182,61 @@ func (backend *Backend) GetAll(_ context.Context) ([]*databroker.Record, *databr
 	}, nil
 }
 
+// Versions returns the server version and the latest record version of the in-memory store.
+func (backend *Backend) Versions() *databroker.Versions {
+	return &databroker.Versions{
+		ServerVersion:       backend.serverVersion,
+		LatestRecordVersion: atomic.LoadUint64(&backend.lastVersion),
+	}
+}
+
+// GetAllOfType gets all the records of the given type whose id starts with idPrefix from the in-memory store.
+func (backend *Backend) GetAllOfType(_ context.Context, recordType, idPrefix string) ([]*databroker.Record, error) {
+	backend.mu.RLock()
+	defer backend.mu.RUnlock()
+
+	rs, ok := backend.lookup[recordType]
+	if !ok {
+		return nil, nil
+	}
+
+	var all []*databroker.Record
+	for _, r := range rs.List() {
+		if !strings.HasPrefix(r.GetId(), idPrefix) {
+			continue
+		}
+		all = append(all, dup(r))
+	}
+	return all, nil
+}
+
 // GetOptions returns the options for a type in the in-memory store.
 func (backend *Backend) GetOptions(_ context.Context, recordType string) (*databroker.Options, error) {
 	backend.mu.RLock()
 	defer backend.mu.RUnlock()
 
-	options := new(databroker.Options)
-	if capacity := backend.capacity[recordType]; capacity != nil {
-		options.Capacity = proto.Uint64(*capacity)
+	return proto.Clone(backend.getOptionsLocked(recordType)).(*databroker.Options), nil
+}
+
+// GetAllOptions returns the options of every type with options in the in-memory store.
+func (backend *Backend) GetAllOptions(_ context.Context) (map[string]*databroker.Options, error) {
+	backend.mu.RLock()
+	defer backend.mu.RUnlock()
+
+	all := make(map[string]*databroker.Options, len(backend.options))
+	for recordType, options := range backend.options {
+		all[recordType] = proto.Clone(options).(*databroker.Options)
 	}
+	return all, nil
+}
 
-	return options, nil
+func (backend *Backend) getOptionsLocked(recordType string) *databroker.Options {
+	options, ok := backend.options[recordType]
+	if !ok {
+		// treat no options as an empty set of options
+		return new(databroker.Options)
+	}
+	return options
 }
 
 // Lease acquires or renews a lease.
@@ -205,66 +272,139 @@ func (backend *Backend) Lease(_ context.Context, leaseName, leaseID string, ttl
 
 // Put puts a record into the in-memory store.
 func (backend *Backend) Put(ctx context.Context, record *databroker.Record) (serverVersion uint64, err error) {
-	if record == nil {
-		return backend.serverVersion, fmt.Errorf("records cannot be nil")
+	return backend.PutMany(ctx, []*databroker.Record{record}, storage.PutOptions{})
+}
+
+// PutMany puts multiple records into the in-memory store atomically.
+func (backend *Backend) PutMany(ctx context.Context, records []*databroker.Record, options storage.PutOptions) (serverVersion uint64, err error) {
+	for _, record := range records {
+		if record == nil {
+			return backend.serverVersion, fmt.Errorf("records cannot be nil")
+		}
 	}
 
 	ctx = log.WithContext(ctx, func(c zerolog.Context) zerolog.Context {
-		return c.Str("db_op", "put").
-			Str("db_id", record.Id).
-			Str("db_type", record.Type)
+		c = c.Str("db_op", "put")
+		if len(records) == 1 {
+			c = c.Str("db_id", records[0].Id).
+				Str("db_type", records[0].Type)
+		}
+		return c
 	})
 
 	backend.mu.Lock()
 	defer backend.mu.Unlock()
+
+	if options.CheckVersions {
+		err = storage.CheckVersions(records, backend.lastVersion+1, func(recordType, recordID string) (uint64, error) {
+			if c, ok := backend.lookup[recordType]; ok {
+				return c.Get(recordID).GetVersion(), nil
+			}
+			return 0, nil
+		})
+		if err != nil {
+			return backend.serverVersion, err
+		}
+	}
+
 	defer backend.onChange.Broadcast(ctx)
 
-	backend.recordChange(record)
+	now := timestamppb.Now()
+	recordTypes := map[string]struct{}{}
+	for _, record := range records {
+		backend.recordChange(record, options.GetModifiedAt(record, now))
 
-	c, ok := backend.lookup[record.GetType()]
-	if !ok {
-		c = NewRecordCollection()
-		backend.lookup[record.GetType()] = c
-	}
+		c, ok := backend.lookup[record.GetType()]
+		if !ok {
+			c = NewRecordCollection()
+			backend.lookup[record.GetType()] = c
+		}
 
-	if record.GetDeletedAt() != nil {
-		c.Delete(record.GetId())
-	} else {
-		c.Put(dup(record))
+		if record.GetDeletedAt() != nil {
+			c.Delete(record.GetId())
+		} else {
+			c.Put(dup(record))
+		}
+		recordTypes[record.GetType()] = struct{}{}
 	}
 
-	backend.enforceCapacity(record.GetType())
+	for recordType := range recordTypes {
+		backend.enforceCapacity(recordType)
+	}
 
 	return backend.serverVersion, nil
 }
 
+// Replicate applies records replicated from another backend. Unlike PutMany, the records keep their versions and
+// modification times, and neither the capacity nor the expiry of records is enforced, as those changes are
+// replicated from the other backend as well. Together with WithServerVersion this makes the in-memory store a copy
+// of the other backend which can serve Sync streams started against either of them.
+func (backend *Backend) Replicate(ctx context.Context, latestRecordVersion uint64, records ...*databroker.Record) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	defer backend.onChange.Broadcast(ctx)
+
+	for _, record := range records {
+		backend.changes.ReplaceOrInsert(recordChange{record: dup(record)})
+
+		c, ok := backend.lookup[record.GetType()]
+		if !ok {
+			c = NewRecordCollection()
+			backend.lookup[record.GetType()] = c
+		}
+
+		if record.GetDeletedAt() != nil {
+			c.Delete(record.GetId())
+		} else {
+			c.Put(dup(record))
+		}
+
+		if record.GetVersion() > latestRecordVersion {
+			latestRecordVersion = record.GetVersion()
+		}
+	}
+
+	if latestRecordVersion > atomic.LoadUint64(&backend.lastVersion) {
+		atomic.StoreUint64(&backend.lastVersion, latestRecordVersion)
+	}
+}
+
 // SetOptions sets the options for a type in the in-memory store.
 func (backend *Backend) SetOptions(_ context.Context, recordType string, options *databroker.Options) error {
 	backend.mu.Lock()
 	defer backend.mu.Unlock()
 
-	if options.Capacity == nil {
-		delete(backend.capacity, recordType)
-	} else {
-		backend.capacity[recordType] = proto.Uint64(options.GetCapacity())
-		backend.enforceCapacity(recordType)
+	previous := backend.getOptionsLocked(recordType)
+	backend.options[recordType] = proto.Clone(options).(*databroker.Options)
+
+	// the expiration of every record of the type changes with the ttl
+	if !proto.Equal(previous.GetTtl(), options.GetTtl()) {
+		if collection, ok := backend.lookup[recordType]; ok {
+			for _, record := range collection.List() {
+				backend.updateExpirationLocked(record)
+			}
+		}
 	}
 
+	backend.enforceCapacity(recordType)
+
 	return nil
 }
 
 // Sync returns a record stream for any changes after recordVersion.
-func (backend *Backend) Sync(ctx context.Context, serverVersion, recordVersion uint64) (storage.RecordStream, error) {
+func (backend *Backend) Sync(ctx context.Context, serverVersion, recordVersion uint64, filter storage.SyncFilter) (storage.RecordStream, error) {
 	if serverVersion != backend.serverVersion {
 		return nil, storage.ErrInvalidServerVersion
 	}
-	return newRecordStream(ctx, backend, recordVersion), nil
+	return newRecordStream(ctx, backend, recordVersion, filter), nil
 }
 
-func (backend *Backend) recordChange(record *databroker.Record) {
-	record.ModifiedAt = timestamppb.Now()
+func (backend *Backend) recordChange(record *databroker.Record, modifiedAt *timestamppb.Timestamp) {
+	record.ModifiedAt = modifiedAt
 	record.Version = backend.nextVersion()
 	backend.changes.ReplaceOrInsert(recordChange{record: dup(record)})
+	backend.updateExpirationLocked(record)
 }
 
 func (backend *Backend) enforceCapacity(recordType string) {
@@ -273,11 +413,11 @@ func (backend *Backend) enforceCapacity(recordType string) {
 		return
 	}
 
-	ptr := backend.capacity[recordType]
-	if ptr == nil {
+	options := backend.getOptionsLocked(recordType)
+	if options.Capacity == nil {
 		return
 	}
-	capacity := *ptr
+	capacity := options.GetCapacity()
 
 	if collection.Len() <= int(capacity) {
 		return
@@ -288,7 +428,7 @@ func (backend *Backend) enforceCapacity(recordType string) {
 		// delete the record
 		record := dup(records[0])
 		record.DeletedAt = timestamppb.Now()
-		backend.recordChange(record)
+		backend.recordChange(record, record.DeletedAt)
 		collection.Delete(record.GetId())
 
 		// move forward
@@ -296,11 +436,13 @@ func (backend *Backend) enforceCapacity(recordType string) {
 	}
 }
 
-func (backend *Backend) getSince(version uint64) []*databroker.Record {
+// getSince returns the changes after version which match the filter, along with the latest version checked.
+func (backend *Backend) getSince(version uint64, filter storage.SyncFilter) ([]*databroker.Record, uint64) {
 	backend.mu.RLock()
 	defer backend.mu.RUnlock()
 
 	var records []*databroker.Record
+	latest := version
 	pivot := recordChange{record: &databroker.Record{Version: version}}
 	backend.changes.AscendGreaterOrEqual(pivot, func(item btree.Item) bool {
 		change, ok := item.(recordChange)
@@ -310,11 +452,14 @@ func (backend *Backend) getSince(version uint64) []*databroker.Record {
 		record := change.record
 		// skip the pivoting version as we only want records after it
 		if record.GetVersion() != version {
-			records = append(records, dup(record))
+			latest = record.GetVersion()
+			if filter.Matches(record) {
+				records = append(records, dup(record))
+			}
 		}
 		return true
 	})
-	return records
+	return records, latest
 }
 
 func (backend *Backend) nextVersion() uint64 {
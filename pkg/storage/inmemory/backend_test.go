@@ -11,6 +11,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
@@ -72,6 +73,71 @@ func TestBackend(t *testing.T) {
 		assert.Len(t, records, 1000)
 		assert.Equal(t, uint64(1002), versions.LatestRecordVersion)
 	})
+	t.Run("get all records of type", func(t *testing.T) {
+		sv, err := backend.Put(ctx, &databroker.Record{
+			Type: "OTHER",
+			Id:   "1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, backend.serverVersion, sv)
+		records, err := backend.GetAllOfType(ctx, "OTHER", "")
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, "OTHER", records[0].GetType())
+		}
+		records, err = backend.GetAllOfType(ctx, "TYPE", "")
+		assert.NoError(t, err)
+		assert.Len(t, records, 1000)
+		records, err = backend.GetAllOfType(ctx, "TYPE", "99")
+		assert.NoError(t, err)
+		assert.Len(t, records, 11, "only 99 and 990-999 should match")
+		records, err = backend.GetAllOfType(ctx, "MISSING", "")
+		assert.NoError(t, err)
+		assert.Len(t, records, 0)
+	})
+}
+
+func TestPutMany(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+	defer func() { _ = backend.Close() }()
+
+	records := []*databroker.Record{
+		{Type: "TYPE", Id: "a"},
+		{Type: "TYPE", Id: "b"},
+		{Type: "OTHER", Id: "c"},
+	}
+	_, err := backend.PutMany(ctx, records, storage.PutOptions{})
+	require.NoError(t, err)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.GetVersion(), "should assign consecutive versions")
+	}
+
+	t.Run("check versions", func(t *testing.T) {
+		_, err := backend.PutMany(ctx, []*databroker.Record{
+			{Type: "TYPE", Id: "a", Version: 1},
+			{Type: "TYPE", Id: "b", Version: 1},
+			{Type: "TYPE", Id: "d", Version: 0},
+		}, storage.PutOptions{CheckVersions: true})
+		assert.ErrorIs(t, err, storage.ErrVersionConflict)
+
+		record, err := backend.Get(ctx, "TYPE", "a")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), record.GetVersion(), "should not write any record on conflict")
+		_, err = backend.Get(ctx, "TYPE", "d")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		_, err = backend.PutMany(ctx, []*databroker.Record{
+			{Type: "TYPE", Id: "a", Version: 1},
+			{Type: "TYPE", Id: "b", Version: 2},
+			{Type: "TYPE", Id: "d", Version: 0},
+		}, storage.PutOptions{CheckVersions: true})
+		assert.NoError(t, err)
+
+		record, err = backend.Get(ctx, "TYPE", "d")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), record.GetVersion())
+	})
 }
 
 func TestExpiry(t *testing.T) {
@@ -87,7 +153,7 @@ func TestExpiry(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, backend.serverVersion, sv)
 	}
-	stream, err := backend.Sync(ctx, backend.serverVersion, 0)
+	stream, err := backend.Sync(ctx, backend.serverVersion, 0, storage.SyncFilter{})
 	require.NoError(t, err)
 	var records []*databroker.Record
 	for stream.Next(false) {
@@ -98,7 +164,7 @@ func TestExpiry(t *testing.T) {
 
 	backend.removeChangesBefore(time.Now().Add(time.Second))
 
-	stream, err = backend.Sync(ctx, backend.serverVersion, 0)
+	stream, err = backend.Sync(ctx, backend.serverVersion, 0, storage.SyncFilter{})
 	require.NoError(t, err)
 	records = nil
 	for stream.Next(false) {
@@ -108,6 +174,49 @@ func TestExpiry(t *testing.T) {
 	require.Len(t, records, 0)
 }
 
+func TestRecordExpiry(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+	defer func() { _ = backend.Close() }()
+	now := time.Now()
+	serverVersion, err := backend.PutMany(ctx, []*databroker.Record{
+		{Type: "TYPE", Id: "expired", ExpiresAt: timestamppb.New(now.Add(-time.Minute))},
+		{Type: "TYPE", Id: "active", ExpiresAt: timestamppb.New(now.Add(time.Minute))},
+		{Type: "OTHER", Id: "ttl"},
+	}, storage.PutOptions{})
+	require.NoError(t, err)
+	require.NoError(t, backend.SetOptions(ctx, "OTHER", &databroker.Options{
+		Ttl: durationpb.New(time.Second),
+	}))
+
+	backend.removeExpiredRecords(ctx, now)
+
+	_, err = backend.Get(ctx, "TYPE", "expired")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = backend.Get(ctx, "TYPE", "active")
+	assert.NoError(t, err)
+	_, err = backend.Get(ctx, "OTHER", "ttl")
+	assert.NoError(t, err, "should not expire before the ttl")
+
+	backend.removeExpiredRecords(ctx, now.Add(time.Hour))
+
+	_, err = backend.Get(ctx, "TYPE", "active")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = backend.Get(ctx, "OTHER", "ttl")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	stream, err := backend.Sync(ctx, serverVersion, 3, storage.SyncFilter{})
+	require.NoError(t, err)
+	var deleted []string
+	for stream.Next(false) {
+		if stream.Record().GetDeletedAt() != nil {
+			deleted = append(deleted, stream.Record().GetId())
+		}
+	}
+	_ = stream.Close()
+	assert.ElementsMatch(t, []string{"expired", "active", "ttl"}, deleted, "expirations should be synced as deletions")
+}
+
 func TestConcurrency(t *testing.T) {
 	ctx := context.Background()
 	backend := New()
@@ -135,7 +244,7 @@ func TestStream(t *testing.T) {
 	backend := New()
 	defer func() { _ = backend.Close() }()
 
-	stream, err := backend.Sync(ctx, backend.serverVersion, 0)
+	stream, err := backend.Sync(ctx, backend.serverVersion, 0, storage.SyncFilter{})
 	require.NoError(t, err)
 	defer func() { _ = stream.Close() }()
 
@@ -163,11 +272,47 @@ func TestStream(t *testing.T) {
 	require.NoError(t, eg.Wait())
 }
 
+func TestStreamFilter(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+	defer func() { _ = backend.Close() }()
+
+	for i := 0; i < 10; i++ {
+		prefix := "odd-"
+		if i%2 == 0 {
+			prefix = "even-"
+		}
+		for _, recordType := range []string{"A", "B"} {
+			_, err := backend.Put(ctx, &databroker.Record{
+				Type: recordType,
+				Id:   prefix + fmt.Sprint(i),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	stream, err := backend.Sync(ctx, backend.serverVersion, 0, storage.SyncFilter{
+		Types:      []string{"B"},
+		IDPrefixes: []string{"even-"},
+	})
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	var versions []uint64
+	for stream.Next(false) {
+		assert.Equal(t, "B", stream.Record().GetType())
+		assert.Contains(t, stream.Record().GetId(), "even-")
+		versions = append(versions, stream.Record().GetVersion())
+	}
+	assert.NoError(t, stream.Err())
+	assert.Equal(t, []uint64{2, 6, 10, 14, 18}, versions)
+}
+
 func TestStreamClose(t *testing.T) {
 	ctx := context.Background()
 	t.Run("by backend", func(t *testing.T) {
 		backend := New()
-		stream, err := backend.Sync(ctx, backend.serverVersion, 0)
+		stream, err := backend.Sync(ctx, backend.serverVersion, 0, storage.SyncFilter{})
 		require.NoError(t, err)
 		require.NoError(t, backend.Close())
 		assert.False(t, stream.Next(true))
@@ -175,7 +320,7 @@ func TestStreamClose(t *testing.T) {
 	})
 	t.Run("by stream", func(t *testing.T) {
 		backend := New()
-		stream, err := backend.Sync(ctx, backend.serverVersion, 0)
+		stream, err := backend.Sync(ctx, backend.serverVersion, 0, storage.SyncFilter{})
 		require.NoError(t, err)
 		require.NoError(t, stream.Close())
 		assert.False(t, stream.Next(true))
@@ -184,7 +329,7 @@ func TestStreamClose(t *testing.T) {
 	t.Run("by context", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(ctx)
 		backend := New()
-		stream, err := backend.Sync(ctx, backend.serverVersion, 0)
+		stream, err := backend.Sync(ctx, backend.serverVersion, 0, storage.SyncFilter{})
 		require.NoError(t, err)
 		cancel()
 		assert.False(t, stream.Next(true))
@@ -192,6 +337,65 @@ func TestStreamClose(t *testing.T) {
 	})
 }
 
+func TestReplicate(t *testing.T) {
+	ctx := context.Background()
+	leader := New()
+	defer func() { _ = leader.Close() }()
+
+	for i := 0; i < 5; i++ {
+		_, err := leader.Put(ctx, &databroker.Record{
+			Type: "TYPE",
+			Id:   fmt.Sprint(i),
+		})
+		require.NoError(t, err)
+	}
+
+	records, versions, err := leader.GetAll(ctx)
+	require.NoError(t, err)
+
+	follower := New(WithServerVersion(versions.GetServerVersion()))
+	defer func() { _ = follower.Close() }()
+	follower.Replicate(ctx, versions.GetLatestRecordVersion(), records...)
+
+	stream, err := leader.Sync(ctx, versions.GetServerVersion(), versions.GetLatestRecordVersion(), storage.SyncFilter{})
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	_, err = leader.Put(ctx, &databroker.Record{
+		Type:      "TYPE",
+		Id:        "1",
+		DeletedAt: timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	_, err = leader.Put(ctx, &databroker.Record{
+		Type: "TYPE",
+		Id:   "5",
+	})
+	require.NoError(t, err)
+	for stream.Next(false) {
+		follower.Replicate(ctx, 0, stream.Record())
+	}
+	require.NoError(t, stream.Err())
+
+	expected, expectedVersions, err := leader.GetAll(ctx)
+	require.NoError(t, err)
+	actual, actualVersions, err := follower.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expectedVersions, actualVersions)
+	assert.ElementsMatch(t, expected, actual)
+
+	// a stream started against the leader can continue against the follower
+	followerStream, err := follower.Sync(ctx, versions.GetServerVersion(), versions.GetLatestRecordVersion(), storage.SyncFilter{})
+	require.NoError(t, err)
+	defer func() { _ = followerStream.Close() }()
+
+	var ids []string
+	for followerStream.Next(false) {
+		ids = append(ids, followerStream.Record().GetId())
+	}
+	assert.Equal(t, []string{"1", "5"}, ids)
+}
+
 func TestCapacity(t *testing.T) {
 	ctx := context.Background()
 	backend := New()
@@ -3,8 +3,9 @@ package inmemory
 import "time"
 
 type config struct {
-	degree int
-	expiry time.Duration
+	degree        int
+	expiry        time.Duration
+	serverVersion uint64
 }
 
 // An Option customizes the in-memory backend.
@@ -34,3 +35,10 @@ func WithExpiry(expiry time.Duration) Option {
 		cfg.expiry = expiry
 	}
 }
+
+// WithServerVersion sets the server version. By default a random server version is used.
+func WithServerVersion(serverVersion uint64) Option {
+	return func(cfg *config) {
+		cfg.serverVersion = serverVersion
+	}
+}
@@ -0,0 +1,96 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/btree"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+const recordExpiryInterval = time.Second
+
+type recordKey struct {
+	recordType, recordID string
+}
+
+// A recordExpiration is the time a record expires. They are stored in a btree so the next records to expire can be
+// found quickly.
+type recordExpiration struct {
+	expiresAt time.Time
+	key       recordKey
+}
+
+func (expiration recordExpiration) Less(item btree.Item) bool {
+	that, ok := item.(recordExpiration)
+	if !ok {
+		return false
+	}
+
+	switch {
+	case !expiration.expiresAt.Equal(that.expiresAt):
+		return expiration.expiresAt.Before(that.expiresAt)
+	case expiration.key.recordType != that.key.recordType:
+		return expiration.key.recordType < that.key.recordType
+	}
+	return expiration.key.recordID < that.key.recordID
+}
+
+// updateExpirationLocked updates the expiration of a record after it has been changed.
+func (backend *Backend) updateExpirationLocked(record *databroker.Record) {
+	key := recordKey{record.GetType(), record.GetId()}
+	if expiresAt, ok := backend.expiresAt[key]; ok {
+		backend.expirations.Delete(recordExpiration{expiresAt: expiresAt, key: key})
+		delete(backend.expiresAt, key)
+	}
+
+	if record.GetDeletedAt() != nil {
+		return
+	}
+
+	expiresAt, ok := storage.GetRecordExpiry(record, backend.getOptionsLocked(record.GetType()))
+	if !ok {
+		return
+	}
+	backend.expirations.ReplaceOrInsert(recordExpiration{expiresAt: expiresAt, key: key})
+	backend.expiresAt[key] = expiresAt
+}
+
+// removeExpiredRecords deletes every record which expired before now.
+func (backend *Backend) removeExpiredRecords(ctx context.Context, now time.Time) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	removed := false
+	for {
+		item := backend.expirations.Min()
+		if item == nil {
+			break
+		}
+		expiration := item.(recordExpiration)
+		if expiration.expiresAt.After(now) {
+			break
+		}
+
+		collection := backend.lookup[expiration.key.recordType]
+		if collection == nil || collection.Get(expiration.key.recordID) == nil {
+			backend.expirations.Delete(expiration)
+			delete(backend.expiresAt, expiration.key)
+			continue
+		}
+
+		// deleting the record removes the expiration
+		record := dup(collection.Get(expiration.key.recordID))
+		record.DeletedAt = timestamppb.New(now)
+		backend.recordChange(record, record.DeletedAt)
+		collection.Delete(expiration.key.recordID)
+		removed = true
+	}
+
+	if removed {
+		backend.onChange.Broadcast(ctx)
+	}
+}
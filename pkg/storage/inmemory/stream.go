@@ -15,18 +15,20 @@ type recordStream struct {
 	changed       chan context.Context
 	ready         []*databroker.Record
 	recordVersion uint64
+	filter        storage.SyncFilter
 
 	closeOnce sync.Once
 	closed    chan struct{}
 }
 
-func newRecordStream(ctx context.Context, backend *Backend, recordVersion uint64) *recordStream {
+func newRecordStream(ctx context.Context, backend *Backend, recordVersion uint64, filter storage.SyncFilter) *recordStream {
 	stream := &recordStream{
 		ctx:     ctx,
 		backend: backend,
 
 		changed:       backend.onChange.Bind(),
 		recordVersion: recordVersion,
+		filter:        filter,
 
 		closed: make(chan struct{}),
 	}
@@ -42,12 +44,9 @@ func newRecordStream(ctx context.Context, backend *Backend, recordVersion uint64
 }
 
 func (stream *recordStream) fill() {
-	stream.ready = stream.backend.getSince(stream.recordVersion)
-	if len(stream.ready) > 0 {
-		// records are sorted by version,
-		// so update the local version to the last record
-		stream.recordVersion = stream.ready[len(stream.ready)-1].GetVersion()
-	}
+	// update the local version to the last change checked,
+	// so changes which don't match the filter aren't checked again
+	stream.ready, stream.recordVersion = stream.backend.getSince(stream.recordVersion, stream.filter)
 }
 
 func (stream *recordStream) Close() error {
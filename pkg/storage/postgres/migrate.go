@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// migrationLockID is the advisory lock used to serialize schema migrations between replicas.
+const migrationLockID = 0x706f6d6572697500 // "pomeriu\x00"
+
+// migrations are applied in order. Once released a migration must never be changed, new schema changes should be
+// appended as new migrations.
+var migrations = []func(ctx context.Context, tx *sql.Tx) error{
+	1: func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			CREATE TABLE pomerium.versions (
+				id INT NOT NULL PRIMARY KEY CHECK (id = 1),
+				server_version BIGINT NOT NULL,
+				latest_record_version BIGINT NOT NULL
+			);
+
+			CREATE TABLE pomerium.records (
+				type TEXT NOT NULL,
+				id TEXT NOT NULL,
+				version BIGINT NOT NULL,
+				data BYTEA,
+				modified_at TIMESTAMPTZ NOT NULL,
+
+				PRIMARY KEY (type, id)
+			);
+			CREATE INDEX ON pomerium.records (type, version);
+
+			CREATE TABLE pomerium.record_changes (
+				type TEXT NOT NULL,
+				id TEXT NOT NULL,
+				version BIGINT NOT NULL PRIMARY KEY,
+				data BYTEA,
+				modified_at TIMESTAMPTZ NOT NULL,
+				deleted_at TIMESTAMPTZ
+			);
+			CREATE INDEX ON pomerium.record_changes (modified_at);
+
+			CREATE TABLE pomerium.record_options (
+				type TEXT NOT NULL PRIMARY KEY,
+				options BYTEA NOT NULL
+			);
+
+			CREATE TABLE pomerium.leases (
+				name TEXT NOT NULL PRIMARY KEY,
+				id TEXT NOT NULL,
+				expires_at TIMESTAMPTZ NOT NULL
+			);
+
+			CREATE TABLE pomerium.services (
+				kind TEXT NOT NULL,
+				endpoint TEXT NOT NULL,
+				expires_at TIMESTAMPTZ NOT NULL,
+
+				PRIMARY KEY (kind, endpoint)
+			);
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO pomerium.versions (id, server_version, latest_record_version)
+			VALUES (1, $1, 0)
+		`, int64(cryptutil.NewRandomUInt64()))
+		return err
+	},
+	2: func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			ALTER TABLE pomerium.records ADD COLUMN expires_at TIMESTAMPTZ;
+			ALTER TABLE pomerium.record_changes ADD COLUMN expires_at TIMESTAMPTZ;
+			ALTER TABLE pomerium.record_options ADD COLUMN ttl INTERVAL;
+			CREATE INDEX ON pomerium.records (expires_at);
+		`)
+		return err
+	},
+}
+
+// migrate brings the database schema up to date.
+func migrate(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(migrationLockID))
+	if err != nil {
+		return fmt.Errorf("postgres: error acquiring migration lock: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE SCHEMA IF NOT EXISTS pomerium;
+		CREATE TABLE IF NOT EXISTS pomerium.migrations (
+			version INT NOT NULL
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("postgres: error creating migrations table: %w", err)
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx, `SELECT version FROM pomerium.migrations`).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = tx.ExecContext(ctx, `INSERT INTO pomerium.migrations (version) VALUES (0)`)
+	}
+	if err != nil {
+		return fmt.Errorf("postgres: error retrieving migration version: %w", err)
+	}
+
+	for version+1 < len(migrations) {
+		version++
+		err = migrations[version](ctx, tx)
+		if err != nil {
+			return fmt.Errorf("postgres: error applying migration %d: %w", version, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE pomerium.migrations SET version = $1`, version)
+	if err != nil {
+		return fmt.Errorf("postgres: error updating migration version: %w", err)
+	}
+
+	return tx.Commit()
+}
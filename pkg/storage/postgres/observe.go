@@ -0,0 +1,17 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	pomeriumconfig "github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+)
+
+func recordOperation(ctx context.Context, startTime time.Time, operation string, err error) {
+	metrics.RecordStorageOperation(ctx, &metrics.StorageOperationTags{
+		Operation: operation,
+		Error:     err,
+		Backend:   pomeriumconfig.StoragePostgresName,
+	}, time.Since(startTime))
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"time"
+)
+
+const defaultRegistryTTL = time.Second * 30
+
+type config struct {
+	expiry      time.Duration
+	registryTTL time.Duration
+}
+
+// Option customizes a Backend.
+type Option func(*config)
+
+// WithExpiry sets the expiry for changes.
+func WithExpiry(expiry time.Duration) Option {
+	return func(cfg *config) {
+		cfg.expiry = expiry
+	}
+}
+
+// WithRegistryTTL sets the ttl for services reported to the registry.
+func WithRegistryTTL(ttl time.Duration) Option {
+	return func(cfg *config) {
+		cfg.registryTTL = ttl
+	}
+}
+
+func getConfig(options ...Option) *config {
+	cfg := new(config)
+	WithExpiry(time.Hour * 24)(cfg)
+	WithRegistryTTL(defaultRegistryTTL)(cfg)
+	for _, o := range options {
+		o(cfg)
+	}
+	return cfg
+}
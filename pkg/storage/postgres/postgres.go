@@ -0,0 +1,924 @@
+// Package postgres implements the storage.Backend interface for PostgreSQL.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/signal"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+const (
+	watchPollInterval    = 30 * time.Second
+	recordBatchSize      = 64
+	recordExpiryInterval = 5 * time.Second
+
+	// recordChangeChannel is the LISTEN/NOTIFY channel used to signal that the record version changed.
+	recordChangeChannel = "pomerium_changed"
+	// serviceChangeChannel is the LISTEN/NOTIFY channel used to signal that the registered services changed.
+	serviceChangeChannel = "pomerium_registry_changed"
+)
+
+// Backend implements the storage.Backend on top of PostgreSQL.
+//
+// What's stored (all tables live in the `pomerium` schema):
+//
+// - versions: a single row containing the server version and the latest record version.
+// - records: the latest version of every record, keyed by (type, id).
+// - record_changes: every change made to a record, keyed by record version. Deletions are stored with `deleted_at`.
+// - record_options: the protobuf options for a record type. The ttl is also stored as an interval, so expired
+//   records can be found by the database.
+// - leases: the current holder and expiry of each lease.
+// - services: the services reported to the registry and when they expire.
+//
+// Writes lock the versions row, so record versions are assigned in commit order. Every commit issues a NOTIFY on
+// the `pomerium_changed` channel, which wakes any blocked record streams.
+//
+// The Backend also implements the registry service.
+type Backend struct {
+	cfg *config
+	dsn string
+
+	db              *sql.DB
+	onChange        *signal.Signal
+	onServiceChange *signal.Signal
+
+	migrateMu sync.Mutex
+	migrated  bool
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// New creates a new postgres storage backend.
+func New(dsn string, options ...Option) (*Backend, error) {
+	ctx := context.TODO()
+	cfg := getConfig(options...)
+
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: invalid connection string: %w", err)
+	}
+
+	backend := &Backend{
+		cfg:             cfg,
+		dsn:             dsn,
+		db:              sql.OpenDB(connector),
+		onChange:        signal.New(),
+		onServiceChange: signal.New(),
+		closed:          make(chan struct{}),
+	}
+	go backend.listenForChanges(ctx)
+	if cfg.expiry != 0 {
+		go func() {
+			ticker := time.NewTicker(time.Minute)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-backend.closed:
+					return
+				case <-ticker.C:
+				}
+
+				backend.removeChangesBefore(ctx, time.Now().Add(-cfg.expiry))
+			}
+		}()
+	}
+	go func() {
+		ticker := time.NewTicker(recordExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-backend.closed:
+				return
+			case <-ticker.C:
+			}
+
+			backend.removeExpiredRecords(ctx, time.Now())
+		}
+	}()
+	return backend, nil
+}
+
+// Close closes the underlying database connections and any watchers.
+func (backend *Backend) Close() error {
+	var err error
+	backend.closeOnce.Do(func() {
+		close(backend.closed)
+		err = backend.db.Close()
+	})
+	return err
+}
+
+// Get gets a record from postgres.
+func (backend *Backend) Get(ctx context.Context, recordType, id string) (_ *databroker.Record, err error) {
+	ctx, span := trace.StartSpan(ctx, "databroker.postgres.Get")
+	defer span.End()
+	defer func(start time.Time) { recordOperation(ctx, start, "get", err) }(time.Now())
+
+	err = backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := scanRecord(backend.db.QueryRowContext(ctx, `
+		SELECT type, id, version, data, modified_at, expires_at
+		  FROM pomerium.records
+		 WHERE type=$1 AND id=$2
+	`, recordType, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres: error retrieving record: %w", err)
+	}
+
+	return record, nil
+}
+
+// GetAll gets all the records from postgres.
+func (backend *Backend) GetAll(ctx context.Context) (records []*databroker.Record, versions *databroker.Versions, err error) {
+	ctx, span := trace.StartSpan(ctx, "databroker.postgres.GetAll")
+	defer span.End()
+	defer func(start time.Time) { recordOperation(ctx, start, "getall", err) }(time.Now())
+
+	err = backend.init(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// use a snapshot so the versions match the records
+	tx, err := backend.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres: error beginning GetAll transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	versions, err = getVersions(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT type, id, version, data, modified_at, expires_at
+		  FROM pomerium.records
+		 ORDER BY version ASC
+	`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres: error retrieving GetAll records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			log.Warn(ctx).Err(err).Msg("postgres: invalid record detected")
+			continue
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("postgres: error retrieving GetAll records: %w", err)
+	}
+
+	return records, versions, nil
+}
+
+// GetAllOfType gets all the records of the given type whose id starts with idPrefix from postgres.
+func (backend *Backend) GetAllOfType(ctx context.Context, recordType, idPrefix string) (records []*databroker.Record, err error) {
+	ctx, span := trace.StartSpan(ctx, "databroker.postgres.GetAllOfType")
+	defer span.End()
+	defer func(start time.Time) { recordOperation(ctx, start, "getalloftype", err) }(time.Now())
+
+	err = backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := backend.db.QueryContext(ctx, `
+		SELECT type, id, version, data, modified_at, expires_at
+		  FROM pomerium.records
+		 WHERE type = $1 AND id LIKE $2
+		 ORDER BY version ASC
+	`, recordType, escapeLike(idPrefix)+"%")
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error retrieving GetAllOfType records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			log.Warn(ctx).Err(err).Msg("postgres: invalid record detected")
+			continue
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error retrieving GetAllOfType records: %w", err)
+	}
+
+	return records, nil
+}
+
+// GetOptions gets the options for the given record type.
+func (backend *Backend) GetOptions(ctx context.Context, recordType string) (*databroker.Options, error) {
+	err := backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return getOptions(ctx, backend.db, recordType)
+}
+
+// GetAllOptions gets the options of every record type with options set.
+func (backend *Backend) GetAllOptions(ctx context.Context) (map[string]*databroker.Options, error) {
+	err := backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := backend.db.QueryContext(ctx, `
+		SELECT type, options
+		  FROM pomerium.record_options
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error retrieving options: %w", err)
+	}
+	defer rows.Close()
+
+	all := map[string]*databroker.Options{}
+	for rows.Next() {
+		var recordType string
+		var raw []byte
+		err = rows.Scan(&recordType, &raw)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: error scanning options row: %w", err)
+		}
+
+		var options databroker.Options
+		err = proto.Unmarshal(raw, &options)
+		if err != nil {
+			return nil, err
+		}
+		all[recordType] = &options
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: error retrieving options: %w", err)
+	}
+	return all, nil
+}
+
+// Lease acquires or renews a lease.
+func (backend *Backend) Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
+	err := backend.init(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// release the lease
+	if ttl <= 0 {
+		_, err = backend.db.ExecContext(ctx, `
+			DELETE FROM pomerium.leases
+			 WHERE name=$1 AND id=$2
+		`, leaseName, leaseID)
+		return false, err
+	}
+
+	// acquire the lease if there is no lease, it has expired, or we already hold it
+	var currentID string
+	err = backend.db.QueryRowContext(ctx, `
+		INSERT INTO pomerium.leases AS l (name, id, expires_at)
+		VALUES ($1, $2, now() + make_interval(secs => $3))
+		ON CONFLICT (name) DO UPDATE
+		   SET id=EXCLUDED.id, expires_at=EXCLUDED.expires_at
+		 WHERE l.id=EXCLUDED.id OR l.expires_at < now()
+		RETURNING id
+	`, leaseName, leaseID, ttl.Seconds()).Scan(&currentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// lease has already been taken
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return currentID == leaseID, nil
+}
+
+// Put puts a record into postgres.
+func (backend *Backend) Put(ctx context.Context, record *databroker.Record) (serverVersion uint64, err error) {
+	return backend.PutMany(ctx, []*databroker.Record{record}, storage.PutOptions{})
+}
+
+// PutMany puts multiple records into postgres in a single transaction.
+func (backend *Backend) PutMany(ctx context.Context, records []*databroker.Record, options storage.PutOptions) (serverVersion uint64, err error) {
+	ctx, span := trace.StartSpan(ctx, "databroker.postgres.PutMany")
+	defer span.End()
+	defer func(start time.Time) { recordOperation(ctx, start, "put", err) }(time.Now())
+
+	for _, record := range records {
+		if record == nil {
+			return 0, fmt.Errorf("records cannot be nil")
+		}
+	}
+
+	err = backend.init(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = backend.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the versions so no other writer can change the records until the transaction completes
+		var sv, rv int64
+		err := tx.QueryRowContext(ctx, `
+			SELECT server_version, latest_record_version
+			  FROM pomerium.versions
+			   FOR UPDATE
+		`).Scan(&sv, &rv)
+		if err != nil {
+			return fmt.Errorf("postgres: error locking versions: %w", err)
+		}
+		serverVersion = uint64(sv)
+
+		if options.CheckVersions {
+			err = storage.CheckVersions(records, uint64(rv)+1, func(recordType, recordID string) (uint64, error) {
+				return getRecordVersion(ctx, tx, recordType, recordID)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		now := timestamppb.Now()
+		recordTypes := map[string]struct{}{}
+		for _, record := range records {
+			_, err = putRecord(ctx, tx, record, options.GetModifiedAt(record, now))
+			if err != nil {
+				return err
+			}
+			recordTypes[record.GetType()] = struct{}{}
+		}
+
+		for recordType := range recordTypes {
+			err = enforceOptions(ctx, tx, recordType)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return serverVersion, err
+}
+
+// SetOptions sets the options for the given record type.
+func (backend *Backend) SetOptions(ctx context.Context, recordType string, options *databroker.Options) error {
+	ctx, span := trace.StartSpan(ctx, "databroker.postgres.SetOptions")
+	defer span.End()
+
+	err := backend.init(ctx)
+	if err != nil {
+		return err
+	}
+
+	bs, err := proto.Marshal(options)
+	if err != nil {
+		return err
+	}
+	if bs == nil {
+		bs = []byte{}
+	}
+
+	var ttl interface{}
+	if options.GetTtl() != nil {
+		ttl = options.GetTtl().AsDuration().Seconds()
+	}
+
+	return backend.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO pomerium.record_options (type, options, ttl)
+			VALUES ($1, $2, $3::FLOAT8 * INTERVAL '1 second')
+			ON CONFLICT (type) DO UPDATE
+			   SET options=EXCLUDED.options, ttl=EXCLUDED.ttl
+		`, recordType, bs, ttl)
+		if err != nil {
+			return err
+		}
+
+		// possibly re-enforce options
+		return enforceOptions(ctx, tx, recordType)
+	})
+}
+
+// Sync returns a record stream of any records changed after the specified recordVersion.
+func (backend *Backend) Sync(ctx context.Context, serverVersion, recordVersion uint64, filter storage.SyncFilter) (storage.RecordStream, error) {
+	err := backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := getVersions(ctx, backend.db)
+	if err != nil {
+		return nil, err
+	}
+	if versions.GetServerVersion() != serverVersion {
+		return nil, storage.ErrInvalidServerVersion
+	}
+
+	return newRecordStream(ctx, backend, serverVersion, recordVersion, filter), nil
+}
+
+// init runs any migrations needed to bring the database schema up to date.
+func (backend *Backend) init(ctx context.Context) error {
+	backend.migrateMu.Lock()
+	defer backend.migrateMu.Unlock()
+
+	if backend.migrated {
+		return nil
+	}
+
+	err := migrate(ctx, backend.db)
+	if err != nil {
+		return err
+	}
+
+	backend.migrated = true
+	return nil
+}
+
+// withTx runs fn in a transaction and notifies listeners of the change when the transaction commits.
+func (backend *Backend) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := backend.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	// notifications are only delivered once the transaction commits
+	_, err = tx.ExecContext(ctx, `SELECT pg_notify($1, '')`, recordChangeChannel)
+	if err != nil {
+		return fmt.Errorf("postgres: error sending change notification: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// listChangesAfter returns the server version, the latest record version checked and up to limit changes after the
+// given record version which match the filter.
+func (backend *Backend) listChangesAfter(
+	ctx context.Context,
+	recordVersion uint64,
+	filter storage.SyncFilter,
+	limit int,
+) (serverVersion, latestVersion uint64, records []*databroker.Record, err error) {
+	tx, err := backend.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("postgres: error beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	versions, err := getVersions(ctx, tx)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	idPatterns := make([]string, len(filter.IDPrefixes))
+	for i, prefix := range filter.IDPrefixes {
+		idPatterns[i] = escapeLike(prefix) + "%"
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT type, id, version, data, modified_at, expires_at, deleted_at
+		  FROM pomerium.record_changes
+		 WHERE version > $1
+		   AND (cardinality($2::TEXT[]) = 0 OR type = ANY($2))
+		   AND (cardinality($3::TEXT[]) = 0 OR id LIKE ANY($3))
+		 ORDER BY version ASC
+		 LIMIT $4
+	`, int64(recordVersion), pq.Array(filter.Types), pq.Array(idPatterns), limit)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("postgres: error retrieving changes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		record, err := scanRecordChange(rows)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("postgres: invalid record change: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, 0, nil, fmt.Errorf("postgres: error retrieving changes: %w", err)
+	}
+
+	// versions are committed in order, so when fewer than limit changes are returned every change in the
+	// snapshot has been checked
+	latestVersion = versions.GetLatestRecordVersion()
+	if len(records) == limit {
+		latestVersion = records[len(records)-1].GetVersion()
+	}
+	if latestVersion < recordVersion {
+		latestVersion = recordVersion
+	}
+
+	return versions.GetServerVersion(), latestVersion, records, nil
+}
+
+func (backend *Backend) listenForChanges(ctx context.Context) {
+	listener := pq.NewListener(backend.dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warn(ctx).Err(err).Msg("postgres: notification listener error")
+		}
+	})
+	defer func() { _ = listener.Close() }()
+
+	for _, channel := range []string{recordChangeChannel, serviceChangeChannel} {
+		err := listener.Listen(channel)
+		if err != nil {
+			log.Error(ctx).Err(err).Str("channel", channel).Msg("postgres: error listening for changes")
+		}
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-backend.closed:
+			return
+		case n := <-listener.Notify:
+			// a nil notification is sent on re-connect, in which case
+			// we may have missed changes, so broadcast to everyone
+			if n == nil || n.Channel == recordChangeChannel {
+				backend.onChange.Broadcast(ctx)
+			}
+			if n == nil || n.Channel == serviceChangeChannel {
+				backend.onServiceChange.Broadcast(ctx)
+			}
+		case <-ticker.C:
+			go func() { _ = listener.Ping() }()
+		}
+	}
+}
+
+func (backend *Backend) removeChangesBefore(ctx context.Context, cutoff time.Time) {
+	err := backend.init(ctx)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("postgres: error removing changes")
+		return
+	}
+
+	_, err = backend.db.ExecContext(ctx, `
+		DELETE FROM pomerium.record_changes
+		 WHERE modified_at < $1
+	`, cutoff)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("postgres: error removing changes")
+	}
+}
+
+// removeExpiredRecords deletes every record which expired before now.
+func (backend *Backend) removeExpiredRecords(ctx context.Context, now time.Time) {
+	err := backend.init(ctx)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("postgres: error removing expired records")
+		return
+	}
+
+	// avoid locking the versions and notifying listeners when nothing has expired
+	var expired bool
+	err = backend.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			  FROM pomerium.records r
+			  LEFT JOIN pomerium.record_options o ON o.type = r.type
+			 WHERE COALESCE(r.expires_at, r.modified_at + o.ttl) <= $1
+		)
+	`, now).Scan(&expired)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("postgres: error removing expired records")
+		return
+	} else if !expired {
+		return
+	}
+
+	err = backend.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the versions so the expired records can't change until they're deleted
+		_, err := tx.ExecContext(ctx, `
+			SELECT 1
+			  FROM pomerium.versions
+			   FOR UPDATE
+		`)
+		if err != nil {
+			return fmt.Errorf("postgres: error locking versions: %w", err)
+		}
+
+		rows, err := tx.QueryContext(ctx, `
+			SELECT r.type, r.id, r.version, r.data, r.modified_at, r.expires_at
+			  FROM pomerium.records r
+			  LEFT JOIN pomerium.record_options o ON o.type = r.type
+			 WHERE COALESCE(r.expires_at, r.modified_at + o.ttl) <= $1
+			 ORDER BY r.version ASC
+		`, now)
+		if err != nil {
+			return fmt.Errorf("postgres: error retrieving expired records: %w", err)
+		}
+		var records []*databroker.Record
+		for rows.Next() {
+			record, err := scanRecord(rows)
+			if err != nil {
+				_ = rows.Close()
+				return err
+			}
+			records = append(records, record)
+		}
+		_ = rows.Close()
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("postgres: error retrieving expired records: %w", err)
+		}
+
+		for _, record := range records {
+			record.DeletedAt = timestamppb.New(now)
+			_, err = putRecord(ctx, tx, record, record.DeletedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("postgres: error removing expired records")
+	}
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func getVersions(ctx context.Context, q queryer) (*databroker.Versions, error) {
+	var serverVersion, latestRecordVersion int64
+	err := q.QueryRowContext(ctx, `
+		SELECT server_version, latest_record_version
+		  FROM pomerium.versions
+	`).Scan(&serverVersion, &latestRecordVersion)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: error retrieving versions: %w", err)
+	}
+	return &databroker.Versions{
+		ServerVersion:       uint64(serverVersion),
+		LatestRecordVersion: uint64(latestRecordVersion),
+	}, nil
+}
+
+func getOptions(ctx context.Context, q queryer, recordType string) (*databroker.Options, error) {
+	var raw []byte
+	err := q.QueryRowContext(ctx, `
+		SELECT options
+		  FROM pomerium.record_options
+		 WHERE type=$1
+	`, recordType).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		// treat no options as an empty set of options
+		return new(databroker.Options), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var options databroker.Options
+	err = proto.Unmarshal(raw, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &options, nil
+}
+
+// getRecordVersion returns the version of a record, or 0 if the record doesn't exist.
+func getRecordVersion(ctx context.Context, q queryer, recordType, recordID string) (uint64, error) {
+	var version int64
+	err := q.QueryRowContext(ctx, `
+		SELECT version
+		  FROM pomerium.records
+		 WHERE type=$1 AND id=$2
+	`, recordType, recordID).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("postgres: error retrieving record version: %w", err)
+	}
+	return uint64(version), nil
+}
+
+// putRecord stores the record and a record change under the next record version. The record's version and modified
+// timestamp are updated.
+func putRecord(
+	ctx context.Context,
+	tx *sql.Tx,
+	record *databroker.Record,
+	modifiedAt *timestamppb.Timestamp,
+) (serverVersion uint64, err error) {
+	// incrementing the latest record version locks the row until the transaction completes
+	var sv, rv int64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE pomerium.versions
+		   SET latest_record_version=latest_record_version+1
+		RETURNING server_version, latest_record_version
+	`).Scan(&sv, &rv)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: error incrementing record version: %w", err)
+	}
+
+	record.ModifiedAt = modifiedAt
+	record.Version = uint64(rv)
+
+	data, err := marshalData(record.GetData())
+	if err != nil {
+		return 0, err
+	}
+
+	var expiresAt, deletedAt interface{}
+	if record.GetExpiresAt() != nil {
+		expiresAt = record.GetExpiresAt().AsTime()
+	}
+	if record.GetDeletedAt() != nil {
+		deletedAt = record.GetDeletedAt().AsTime()
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO pomerium.record_changes (type, id, version, data, modified_at, expires_at, deleted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, record.GetType(), record.GetId(), rv, data, record.GetModifiedAt().AsTime(), expiresAt, deletedAt)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: error storing record change: %w", err)
+	}
+
+	if record.GetDeletedAt() != nil {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM pomerium.records
+			 WHERE type=$1 AND id=$2
+		`, record.GetType(), record.GetId())
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO pomerium.records (type, id, version, data, modified_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (type, id) DO UPDATE
+			   SET version=EXCLUDED.version, data=EXCLUDED.data, modified_at=EXCLUDED.modified_at,
+			       expires_at=EXCLUDED.expires_at
+		`, record.GetType(), record.GetId(), rv, data, record.GetModifiedAt().AsTime(), expiresAt)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("postgres: error storing record: %w", err)
+	}
+
+	return uint64(sv), nil
+}
+
+// enforceOptions enforces the options for the given record type.
+func enforceOptions(ctx context.Context, tx *sql.Tx, recordType string) error {
+	options, err := getOptions(ctx, tx, recordType)
+	if err != nil {
+		return err
+	}
+
+	// nothing to do if capacity isn't set
+	if options.Capacity == nil {
+		return nil
+	}
+
+	// find oldest records that exceed the capacity
+	rows, err := tx.QueryContext(ctx, `
+		SELECT type, id, version, data, modified_at, expires_at
+		  FROM pomerium.records
+		 WHERE type=$1
+		 ORDER BY version DESC
+		OFFSET $2
+	`, recordType, int64(options.GetCapacity()))
+	if err != nil {
+		return err
+	}
+	var records []*databroker.Record
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		records = append(records, record)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// delete the oldest records first
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		record.DeletedAt = timestamppb.Now()
+		_, err = putRecord(ctx, tx, record, record.DeletedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// escapeLike escapes the special characters in a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func scanRecord(s scanner) (*databroker.Record, error) {
+	var recordType, id string
+	var version int64
+	var data []byte
+	var modifiedAt time.Time
+	var expiresAt sql.NullTime
+	err := s.Scan(&recordType, &id, &version, &data, &modifiedAt, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRecord(recordType, id, version, data, modifiedAt, expiresAt, sql.NullTime{})
+}
+
+func scanRecordChange(s scanner) (*databroker.Record, error) {
+	var recordType, id string
+	var version int64
+	var data []byte
+	var modifiedAt time.Time
+	var expiresAt, deletedAt sql.NullTime
+	err := s.Scan(&recordType, &id, &version, &data, &modifiedAt, &expiresAt, &deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRecord(recordType, id, version, data, modifiedAt, expiresAt, deletedAt)
+}
+
+func newRecord(
+	recordType, id string,
+	version int64,
+	data []byte,
+	modifiedAt time.Time,
+	expiresAt, deletedAt sql.NullTime,
+) (*databroker.Record, error) {
+	record := &databroker.Record{
+		Version:    uint64(version),
+		Type:       recordType,
+		Id:         id,
+		ModifiedAt: timestamppb.New(modifiedAt),
+	}
+	if data != nil {
+		record.Data = new(anypb.Any)
+		err := proto.Unmarshal(data, record.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if expiresAt.Valid {
+		record.ExpiresAt = timestamppb.New(expiresAt.Time)
+	}
+	if deletedAt.Valid {
+		record.DeletedAt = timestamppb.New(deletedAt.Time)
+	}
+	return record, nil
+}
+
+// marshalData marshals the record data, using nil (stored as NULL) for missing data.
+func marshalData(data *anypb.Any) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	bs, err := proto.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if bs == nil {
+		bs = []byte{}
+	}
+	return bs, nil
+}
@@ -0,0 +1,395 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/testutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	registrypb "github.com/pomerium/pomerium/pkg/grpc/registry"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+func TestBackend(t *testing.T) {
+	if os.Getenv("GITHUB_ACTION") != "" && runtime.GOOS == "darwin" {
+		t.Skip("Github action can not run docker on MacOS")
+	}
+
+	ctx := context.Background()
+	require.NoError(t, testutil.WithTestPostgres(func(dsn string) error {
+		backend, err := New(dsn)
+		require.NoError(t, err)
+		defer func() { _ = backend.Close() }()
+
+		require.NoError(t, backend.init(ctx))
+		versions, err := getVersions(ctx, backend.db)
+		require.NoError(t, err)
+		serverVersion := versions.GetServerVersion()
+
+		t.Run("get missing record", func(t *testing.T) {
+			record, err := backend.Get(ctx, "TYPE", "abcd")
+			require.Error(t, err)
+			assert.Nil(t, record)
+		})
+		t.Run("get record", func(t *testing.T) {
+			data := new(anypb.Any)
+			sv, err := backend.Put(ctx, &databroker.Record{
+				Type: "TYPE",
+				Id:   "abcd",
+				Data: data,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, serverVersion, sv)
+			record, err := backend.Get(ctx, "TYPE", "abcd")
+			require.NoError(t, err)
+			if assert.NotNil(t, record) {
+				assert.True(t, proto.Equal(data, record.Data))
+				assert.Nil(t, record.DeletedAt)
+				assert.Equal(t, "abcd", record.Id)
+				assert.NotNil(t, record.ModifiedAt)
+				assert.Equal(t, "TYPE", record.Type)
+				assert.Equal(t, uint64(1), record.Version)
+			}
+		})
+		t.Run("delete record", func(t *testing.T) {
+			sv, err := backend.Put(ctx, &databroker.Record{
+				Type:      "TYPE",
+				Id:        "abcd",
+				DeletedAt: timestamppb.Now(),
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, serverVersion, sv)
+			record, err := backend.Get(ctx, "TYPE", "abcd")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			assert.Nil(t, record)
+		})
+		t.Run("get all records", func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				sv, err := backend.Put(ctx, &databroker.Record{
+					Type: "TYPE",
+					Id:   fmt.Sprint(i),
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, serverVersion, sv)
+			}
+			records, versions, err := backend.GetAll(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, records, 1000)
+			assert.Equal(t, uint64(1002), versions.LatestRecordVersion)
+		})
+		t.Run("get all records of type", func(t *testing.T) {
+			sv, err := backend.Put(ctx, &databroker.Record{
+				Type: "TYPE*",
+				Id:   "1",
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, serverVersion, sv)
+			records, err := backend.GetAllOfType(ctx, "TYPE*", "")
+			assert.NoError(t, err)
+			if assert.Len(t, records, 1) {
+				assert.Equal(t, "TYPE*", records[0].GetType())
+			}
+			records, err = backend.GetAllOfType(ctx, "TYPE", "")
+			assert.NoError(t, err)
+			assert.Len(t, records, 1000)
+			records, err = backend.GetAllOfType(ctx, "TYPE", "99")
+			assert.NoError(t, err)
+			assert.Len(t, records, 11, "only 99 and 990-999 should match")
+			records, err = backend.GetAllOfType(ctx, "TYPE", "9%")
+			assert.NoError(t, err)
+			assert.Len(t, records, 0, "the prefix should be matched literally")
+		})
+		t.Run("put many", func(t *testing.T) {
+			records := []*databroker.Record{
+				{Type: "MANY", Id: "a"},
+				{Type: "MANY", Id: "b"},
+			}
+			sv, err := backend.PutMany(ctx, records, storage.PutOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, serverVersion, sv)
+			assert.Equal(t, records[0].GetVersion()+1, records[1].GetVersion())
+
+			_, err = backend.PutMany(ctx, []*databroker.Record{
+				{Type: "MANY", Id: "a", Version: records[0].GetVersion()},
+				{Type: "MANY", Id: "b", Version: records[0].GetVersion()},
+			}, storage.PutOptions{CheckVersions: true})
+			assert.ErrorIs(t, err, storage.ErrVersionConflict)
+
+			_, err = backend.PutMany(ctx, []*databroker.Record{
+				{Type: "MANY", Id: "a", Version: records[0].GetVersion()},
+				{Type: "MANY", Id: "b", Version: records[1].GetVersion()},
+			}, storage.PutOptions{CheckVersions: true})
+			assert.NoError(t, err)
+		})
+		t.Run("record expiry", func(t *testing.T) {
+			now := time.Now()
+			_, err := backend.PutMany(ctx, []*databroker.Record{
+				{Type: "EXPIRY", Id: "a", ExpiresAt: timestamppb.New(now.Add(-time.Minute))},
+				{Type: "EXPIRY", Id: "b", ExpiresAt: timestamppb.New(now.Add(time.Minute))},
+				{Type: "EXPIRY_TTL", Id: "c"},
+			}, storage.PutOptions{})
+			require.NoError(t, err)
+			require.NoError(t, backend.SetOptions(ctx, "EXPIRY_TTL", &databroker.Options{
+				Ttl: durationpb.New(time.Second),
+			}))
+
+			backend.removeExpiredRecords(ctx, now)
+			_, err = backend.Get(ctx, "EXPIRY", "a")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = backend.Get(ctx, "EXPIRY", "b")
+			assert.NoError(t, err)
+
+			backend.removeExpiredRecords(ctx, now.Add(time.Hour))
+			_, err = backend.Get(ctx, "EXPIRY", "b")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = backend.Get(ctx, "EXPIRY_TTL", "c")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		})
+		t.Run("sync", func(t *testing.T) {
+			stream, err := backend.Sync(ctx, serverVersion, 1000, storage.SyncFilter{})
+			require.NoError(t, err)
+			var records []*databroker.Record
+			for stream.Next(false) {
+				records = append(records, stream.Record())
+			}
+			assert.NoError(t, stream.Err())
+			_ = stream.Close()
+			if assert.Len(t, records, 2) {
+				assert.Equal(t, uint64(1001), records[0].GetVersion())
+				assert.Equal(t, uint64(1002), records[1].GetVersion())
+			}
+
+			_, err = backend.Sync(ctx, serverVersion+1, 0, storage.SyncFilter{})
+			assert.ErrorIs(t, err, storage.ErrInvalidServerVersion)
+		})
+		return nil
+	}))
+}
+
+func TestChangeSignal(t *testing.T) {
+	if os.Getenv("GITHUB_ACTION") != "" && runtime.GOOS == "darwin" {
+		t.Skip("Github action can not run docker on MacOS")
+	}
+
+	ctx := context.Background()
+	require.NoError(t, testutil.WithTestPostgres(func(dsn string) error {
+		ctx, clearTimeout := context.WithTimeout(ctx, time.Second*30)
+		defer clearTimeout()
+
+		done := make(chan struct{})
+		var eg errgroup.Group
+		eg.Go(func() error {
+			backend, err := New(dsn)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = backend.Close() }()
+
+			ch := backend.onChange.Bind()
+			defer backend.onChange.Unbind(ch)
+
+			select {
+			case <-ch:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			// signal the second backend that we've received the change
+			close(done)
+
+			return nil
+		})
+		eg.Go(func() error {
+			backend, err := New(dsn)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = backend.Close() }()
+
+			// put a new value to trigger a change
+			for {
+				_, err = backend.Put(ctx, &databroker.Record{
+					Type: "TYPE",
+					Id:   "ID",
+				})
+				if err != nil {
+					return err
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-done:
+					return nil
+				case <-time.After(time.Millisecond * 100):
+				}
+			}
+		})
+		assert.NoError(t, eg.Wait(), "expected signal to be fired when another backend triggers a change")
+		return nil
+	}))
+}
+
+func TestExpiry(t *testing.T) {
+	if os.Getenv("GITHUB_ACTION") != "" && runtime.GOOS == "darwin" {
+		t.Skip("Github action can not run docker on MacOS")
+	}
+
+	ctx := context.Background()
+	require.NoError(t, testutil.WithTestPostgres(func(dsn string) error {
+		backend, err := New(dsn, WithExpiry(0))
+		require.NoError(t, err)
+		defer func() { _ = backend.Close() }()
+
+		var serverVersion uint64
+		for i := 0; i < 1000; i++ {
+			serverVersion, err = backend.Put(ctx, &databroker.Record{
+				Type: "TYPE",
+				Id:   fmt.Sprint(i),
+			})
+			assert.NoError(t, err)
+		}
+		stream, err := backend.Sync(ctx, serverVersion, 0, storage.SyncFilter{})
+		require.NoError(t, err)
+		var records []*databroker.Record
+		for stream.Next(false) {
+			records = append(records, stream.Record())
+		}
+		_ = stream.Close()
+		require.Len(t, records, 1000)
+
+		backend.removeChangesBefore(ctx, time.Now().Add(time.Second))
+
+		stream, err = backend.Sync(ctx, serverVersion, 0, storage.SyncFilter{})
+		require.NoError(t, err)
+		records = nil
+		for stream.Next(false) {
+			records = append(records, stream.Record())
+		}
+		_ = stream.Close()
+		require.Len(t, records, 0)
+
+		return nil
+	}))
+}
+
+func TestCapacity(t *testing.T) {
+	if os.Getenv("GITHUB_ACTION") != "" && runtime.GOOS == "darwin" {
+		t.Skip("Github action can not run docker on MacOS")
+	}
+
+	ctx := context.Background()
+	require.NoError(t, testutil.WithTestPostgres(func(dsn string) error {
+		backend, err := New(dsn, WithExpiry(0))
+		require.NoError(t, err)
+		defer func() { _ = backend.Close() }()
+
+		err = backend.SetOptions(ctx, "EXAMPLE", &databroker.Options{
+			Capacity: proto.Uint64(3),
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			_, err = backend.Put(ctx, &databroker.Record{
+				Type: "EXAMPLE",
+				Id:   fmt.Sprint(i),
+			})
+			require.NoError(t, err)
+		}
+
+		records, _, err := backend.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, records, 3)
+
+		var ids []string
+		for _, r := range records {
+			ids = append(ids, r.GetId())
+		}
+		assert.Equal(t, []string{"7", "8", "9"}, ids, "should contain recent records")
+
+		return nil
+	}))
+}
+
+func TestLease(t *testing.T) {
+	if os.Getenv("GITHUB_ACTION") != "" && runtime.GOOS == "darwin" {
+		t.Skip("Github action can not run docker on MacOS")
+	}
+
+	ctx := context.Background()
+	require.NoError(t, testutil.WithTestPostgres(func(dsn string) error {
+		backend, err := New(dsn)
+		require.NoError(t, err)
+		defer func() { _ = backend.Close() }()
+
+		{
+			ok, err := backend.Lease(ctx, "test", "a", time.Second*30)
+			require.NoError(t, err)
+			assert.True(t, ok, "expected a to acquire the lease")
+		}
+		{
+			ok, err := backend.Lease(ctx, "test", "b", time.Second*30)
+			require.NoError(t, err)
+			assert.False(t, ok, "expected b to fail to acquire the lease")
+		}
+		{
+			ok, err := backend.Lease(ctx, "test", "a", 0)
+			require.NoError(t, err)
+			assert.False(t, ok, "expected a to clear the lease")
+		}
+		{
+			ok, err := backend.Lease(ctx, "test", "b", time.Second*30)
+			require.NoError(t, err)
+			assert.True(t, ok, "expected b to to acquire the lease")
+		}
+
+		return nil
+	}))
+}
+
+func TestRegistry(t *testing.T) {
+	if os.Getenv("GITHUB_ACTION") != "" && runtime.GOOS == "darwin" {
+		t.Skip("Github action can not run docker on MacOS")
+	}
+
+	ctx := context.Background()
+	require.NoError(t, testutil.WithTestPostgres(func(dsn string) error {
+		backend, err := New(dsn)
+		require.NoError(t, err)
+		defer func() { _ = backend.Close() }()
+
+		_, err = backend.Report(ctx, &registrypb.RegisterRequest{
+			Services: []*registrypb.Service{
+				{Kind: registrypb.ServiceKind_PROXY, Endpoint: "https://proxy2.example.com"},
+				{Kind: registrypb.ServiceKind_PROXY, Endpoint: "https://proxy1.example.com"},
+				{Kind: registrypb.ServiceKind_AUTHORIZE, Endpoint: "https://authorize.example.com"},
+			},
+		})
+		require.NoError(t, err)
+
+		lst, err := backend.List(ctx, &registrypb.ListRequest{
+			Kinds: []registrypb.ServiceKind{registrypb.ServiceKind_PROXY},
+		})
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(&registrypb.ServiceList{
+			Services: []*registrypb.Service{
+				{Kind: registrypb.ServiceKind_PROXY, Endpoint: "https://proxy1.example.com"},
+				{Kind: registrypb.ServiceKind_PROXY, Endpoint: "https://proxy2.example.com"},
+			},
+		}, lst))
+
+		return nil
+	}))
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	registrypb "github.com/pomerium/pomerium/pkg/grpc/registry"
+)
+
+// Report registers the given services with the registry.
+func (backend *Backend) Report(ctx context.Context, req *registrypb.RegisterRequest) (*registrypb.RegisterResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err := backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := backend.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, svc := range req.GetServices() {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO pomerium.services (kind, endpoint, expires_at)
+			VALUES ($1, $2, now() + make_interval(secs => $3))
+			ON CONFLICT (kind, endpoint) DO UPDATE
+			   SET expires_at=EXCLUDED.expires_at
+		`, svc.GetKind().String(), svc.GetEndpoint(), backend.cfg.registryTTL.Seconds())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// remove any services that have expired
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM pomerium.services
+		 WHERE expires_at < now()
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `SELECT pg_notify($1, '')`, serviceChangeChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &registrypb.RegisterResponse{
+		CallBackAfter: durationpb.New(backend.cfg.registryTTL / 2),
+	}, nil
+}
+
+// List lists the unexpired services of the requested kinds.
+func (backend *Backend) List(ctx context.Context, req *registrypb.ListRequest) (*registrypb.ServiceList, error) {
+	err := backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	include := map[registrypb.ServiceKind]struct{}{}
+	for _, kind := range req.GetKinds() {
+		include[kind] = struct{}{}
+	}
+
+	rows, err := backend.db.QueryContext(ctx, `
+		SELECT kind, endpoint
+		  FROM pomerium.services
+		 WHERE expires_at > now()
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	filtered := []*registrypb.Service{}
+	for rows.Next() {
+		var kind, endpoint string
+		err = rows.Scan(&kind, &endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		svcKind, ok := registrypb.ServiceKind_value[kind]
+		if !ok {
+			log.Warn(ctx).Str("kind", kind).Msg("postgres: unknown service kind")
+			continue
+		}
+		if _, ok := include[registrypb.ServiceKind(svcKind)]; !ok {
+			continue
+		}
+
+		filtered = append(filtered, &registrypb.Service{
+			Kind:     registrypb.ServiceKind(svcKind),
+			Endpoint: endpoint,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].GetKind() != filtered[j].GetKind() {
+			return filtered[i].GetKind() < filtered[j].GetKind()
+		}
+		return filtered[i].GetEndpoint() < filtered[j].GetEndpoint()
+	})
+
+	return &registrypb.ServiceList{
+		Services: filtered,
+	}, nil
+}
+
+// Watch streams the list of services whenever it changes.
+func (backend *Backend) Watch(req *registrypb.ListRequest, stream registrypb.Registry_WatchServer) error {
+	// listen for changes
+	ch := backend.onServiceChange.Bind()
+	defer backend.onServiceChange.Unbind(ch)
+
+	// force a check periodically, services may have expired
+	poll := time.NewTicker(backend.cfg.registryTTL / 2)
+	defer poll.Stop()
+
+	var prev *registrypb.ServiceList
+	for {
+		// retrieve the most recent list of services
+		lst, err := backend.List(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		// only send a new list if something changed
+		if !proto.Equal(prev, lst) {
+			err = stream.Send(lst)
+			if err != nil {
+				return err
+			}
+		}
+		prev = lst
+
+		// wait for an update
+		select {
+		case <-backend.closed:
+			return nil
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ch:
+		case <-poll.C:
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+type recordStream struct {
+	ctx     context.Context
+	backend *Backend
+
+	changed       chan context.Context
+	serverVersion uint64
+	recordVersion uint64
+	filter        storage.SyncFilter
+	ready         []*databroker.Record
+	record        *databroker.Record
+	err           error
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newRecordStream(ctx context.Context, backend *Backend, serverVersion, recordVersion uint64, filter storage.SyncFilter) *recordStream {
+	stream := &recordStream{
+		ctx:     ctx,
+		backend: backend,
+
+		changed:       backend.onChange.Bind(),
+		serverVersion: serverVersion,
+		recordVersion: recordVersion,
+		filter:        filter,
+
+		closed: make(chan struct{}),
+	}
+	// if the backend is closed, close the stream
+	go func() {
+		select {
+		case <-stream.closed:
+		case <-backend.closed:
+			_ = stream.Close()
+		}
+	}()
+	return stream
+}
+
+func (stream *recordStream) Close() error {
+	stream.closeOnce.Do(func() {
+		stream.backend.onChange.Unbind(stream.changed)
+		close(stream.closed)
+	})
+	return nil
+}
+
+func (stream *recordStream) Next(block bool) bool {
+	if stream.err != nil {
+		return false
+	}
+
+	if len(stream.ready) > 0 {
+		stream.record, stream.ready = stream.ready[0], stream.ready[1:]
+		return true
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.closed:
+			stream.err = storage.ErrStreamClosed
+			return false
+		default:
+		}
+
+		serverVersion, latestVersion, records, err := stream.backend.listChangesAfter(stream.ctx,
+			stream.recordVersion, stream.filter, recordBatchSize)
+		if err != nil {
+			stream.err = err
+			return false
+		}
+		if stream.serverVersion != serverVersion {
+			stream.err = storage.ErrInvalidServerVersion
+			return false
+		}
+
+		// update the local version to the last change checked,
+		// so changes which don't match the filter aren't checked again
+		stream.recordVersion = latestVersion
+		if len(records) > 0 {
+			stream.record, stream.ready = records[0], records[1:]
+			return true
+		}
+
+		if !block {
+			return false
+		}
+
+		select {
+		case <-stream.ctx.Done():
+			stream.err = stream.ctx.Err()
+			return false
+		case <-stream.closed:
+			stream.err = storage.ErrStreamClosed
+			return false
+		case <-ticker.C: // check again
+		case <-stream.changed: // check again
+		}
+	}
+}
+
+func (stream *recordStream) Record() *databroker.Record {
+	return stream.record
+}
+
+func (stream *recordStream) Err() error {
+	return stream.err
+}
@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+type queryOperator string
+
+const (
+	queryOperatorEqual          queryOperator = "="
+	queryOperatorNotEqual       queryOperator = "!="
+	queryOperatorLess           queryOperator = "<"
+	queryOperatorLessOrEqual    queryOperator = "<="
+	queryOperatorGreater        queryOperator = ">"
+	queryOperatorGreaterOrEqual queryOperator = ">="
+	queryOperatorContains       queryOperator = "contains"
+	queryOperatorStartsWith     queryOperator = "starts_with"
+	queryOperatorEndsWith       queryOperator = "ends_with"
+)
+
+// queryRecord is a record being evaluated by a query.
+type queryRecord struct {
+	record *databroker.Record
+	msg    protoreflect.Message
+	keys   []queryValue
+}
+
+type queryExpr interface {
+	match(r *queryRecord, now time.Time) bool
+}
+
+type queryAndExpr [2]queryExpr
+
+func (e queryAndExpr) match(r *queryRecord, now time.Time) bool {
+	return e[0].match(r, now) && e[1].match(r, now)
+}
+
+type queryOrExpr [2]queryExpr
+
+func (e queryOrExpr) match(r *queryRecord, now time.Time) bool {
+	return e[0].match(r, now) || e[1].match(r, now)
+}
+
+type queryNotExpr [1]queryExpr
+
+func (e queryNotExpr) match(r *queryRecord, now time.Time) bool {
+	return !e[0].match(r, now)
+}
+
+type queryComparisonExpr struct {
+	path  []string
+	op    queryOperator
+	value queryValue
+}
+
+func (e queryComparisonExpr) match(r *queryRecord, now time.Time) bool {
+	value := e.value
+	if value.Kind == queryValueKindNow {
+		value = queryValue{Kind: queryValueKindTime, T: now}
+	}
+
+	found := resolveQueryPath(r.record, r.msg, e.path)
+
+	switch e.op {
+	case queryOperatorNotEqual:
+		return !queryComparisonExpr{path: e.path, op: queryOperatorEqual, value: e.value}.match(r, now)
+	case queryOperatorContains:
+		// lists contain an element, strings contain a substring
+		if found.list {
+			return anyQueryValue(found.values, func(v queryValue) bool {
+				cmp, ok := compareQueryValues(v, value)
+				return ok && cmp == 0
+			})
+		}
+		return anyQueryValue(found.values, func(v queryValue) bool {
+			return v.Kind == queryValueKindString && strings.Contains(v.S, value.S)
+		})
+	case queryOperatorStartsWith:
+		return anyQueryValue(found.values, func(v queryValue) bool {
+			return v.Kind == queryValueKindString && strings.HasPrefix(v.S, value.S)
+		})
+	case queryOperatorEndsWith:
+		return anyQueryValue(found.values, func(v queryValue) bool {
+			return v.Kind == queryValueKindString && strings.HasSuffix(v.S, value.S)
+		})
+	}
+
+	// a missing value only equals null
+	if len(found.values) == 0 {
+		found.values = []queryValue{{Kind: queryValueKindNull}}
+	}
+
+	return anyQueryValue(found.values, func(v queryValue) bool {
+		cmp, ok := compareQueryValues(v, value)
+		if !ok {
+			return false
+		}
+		switch e.op {
+		case queryOperatorEqual:
+			return cmp == 0
+		case queryOperatorLess:
+			return cmp < 0
+		case queryOperatorLessOrEqual:
+			return cmp <= 0
+		case queryOperatorGreater:
+			return cmp > 0
+		case queryOperatorGreaterOrEqual:
+			return cmp >= 0
+		}
+		return false
+	})
+}
+
+func anyQueryValue(values []queryValue, f func(v queryValue) bool) bool {
+	for _, v := range values {
+		if f(v) {
+			return true
+		}
+	}
+	return false
+}
+
+type queryOrderBy struct {
+	path       []string
+	descending bool
+}
+
+// A Query is a structured query for records. Queries are evaluated against the record data using reflection, so
+// they can be used with any storage backend.
+//
+// Filters compare a field path with a value:
+//
+//     email = "a@b.com" and claims.groups contains "admins"
+//     expires_at < now() or not (user_id starts_with "svc-")
+//
+// Paths are dot-separated field names, map keys or struct keys. Paths starting with `$` refer to the record
+// itself: `$id`, `$type`, `$version` and `$modified_at`. When a path refers to a list, the comparison matches if
+// any element matches. Timestamps are compared with RFC3339 strings or `now()`.
+//
+// Records are ordered by a comma-separated list of paths, each optionally followed by `asc` or `desc`, e.g.
+// `expires_at desc, user_id`. Records are always ordered by id last, so the order is stable and can be used for
+// cursor-based pagination.
+type Query struct {
+	filter  queryExpr
+	orderBy []queryOrderBy
+	now     time.Time
+}
+
+// ParseQuery parses a query filter and order. Both may be empty.
+func ParseQuery(filter, orderBy string) (*Query, error) {
+	q := &Query{now: time.Now()}
+
+	if strings.TrimSpace(filter) != "" {
+		var err error
+		q.filter, err = parseQueryFilter(filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query filter: %w", err)
+		}
+	}
+
+	for _, field := range strings.Split(orderBy, ",") {
+		parts := strings.Fields(field)
+		if len(parts) == 0 {
+			continue
+		}
+
+		path, err := parseQueryPath(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid query order: %w", err)
+		}
+
+		o := queryOrderBy{path: path}
+		if len(parts) > 1 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				o.descending = true
+			default:
+				return nil, fmt.Errorf("invalid query order: unknown direction %q", parts[1])
+			}
+		}
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid query order: %q", field)
+		}
+		q.orderBy = append(q.orderBy, o)
+	}
+
+	return q, nil
+}
+
+// IDPrefix returns a prefix which the id of every record matched by the filter starts with, so backends can skip
+// the other records. It's derived from `$id =` and `$id starts_with` comparisons joined by `and`, and is empty if
+// the filter doesn't restrict the ids.
+func (q *Query) IDPrefix() string {
+	return getQueryIDPrefix(q.filter)
+}
+
+func getQueryIDPrefix(expr queryExpr) string {
+	switch e := expr.(type) {
+	case queryAndExpr:
+		// both sides have to match, so the longer prefix is the more selective one
+		a, b := getQueryIDPrefix(e[0]), getQueryIDPrefix(e[1])
+		if len(b) > len(a) {
+			return b
+		}
+		return a
+	case queryComparisonExpr:
+		if len(e.path) == 1 && e.path[0] == "$id" && e.value.Kind == queryValueKindString &&
+			(e.op == queryOperatorEqual || e.op == queryOperatorStartsWith) {
+			return e.value.S
+		}
+	}
+	return ""
+}
+
+// Execute filters and sorts the records.
+func (q *Query) Execute(ctx context.Context, records []*databroker.Record) []*databroker.Record {
+	var filtered []*queryRecord
+	for _, record := range records {
+		r := newQueryRecord(ctx, record)
+		if q.filter != nil && !q.filter.match(r, q.now) {
+			continue
+		}
+		r.keys = q.getKeys(r)
+		filtered = append(filtered, r)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return q.compareKeys(filtered[i].keys, filtered[i].record.GetId(), filtered[j].keys, filtered[j].record.GetId()) < 0
+	})
+
+	results := make([]*databroker.Record, 0, len(filtered))
+	for _, r := range filtered {
+		results = append(results, r.record)
+	}
+	return results
+}
+
+// After returns the records which come after the cursor. The records must already be sorted by Execute.
+func (q *Query) After(ctx context.Context, records []*databroker.Record, cursor string) ([]*databroker.Record, error) {
+	after, err := decodeQueryCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if len(after.Keys) != len(q.orderBy) {
+		return nil, fmt.Errorf("invalid query cursor: cursor does not match the query order")
+	}
+
+	for i, record := range records {
+		r := newQueryRecord(ctx, record)
+		if q.compareKeys(q.getKeys(r), record.GetId(), after.Keys, after.ID) > 0 {
+			return records[i:], nil
+		}
+	}
+	return nil, nil
+}
+
+// Cursor returns a cursor which can be passed to After to retrieve the records after the given record.
+func (q *Query) Cursor(ctx context.Context, record *databroker.Record) string {
+	bs, _ := json.Marshal(queryCursor{
+		Keys: q.getKeys(newQueryRecord(ctx, record)),
+		ID:   record.GetId(),
+	})
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+func newQueryRecord(ctx context.Context, record *databroker.Record) *queryRecord {
+	r := &queryRecord{record: record}
+	if record.GetData() != nil {
+		msg, err := record.GetData().UnmarshalNew()
+		if err != nil {
+			// ignore invalid any types
+			log.Error(ctx).Err(err).Msg("storage: invalid any type")
+		} else {
+			r.msg = msg.ProtoReflect()
+		}
+	}
+	return r
+}
+
+func (q *Query) getKeys(r *queryRecord) []queryValue {
+	keys := make([]queryValue, len(q.orderBy))
+	for i, o := range q.orderBy {
+		values := resolveQueryPath(r.record, r.msg, o.path).values
+		if len(values) > 0 {
+			keys[i] = values[0]
+		}
+	}
+	return keys
+}
+
+func (q *Query) compareKeys(aKeys []queryValue, aID string, bKeys []queryValue, bID string) int {
+	for i, o := range q.orderBy {
+		cmp, ok := compareQueryValues(aKeys[i], bKeys[i])
+		if !ok {
+			// order incomparable values by kind
+			cmp = int(aKeys[i].Kind) - int(bKeys[i].Kind)
+		}
+		if o.descending {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return strings.Compare(aID, bID)
+}
+
+type queryCursor struct {
+	Keys []queryValue `json:"k"`
+	ID   string       `json:"id"`
+}
+
+func decodeQueryCursor(raw string) (*queryCursor, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query cursor: %w", err)
+	}
+
+	var cursor queryCursor
+	err = json.Unmarshal(bs, &cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query cursor: %w", err)
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type queryTokenKind int
+
+const (
+	queryTokenEOF queryTokenKind = iota
+	queryTokenIdentifier
+	queryTokenString
+	queryTokenNumber
+	queryTokenOperator
+	queryTokenLeftParen
+	queryTokenRightParen
+)
+
+type queryToken struct {
+	kind  queryTokenKind
+	value string
+	pos   int
+}
+
+func (t queryToken) String() string {
+	if t.kind == queryTokenEOF {
+		return "end of query"
+	}
+	return strconv.Quote(t.value)
+}
+
+// tokenizeQuery splits a raw query filter into tokens.
+func tokenizeQuery(raw string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(raw); {
+		c := rune(raw[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: queryTokenLeftParen, value: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: queryTokenRightParen, value: ")", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(raw) && rune(raw[j]) != c; j++ {
+				if raw[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(raw) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			str := raw[i : j+1]
+			if c == '\'' {
+				// convert to a double-quoted string so it can be unquoted
+				str = `"` + strings.ReplaceAll(strings.ReplaceAll(str[1:len(str)-1], `\'`, `'`), `"`, `\"`) + `"`
+			}
+			value, err := strconv.Unquote(str)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %w", i, err)
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenString, value: value, pos: i})
+			i = j + 1
+		case strings.ContainsRune("=!<>", c):
+			j := i + 1
+			if j < len(raw) && raw[j] == '=' {
+				j++
+			}
+			op := raw[i:j]
+			if op == "!" {
+				return nil, fmt.Errorf("invalid operator %q at position %d", op, i)
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenOperator, value: op, pos: i})
+			i = j
+		case c == '-' || c == '.' || unicode.IsDigit(c):
+			j := i + 1
+			for ; j < len(raw) && strings.ContainsRune("0123456789.eE+-", rune(raw[j])); j++ {
+				if (raw[j] == '+' || raw[j] == '-') && raw[j-1] != 'e' && raw[j-1] != 'E' {
+					break
+				}
+			}
+			if _, err := strconv.ParseFloat(raw[i:j], 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", raw[i:j], i)
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenNumber, value: raw[i:j], pos: i})
+			i = j
+		case c == '_' || c == '$' || unicode.IsLetter(c):
+			j := i + 1
+			for ; j < len(raw); j++ {
+				r := rune(raw[j])
+				if !(r == '_' || r == '.' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					break
+				}
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenIdentifier, value: raw[i:j], pos: i})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(tokens, queryToken{kind: queryTokenEOF, pos: len(raw)}), nil
+}
+
+// queryParser is a recursive descent parser for query filters.
+//
+// The grammar is:
+//
+//     expr       = or
+//     or         = and { "or" and }
+//     and        = unary { "and" unary }
+//     unary      = "not" unary | "(" expr ")" | comparison
+//     comparison = path operator value
+//     operator   = "=" | "!=" | "<" | "<=" | ">" | ">=" | "contains" | "starts_with" | "ends_with"
+//     value      = string | number | "true" | "false" | "null" | "now()"
+//
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func parseQueryFilter(raw string) (queryExpr, error) {
+	tokens, err := tokenizeQuery(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != queryTokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos)
+	}
+	return expr, nil
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != queryTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == queryTokenIdentifier && strings.EqualFold(tok.value, keyword)
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	expr, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		expr = queryOrExpr{expr, right}
+	}
+	return expr, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	expr, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		expr = queryAndExpr{expr, right}
+	}
+	return expr, nil
+}
+
+func (p *queryParser) parseUnary() (queryExpr, error) {
+	if p.peekKeyword("not") {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNotExpr{expr}, nil
+	}
+
+	if p.peek().kind == queryTokenLeftParen {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != queryTokenRightParen {
+			return nil, fmt.Errorf("expected \")\" at position %d, got %s", tok.pos, tok)
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (queryExpr, error) {
+	tok := p.next()
+	if tok.kind != queryTokenIdentifier {
+		return nil, fmt.Errorf("expected field name at position %d, got %s", tok.pos, tok)
+	}
+	path, err := parseQueryPath(tok.value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field name at position %d: %w", tok.pos, err)
+	}
+
+	tok = p.next()
+	var op queryOperator
+	switch {
+	case tok.kind == queryTokenOperator:
+		op = queryOperator(tok.value)
+	case tok.kind == queryTokenIdentifier:
+		op = queryOperator(strings.ToLower(tok.value))
+	}
+	switch op {
+	case queryOperatorEqual, "==", queryOperatorNotEqual,
+		queryOperatorLess, queryOperatorLessOrEqual,
+		queryOperatorGreater, queryOperatorGreaterOrEqual,
+		queryOperatorContains, queryOperatorStartsWith, queryOperatorEndsWith:
+	default:
+		return nil, fmt.Errorf("expected operator at position %d, got %s", tok.pos, tok)
+	}
+	if op == "==" {
+		op = queryOperatorEqual
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return queryComparisonExpr{path: path, op: op, value: value}, nil
+}
+
+func (p *queryParser) parseValue() (queryValue, error) {
+	tok := p.next()
+	switch tok.kind {
+	case queryTokenString:
+		return queryValue{Kind: queryValueKindString, S: tok.value}, nil
+	case queryTokenNumber:
+		n, _ := strconv.ParseFloat(tok.value, 64)
+		return queryValue{Kind: queryValueKindNumber, N: n}, nil
+	case queryTokenIdentifier:
+		switch strings.ToLower(tok.value) {
+		case "true":
+			return queryValue{Kind: queryValueKindBool, B: true}, nil
+		case "false":
+			return queryValue{Kind: queryValueKindBool, B: false}, nil
+		case "null":
+			return queryValue{Kind: queryValueKindNull}, nil
+		case "now":
+			if p.next().kind != queryTokenLeftParen || p.next().kind != queryTokenRightParen {
+				return queryValue{}, fmt.Errorf("expected \"now()\" at position %d", tok.pos)
+			}
+			return queryValue{Kind: queryValueKindNow}, nil
+		}
+	}
+	return queryValue{}, fmt.Errorf("expected value at position %d, got %s", tok.pos, tok)
+}
+
+func parseQueryPath(raw string) ([]string, error) {
+	path := strings.Split(raw, ".")
+	for _, segment := range path {
+		if segment == "" {
+			return nil, fmt.Errorf("empty path segment in %q", raw)
+		}
+	}
+	return path, nil
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+func newQueryTestRecords() []*databroker.Record {
+	now := time.Now()
+	sessions := []*session.Session{
+		{
+			Id:        "s1",
+			UserId:    "u1",
+			ExpiresAt: timestamppb.New(now.Add(-time.Hour)),
+			Audience:  []string{"a", "b"},
+			Claims: map[string]*structpb.ListValue{
+				"groups": {Values: []*structpb.Value{structpb.NewStringValue("admins")}},
+			},
+		},
+		{
+			Id:        "s2",
+			UserId:    "u2",
+			ExpiresAt: timestamppb.New(now.Add(time.Hour)),
+			Audience:  []string{"c"},
+		},
+		{
+			Id:        "s3",
+			UserId:    "svc-1",
+			ExpiresAt: timestamppb.New(now.Add(2 * time.Hour)),
+			Claims: map[string]*structpb.ListValue{
+				"groups": {Values: []*structpb.Value{structpb.NewStringValue("users")}},
+			},
+		},
+	}
+
+	var records []*databroker.Record
+	for i, s := range sessions {
+		records = append(records, &databroker.Record{
+			Version: uint64(i + 1),
+			Type:    "session",
+			Id:      s.Id,
+			Data:    protoutil.NewAny(s),
+		})
+	}
+	return records
+}
+
+func getQueryRecordIDs(records []*databroker.Record) []string {
+	ids := []string{}
+	for _, r := range records {
+		ids = append(ids, r.GetId())
+	}
+	return ids
+}
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	records := newQueryTestRecords()
+
+	for _, tc := range []struct {
+		filter  string
+		orderBy string
+		expect  []string
+	}{
+		{"", "", []string{"s1", "s2", "s3"}},
+		{`user_id = "u1"`, "", []string{"s1"}},
+		{`userId == 'u2'`, "", []string{"s2"}},
+		{`user_id != "u1"`, "", []string{"s2", "s3"}},
+		{`user_id starts_with "svc-"`, "", []string{"s3"}},
+		{`not (user_id starts_with "svc-")`, "", []string{"s1", "s2"}},
+		{`user_id ends_with "2" or user_id = "u1"`, "", []string{"s1", "s2"}},
+		{`user_id contains "vc"`, "", []string{"s3"}},
+		{`audience contains "b"`, "", []string{"s1"}},
+		{`audience = "c"`, "", []string{"s2"}},
+		{`claims.groups contains "admins"`, "", []string{"s1"}},
+		{`claims.groups = null`, "", []string{"s2"}},
+		{`expires_at < now()`, "", []string{"s1"}},
+		{`expires_at > now() AND user_id != "svc-1"`, "", []string{"s2"}},
+		{`impersonate_session_id = null`, "", []string{"s1", "s2", "s3"}},
+		{`$version >= 2`, "", []string{"s2", "s3"}},
+		{`$id = "s3"`, "", []string{"s3"}},
+		{"", "expires_at desc", []string{"s3", "s2", "s1"}},
+		{"", "user_id desc", []string{"s2", "s1", "s3"}},
+		{`$version > 1`, "$version DESC, $id", []string{"s3", "s2"}},
+	} {
+		t.Run(tc.filter+"|"+tc.orderBy, func(t *testing.T) {
+			q, err := ParseQuery(tc.filter, tc.orderBy)
+			require.NoError(t, err)
+			results := q.Execute(ctx, records)
+			assert.Equal(t, tc.expect, getQueryRecordIDs(results))
+		})
+	}
+}
+
+func TestQuery_IDPrefix(t *testing.T) {
+	for _, tc := range []struct {
+		filter string
+		expect string
+	}{
+		{"", ""},
+		{`$id = "s3"`, "s3"},
+		{`$id starts_with "s"`, "s"},
+		{`$id starts_with "s" and user_id = "u1"`, "s"},
+		{`$id starts_with "s" and $id = "s1"`, "s1"},
+		{`$id starts_with "s" or user_id = "u1"`, ""},
+		{`not ($id starts_with "s")`, ""},
+		{`$id ends_with "s"`, ""},
+		{`$id = 1`, ""},
+		{`id = "s1"`, ""},
+	} {
+		q, err := ParseQuery(tc.filter, "")
+		require.NoError(t, err)
+		assert.Equal(t, tc.expect, q.IDPrefix(), "filter: %s", tc.filter)
+	}
+}
+
+func TestQueryCursor(t *testing.T) {
+	ctx := context.Background()
+	records := newQueryTestRecords()
+
+	q, err := ParseQuery("", "expires_at desc")
+	require.NoError(t, err)
+	results := q.Execute(ctx, records)
+
+	after, err := q.After(ctx, results, q.Cursor(ctx, results[0]))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s2", "s1"}, getQueryRecordIDs(after))
+
+	after, err = q.After(ctx, results, q.Cursor(ctx, results[2]))
+	require.NoError(t, err)
+	assert.Empty(t, after)
+
+	_, err = q.After(ctx, results, "not-a-cursor")
+	assert.Error(t, err)
+
+	other, err := ParseQuery("", "")
+	require.NoError(t, err)
+	_, err = other.After(ctx, results, q.Cursor(ctx, results[0]))
+	assert.Error(t, err, "should reject a cursor for a different order")
+}
+
+func TestParseQuery(t *testing.T) {
+	for _, tc := range []struct {
+		filter  string
+		orderBy string
+	}{
+		{`user_id`, ""},
+		{`user_id = `, ""},
+		{`user_id ~ "x"`, ""},
+		{`user_id = "x`, ""},
+		{`(user_id = "x"`, ""},
+		{`user_id = "x" and`, ""},
+		{`user_id = now`, ""},
+		{`user..id = "x"`, ""},
+		{"", "user_id sideways"},
+		{"", "user_id asc desc"},
+	} {
+		_, err := ParseQuery(tc.filter, tc.orderBy)
+		assert.Error(t, err, "expected %q / %q to fail", tc.filter, tc.orderBy)
+	}
+}
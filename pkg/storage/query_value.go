@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+type queryValueKind int
+
+const (
+	queryValueKindNull queryValueKind = iota
+	queryValueKindString
+	queryValueKindNumber
+	queryValueKindBool
+	queryValueKindTime
+	// queryValueKindNow is replaced with the current time when a query is evaluated.
+	queryValueKindNow
+)
+
+// A queryValue is a value extracted from a record, or a literal in a query.
+type queryValue struct {
+	Kind queryValueKind `json:"k"`
+	S    string         `json:"s,omitempty"`
+	N    float64        `json:"n,omitempty"`
+	B    bool           `json:"b,omitempty"`
+	T    time.Time      `json:"t,omitempty"`
+}
+
+// compareQueryValues compares two values. If the values cannot be compared, ok is false.
+func compareQueryValues(a, b queryValue) (cmp int, ok bool) {
+	if a.Kind == queryValueKindNull || b.Kind == queryValueKindNull {
+		switch {
+		case a.Kind == b.Kind:
+			return 0, true
+		case a.Kind == queryValueKindNull:
+			return -1, true
+		default:
+			return 1, true
+		}
+	}
+
+	// coerce strings to the type of the other value
+	if a.Kind == queryValueKindString && b.Kind != queryValueKindString {
+		var ok bool
+		if a, ok = coerceQueryValue(a, b.Kind); !ok {
+			return 0, false
+		}
+	} else if b.Kind == queryValueKindString && a.Kind != queryValueKindString {
+		var ok bool
+		if b, ok = coerceQueryValue(b, a.Kind); !ok {
+			return 0, false
+		}
+	}
+
+	if a.Kind != b.Kind {
+		return 0, false
+	}
+
+	switch a.Kind {
+	case queryValueKindString:
+		return strings.Compare(a.S, b.S), true
+	case queryValueKindNumber:
+		switch {
+		case a.N < b.N:
+			return -1, true
+		case a.N > b.N:
+			return 1, true
+		}
+		return 0, true
+	case queryValueKindBool:
+		switch {
+		case a.B == b.B:
+			return 0, true
+		case !a.B:
+			return -1, true
+		}
+		return 1, true
+	case queryValueKindTime:
+		switch {
+		case a.T.Before(b.T):
+			return -1, true
+		case a.T.After(b.T):
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func coerceQueryValue(v queryValue, kind queryValueKind) (queryValue, bool) {
+	switch kind {
+	case queryValueKindNumber:
+		n, err := strconv.ParseFloat(v.S, 64)
+		if err != nil {
+			return v, false
+		}
+		return queryValue{Kind: queryValueKindNumber, N: n}, true
+	case queryValueKindBool:
+		b, err := strconv.ParseBool(v.S)
+		if err != nil {
+			return v, false
+		}
+		return queryValue{Kind: queryValueKindBool, B: b}, true
+	case queryValueKindTime:
+		t, err := time.Parse(time.RFC3339Nano, v.S)
+		if err != nil {
+			return v, false
+		}
+		return queryValue{Kind: queryValueKindTime, T: t}, true
+	}
+	return v, false
+}
+
+// queryValues are the values found at a path.
+type queryValues struct {
+	values []queryValue
+	// list is true when the values are the elements of a list.
+	list bool
+}
+
+// resolveQueryPath returns all the values found at path. Paths starting with `$` refer to the record
+// (`$id`, `$type`, `$version`, `$modified_at`), all other paths refer to the record data.
+func resolveQueryPath(record *databroker.Record, msg protoreflect.Message, path []string) queryValues {
+	if strings.HasPrefix(path[0], "$") {
+		if len(path) != 1 {
+			return queryValues{}
+		}
+		switch path[0] {
+		case "$id":
+			return queryValues{values: []queryValue{{Kind: queryValueKindString, S: record.GetId()}}}
+		case "$type":
+			return queryValues{values: []queryValue{{Kind: queryValueKindString, S: record.GetType()}}}
+		case "$version":
+			return queryValues{values: []queryValue{{Kind: queryValueKindNumber, N: float64(record.GetVersion())}}}
+		case "$modified_at":
+			return queryValues{values: []queryValue{{Kind: queryValueKindTime, T: record.GetModifiedAt().AsTime()}}}
+		}
+		return queryValues{}
+	}
+
+	if msg == nil {
+		return queryValues{}
+	}
+
+	var result queryValues
+	resolveQueryMessage(&result, msg, path)
+	return result
+}
+
+func resolveQueryMessage(result *queryValues, msg protoreflect.Message, path []string) {
+	switch msg.Descriptor().FullName() {
+	case "google.protobuf.Timestamp":
+		if len(path) == 0 {
+			fds := msg.Descriptor().Fields()
+			t := time.Unix(msg.Get(fds.ByName("seconds")).Int(), msg.Get(fds.ByName("nanos")).Int())
+			result.values = append(result.values, queryValue{Kind: queryValueKindTime, T: t.UTC()})
+		}
+		return
+	case "google.protobuf.Duration":
+		if len(path) == 0 {
+			fds := msg.Descriptor().Fields()
+			d := time.Duration(msg.Get(fds.ByName("seconds")).Int())*time.Second +
+				time.Duration(msg.Get(fds.ByName("nanos")).Int())
+			result.values = append(result.values, queryValue{Kind: queryValueKindNumber, N: d.Seconds()})
+		}
+		return
+	case "google.protobuf.Struct":
+		if len(path) == 0 {
+			return
+		}
+		fd := msg.Descriptor().Fields().ByName("fields")
+		v := msg.Get(fd).Map().Get(protoreflect.ValueOfString(path[0]).MapKey())
+		if v.IsValid() {
+			resolveQueryMessage(result, v.Message(), path[1:])
+		}
+		return
+	case "google.protobuf.ListValue":
+		fd := msg.Descriptor().Fields().ByName("values")
+		lst := msg.Get(fd).List()
+		for i := 0; i < lst.Len(); i++ {
+			resolveQueryMessage(result, lst.Get(i).Message(), path)
+		}
+		result.list = true
+		return
+	case "google.protobuf.Value":
+		od := msg.Descriptor().Oneofs().ByName("kind")
+		fd := msg.WhichOneof(od)
+		if fd == nil {
+			return
+		}
+		switch {
+		case fd.Kind() == protoreflect.MessageKind:
+			resolveQueryMessage(result, msg.Get(fd).Message(), path)
+		case len(path) > 0:
+		case fd.Kind() == protoreflect.EnumKind:
+			result.values = append(result.values, queryValue{Kind: queryValueKindNull})
+		default:
+			result.values = append(result.values, scalarQueryValue(fd, msg.Get(fd)))
+		}
+		return
+	case "google.protobuf.StringValue", "google.protobuf.BoolValue",
+		"google.protobuf.Int32Value", "google.protobuf.Int64Value",
+		"google.protobuf.UInt32Value", "google.protobuf.UInt64Value",
+		"google.protobuf.FloatValue", "google.protobuf.DoubleValue":
+		if len(path) == 0 {
+			fd := msg.Descriptor().Fields().ByName("value")
+			result.values = append(result.values, scalarQueryValue(fd, msg.Get(fd)))
+		}
+		return
+	}
+
+	if len(path) == 0 {
+		return
+	}
+
+	fds := msg.Descriptor().Fields()
+	fd := fds.ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		fd = fds.ByJSONName(path[0])
+	}
+	if fd == nil {
+		return
+	}
+
+	switch {
+	case fd.IsList():
+		lst := msg.Get(fd).List()
+		for i := 0; i < lst.Len(); i++ {
+			resolveQueryFieldValue(result, fd, lst.Get(i), path[1:])
+		}
+		result.list = true
+	case fd.IsMap():
+		if len(path) < 2 {
+			return
+		}
+		var key protoreflect.MapKey
+		switch fd.MapKey().Kind() {
+		case protoreflect.StringKind:
+			key = protoreflect.ValueOfString(path[1]).MapKey()
+		default:
+			return
+		}
+		v := msg.Get(fd).Map().Get(key)
+		if v.IsValid() {
+			resolveQueryFieldValue(result, fd.MapValue(), v, path[2:])
+		}
+	case fd.HasPresence() && !msg.Has(fd):
+		// unset messages and optional fields have no values
+	default:
+		resolveQueryFieldValue(result, fd, msg.Get(fd), path[1:])
+	}
+}
+
+func resolveQueryFieldValue(result *queryValues, fd protoreflect.FieldDescriptor, v protoreflect.Value, path []string) {
+	if fd.Message() != nil {
+		resolveQueryMessage(result, v.Message(), path)
+		return
+	}
+	if len(path) == 0 {
+		result.values = append(result.values, scalarQueryValue(fd, v))
+	}
+}
+
+func scalarQueryValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) queryValue {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return queryValue{Kind: queryValueKindBool, B: v.Bool()}
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return queryValue{Kind: queryValueKindString, S: string(ev.Name())}
+		}
+		return queryValue{Kind: queryValueKindNumber, N: float64(v.Enum())}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return queryValue{Kind: queryValueKindNumber, N: float64(v.Int())}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return queryValue{Kind: queryValueKindNumber, N: float64(v.Uint())}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return queryValue{Kind: queryValueKindNumber, N: v.Float()}
+	case protoreflect.StringKind:
+		return queryValue{Kind: queryValueKindString, S: v.String()}
+	}
+	return queryValue{Kind: queryValueKindNull}
+}
@@ -0,0 +1,118 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+// updateExpiration updates the expiration of a record after it has been changed.
+func updateExpiration(ctx context.Context, c redis.Cmdable, record *databroker.Record, options *databroker.Options) error {
+	member, err := getExpirationMember(record.GetType(), record.GetId())
+	if err != nil {
+		return err
+	}
+
+	expiresAt, ok := storage.GetRecordExpiry(record, options)
+	if !ok || record.GetDeletedAt() != nil {
+		c.ZRem(ctx, expirationsKey, member)
+		return nil
+	}
+
+	c.ZAdd(ctx, expirationsKey, &redis.Z{
+		Score:  getExpirationScore(expiresAt),
+		Member: member,
+	})
+	return nil
+}
+
+// updateExpirations updates the expiration of every record of the given type after its options have changed.
+func (backend *Backend) updateExpirations(ctx context.Context, recordType string, options *databroker.Options) error {
+	records, err := backend.GetAllOfType(ctx, recordType, "")
+	if err != nil {
+		return err
+	}
+
+	p := backend.client.Pipeline()
+	for _, record := range records {
+		err = updateExpiration(ctx, p, record, options)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = p.Exec(ctx)
+	return err
+}
+
+// removeExpiredRecords deletes every record which expired before now.
+func (backend *Backend) removeExpiredRecords(ctx context.Context, now time.Time) {
+	members, err := backend.client.ZRangeByScore(ctx, expirationsKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatFloat(getExpirationScore(now), 'f', -1, 64),
+	}).Result()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("redis: error retrieving expired records")
+		return
+	}
+
+	for _, member := range members {
+		err = backend.removeExpiredRecord(ctx, member, now)
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("redis: error removing expired record")
+		}
+	}
+}
+
+func (backend *Backend) removeExpiredRecord(ctx context.Context, member string, now time.Time) error {
+	var key databroker.Record
+	err := proto.Unmarshal([]byte(member), &key)
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("redis: invalid expiration detected")
+		return backend.client.ZRem(ctx, expirationsKey, member).Err()
+	}
+
+	record, err := backend.Get(ctx, key.GetType(), key.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return backend.client.ZRem(ctx, expirationsKey, member).Err()
+	} else if err != nil {
+		return err
+	}
+
+	// the record may have been updated since the expirations were retrieved
+	options, err := backend.GetOptions(ctx, record.GetType())
+	if err != nil {
+		return err
+	}
+	if expiresAt, ok := storage.GetRecordExpiry(record, options); !ok || expiresAt.After(now) {
+		return nil
+	}
+
+	// only delete the record if it hasn't changed since it was retrieved, this also removes the expiration
+	record.DeletedAt = timestamppb.New(now)
+	err = backend.put(ctx, []*databroker.Record{record}, storage.PutOptions{CheckVersions: true})
+	if errors.Is(err, storage.ErrVersionConflict) {
+		return nil
+	}
+	return err
+}
+
+func getExpirationMember(recordType, recordID string) (string, error) {
+	bs, err := proto.MarshalOptions{Deterministic: true}.Marshal(&databroker.Record{
+		Type: recordType,
+		Id:   recordID,
+	})
+	return string(bs), err
+}
+
+func getExpirationScore(expiresAt time.Time) float64 {
+	return float64(expiresAt.UnixNano()) / float64(time.Second)
+}
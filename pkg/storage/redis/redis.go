@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 const (
 	maxTransactionRetries = 100
 	watchPollInterval     = 30 * time.Second
+	recordExpiryInterval  = 5 * time.Second
 
 	// we rely on transactions in redis, so all redis-cluster keys need to be
 	// on the same node. Using a `hash tag` gives us this capability.
@@ -35,6 +37,7 @@ const (
 	recordHashKey    = redisutil.KeyPrefix + "records"
 	changesSetKey    = redisutil.KeyPrefix + "changes"
 	optionsKey       = redisutil.KeyPrefix + "options"
+	expirationsKey   = redisutil.KeyPrefix + "expirations"
 
 	recordTypeChangesKeyTpl = redisutil.KeyPrefix + "changes.%s"
 	leaseKeyTpl             = "{pomerium_v3}.lease.%s"
@@ -56,6 +59,8 @@ var (
 // - options: a Hash of options. The hash key is {recordType}, the hash value the protobuf options.
 // - changes.{recordType}: a Sorted Set of the changes for a record type. The score is the current time,
 //   the value the record id.
+// - expirations: a Sorted Set of the records which expire. The score is the unix time the record expires, the
+//   member a protobuf record containing only the record type and id.
 //
 // Records stored in these keys are typically encrypted.
 type Backend struct {
@@ -99,6 +104,19 @@ func New(rawURL string, options ...Option) (*Backend, error) {
 			}
 		}()
 	}
+	go func() {
+		ticker := time.NewTicker(recordExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-backend.closed:
+				return
+			case <-ticker.C:
+			}
+
+			backend.removeExpiredRecords(ctx, time.Now())
+		}
+	}()
 	return backend, nil
 }
 
@@ -184,9 +202,71 @@ func (backend *Backend) GetAll(ctx context.Context) (records []*databroker.Recor
 	return records, versions, nil
 }
 
+// GetAllOfType gets all the records of the given type whose id starts with idPrefix from redis.
+func (backend *Backend) GetAllOfType(ctx context.Context, recordType, idPrefix string) (records []*databroker.Record, err error) {
+	ctx, span := trace.StartSpan(ctx, "databroker.redis.GetAllOfType")
+	defer span.End()
+	defer func(start time.Time) { recordOperation(ctx, start, "getalloftype", err) }(time.Now())
+
+	// only scan the fields for the given type and id prefix
+	_, match := getHashKey(escapeGlob(recordType), escapeGlob(idPrefix)+"*")
+
+	var cursor uint64
+	for {
+		var kvs []string
+		kvs, cursor, err = backend.client.HScan(ctx, recordHashKey, cursor, match, 1000).Result()
+		if err != nil {
+			return nil, fmt.Errorf("redis: error retrieving GetAllOfType records: %w", err)
+		}
+
+		// HSCAN returns alternating fields and values
+		for i := 1; i < len(kvs); i += 2 {
+			var record databroker.Record
+			err := proto.Unmarshal([]byte(kvs[i]), &record)
+			if err != nil {
+				log.Warn(ctx).Err(err).Msg("redis: invalid record detected")
+				continue
+			}
+			// types may contain slashes, so the pattern can match other types too
+			if record.GetType() != recordType || !strings.HasPrefix(record.GetId(), idPrefix) {
+				continue
+			}
+			records = append(records, &record)
+		}
+
+		if cursor == 0 {
+			break
+		}
+	}
+	return records, nil
+}
+
 // GetOptions gets the options for the given record type.
 func (backend *Backend) GetOptions(ctx context.Context, recordType string) (*databroker.Options, error) {
-	raw, err := backend.client.HGet(ctx, optionsKey, recordType).Result()
+	return getOptions(ctx, backend.client, recordType)
+}
+
+// GetAllOptions gets the options of every record type with options set.
+func (backend *Backend) GetAllOptions(ctx context.Context) (map[string]*databroker.Options, error) {
+	raw, err := backend.client.HGetAll(ctx, optionsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]*databroker.Options, len(raw))
+	for recordType, rawOptions := range raw {
+		var options databroker.Options
+		err = proto.Unmarshal([]byte(rawOptions), &options)
+		if err != nil {
+			return nil, err
+		}
+		all[recordType] = &options
+	}
+	return all, nil
+}
+
+func getOptions(ctx context.Context, c redis.Cmdable, recordType string) (*databroker.Options, error) {
+	raw, err := c.HGet(ctx, optionsKey, recordType).Result()
 	if err == redis.Nil {
 		// treat no options as an empty set of options
 		return new(databroker.Options), nil
@@ -242,23 +322,42 @@ func (backend *Backend) Lease(ctx context.Context, leaseName, leaseID string, tt
 
 // Put puts a record into redis.
 func (backend *Backend) Put(ctx context.Context, record *databroker.Record) (serverVersion uint64, err error) {
-	ctx, span := trace.StartSpan(ctx, "databroker.redis.Put")
+	return backend.PutMany(ctx, []*databroker.Record{record}, storage.PutOptions{})
+}
+
+// PutMany writes multiple records to redis in a single transaction.
+func (backend *Backend) PutMany(ctx context.Context, records []*databroker.Record, options storage.PutOptions) (serverVersion uint64, err error) {
+	ctx, span := trace.StartSpan(ctx, "databroker.redis.PutMany")
 	defer span.End()
 	defer func(start time.Time) { recordOperation(ctx, start, "put", err) }(time.Now())
 
+	for _, record := range records {
+		if record == nil {
+			return 0, fmt.Errorf("records cannot be nil")
+		}
+	}
+
 	serverVersion, err = backend.getOrCreateServerVersion(ctx)
 	if err != nil {
 		return serverVersion, err
 	}
 
-	err = backend.put(ctx, record)
+	err = backend.put(ctx, records, options)
 	if err != nil {
 		return serverVersion, err
 	}
 
-	err = backend.enforceOptions(ctx, record.GetType())
-	if err != nil {
-		return serverVersion, err
+	recordTypes := map[string]struct{}{}
+	for _, record := range records {
+		if _, ok := recordTypes[record.GetType()]; ok {
+			continue
+		}
+		recordTypes[record.GetType()] = struct{}{}
+
+		err = backend.enforceOptions(ctx, record.GetType())
+		if err != nil {
+			return serverVersion, err
+		}
 	}
 
 	return serverVersion, nil
@@ -269,6 +368,11 @@ func (backend *Backend) SetOptions(ctx context.Context, recordType string, optio
 	ctx, span := trace.StartSpan(ctx, "databroker.redis.SetOptions")
 	defer span.End()
 
+	previous, err := backend.GetOptions(ctx, recordType)
+	if err != nil {
+		return err
+	}
+
 	bs, err := proto.Marshal(options)
 	if err != nil {
 		return err
@@ -280,6 +384,14 @@ func (backend *Backend) SetOptions(ctx context.Context, recordType string, optio
 		return err
 	}
 
+	// the expiration of every record of the type changes with the ttl
+	if !proto.Equal(previous.GetTtl(), options.GetTtl()) {
+		err = backend.updateExpirations(ctx, recordType, options)
+		if err != nil {
+			return err
+		}
+	}
+
 	// possibly re-enforce options
 	err = backend.enforceOptions(ctx, recordType)
 	if err != nil {
@@ -290,41 +402,91 @@ func (backend *Backend) SetOptions(ctx context.Context, recordType string, optio
 }
 
 // Sync returns a record stream of any records changed after the specified recordVersion.
-func (backend *Backend) Sync(ctx context.Context, serverVersion, recordVersion uint64) (storage.RecordStream, error) {
-	return newRecordStream(ctx, backend, serverVersion, recordVersion), nil
+func (backend *Backend) Sync(ctx context.Context, serverVersion, recordVersion uint64, filter storage.SyncFilter) (storage.RecordStream, error) {
+	return newRecordStream(ctx, backend, serverVersion, recordVersion, filter), nil
 }
 
-func (backend *Backend) put(ctx context.Context, record *databroker.Record) error {
-	return backend.incrementVersion(ctx,
+func (backend *Backend) put(ctx context.Context, records []*databroker.Record, options storage.PutOptions) error {
+	recordOptions := map[string]*databroker.Options{}
+	return backend.incrementVersion(ctx, uint64(len(records)),
 		func(tx *redis.Tx, version uint64) error {
-			record.ModifiedAt = timestamppb.Now()
-			record.Version = version
+			for _, record := range records {
+				if _, ok := recordOptions[record.GetType()]; ok {
+					continue
+				}
+				o, err := getOptions(ctx, tx, record.GetType())
+				if err != nil {
+					return err
+				}
+				recordOptions[record.GetType()] = o
+			}
+
+			// every write increments the watched last version key,
+			// so the records can't change until the transaction is committed
+			if options.CheckVersions {
+				err := storage.CheckVersions(records, version, func(recordType, recordID string) (uint64, error) {
+					return getRecordVersion(ctx, tx, recordType, recordID)
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			now := timestamppb.Now()
+			for i, record := range records {
+				record.ModifiedAt = options.GetModifiedAt(record, now)
+				record.Version = version + uint64(i)
+			}
 			return nil
 		},
 		func(p redis.Pipeliner, version uint64) error {
-			bs, err := proto.Marshal(record)
-			if err != nil {
-				return err
-			}
+			for _, record := range records {
+				bs, err := proto.Marshal(record)
+				if err != nil {
+					return err
+				}
 
-			key, field := getHashKey(record.GetType(), record.GetId())
-			if record.DeletedAt != nil {
-				p.HDel(ctx, key, field)
-			} else {
-				p.HSet(ctx, key, field, bs)
-				p.ZAdd(ctx, getRecordTypeChangesKey(record.GetType()), &redis.Z{
-					Score:  float64(record.GetModifiedAt().GetSeconds()),
-					Member: record.GetId(),
+				key, field := getHashKey(record.GetType(), record.GetId())
+				if record.DeletedAt != nil {
+					p.HDel(ctx, key, field)
+				} else {
+					p.HSet(ctx, key, field, bs)
+					p.ZAdd(ctx, getRecordTypeChangesKey(record.GetType()), &redis.Z{
+						Score:  float64(record.GetModifiedAt().GetSeconds()),
+						Member: record.GetId(),
+					})
+				}
+				err = updateExpiration(ctx, p, record, recordOptions[record.GetType()])
+				if err != nil {
+					return err
+				}
+				p.ZAdd(ctx, changesSetKey, &redis.Z{
+					Score:  float64(record.GetVersion()),
+					Member: bs,
 				})
 			}
-			p.ZAdd(ctx, changesSetKey, &redis.Z{
-				Score:  float64(version),
-				Member: bs,
-			})
 			return nil
 		})
 }
 
+// getRecordVersion returns the version of a record, or 0 if the record doesn't exist.
+func getRecordVersion(ctx context.Context, c redis.Cmdable, recordType, recordID string) (uint64, error) {
+	key, field := getHashKey(recordType, recordID)
+	raw, err := c.HGet(ctx, key, field).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	var record databroker.Record
+	err = proto.Unmarshal([]byte(raw), &record)
+	if err != nil {
+		return 0, err
+	}
+	return record.GetVersion(), nil
+}
+
 // enforceOptions enforces the options for the given record type.
 func (backend *Backend) enforceOptions(ctx context.Context, recordType string) error {
 	ctx, span := trace.StartSpan(ctx, "databroker.redis.enforceOptions")
@@ -354,7 +516,7 @@ func (backend *Backend) enforceOptions(ctx context.Context, recordType string) e
 		if err == nil {
 			// mark the record as deleted and re-submit
 			record.DeletedAt = timestamppb.Now()
-			err = backend.put(ctx, record)
+			err = backend.put(ctx, []*databroker.Record{record}, storage.PutOptions{})
 			if err != nil {
 				return err
 			}
@@ -374,9 +536,10 @@ func (backend *Backend) enforceOptions(ctx context.Context, recordType string) e
 	return nil
 }
 
-// incrementVersion increments the last recordVersion key, runs the code in `query`, then attempts to commit the code in
-// `commit`. If the last recordVersion changes in the interim, we will retry the transaction.
-func (backend *Backend) incrementVersion(ctx context.Context,
+// incrementVersion increments the last recordVersion key by count, runs the code in `query`, then attempts to commit
+// the code in `commit`. Both are passed the first of the new recordVersions. If the last recordVersion changes in the
+// interim, we will retry the transaction.
+func (backend *Backend) incrementVersion(ctx context.Context, count uint64,
 	query func(tx *redis.Tx, recordVersion uint64) error,
 	commit func(p redis.Pipeliner, recordVersion uint64) error,
 ) error {
@@ -401,8 +564,8 @@ func (backend *Backend) incrementVersion(ctx context.Context,
 			if err != nil {
 				return err
 			}
-			p.Set(ctx, lastVersionKey, version, 0)
-			p.Publish(ctx, lastVersionChKey, version)
+			p.Set(ctx, lastVersionKey, version+count-1, 0)
+			p.Publish(ctx, lastVersionChKey, version+count-1)
 			return nil
 		})
 		return err
@@ -525,6 +688,19 @@ func getRecordTypeChangesKey(recordType string) string {
 	return fmt.Sprintf(recordTypeChangesKeyTpl, recordType)
 }
 
+// escapeGlob escapes the special characters in a redis glob-style pattern.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '^', '-', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
 func getHashKey(recordType, id string) (key, field string) {
 	return recordHashKey, fmt.Sprintf("%s/%s", recordType, id)
 }
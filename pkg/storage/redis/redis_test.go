@@ -13,10 +13,12 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/internal/testutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
 )
 
 func TestBackend(t *testing.T) {
@@ -88,6 +90,74 @@ func TestBackend(t *testing.T) {
 			assert.Len(t, records, 1000)
 			assert.Equal(t, uint64(1002), versions.LatestRecordVersion)
 		})
+		t.Run("get all records of type", func(t *testing.T) {
+			sv, err := backend.Put(ctx, &databroker.Record{
+				Type: "TYPE*",
+				Id:   "1",
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, serverVersion, sv)
+			records, err := backend.GetAllOfType(ctx, "TYPE*", "")
+			assert.NoError(t, err)
+			if assert.Len(t, records, 1) {
+				assert.Equal(t, "TYPE*", records[0].GetType())
+			}
+			records, err = backend.GetAllOfType(ctx, "TYPE", "")
+			assert.NoError(t, err)
+			assert.Len(t, records, 1000)
+			records, err = backend.GetAllOfType(ctx, "TYPE", "99")
+			assert.NoError(t, err)
+			assert.Len(t, records, 11, "only 99 and 990-999 should match")
+			records, err = backend.GetAllOfType(ctx, "TYPE", "9*")
+			assert.NoError(t, err)
+			assert.Len(t, records, 0, "the prefix should be matched literally")
+		})
+		t.Run("put many", func(t *testing.T) {
+			records := []*databroker.Record{
+				{Type: "MANY", Id: "a"},
+				{Type: "MANY", Id: "b"},
+			}
+			sv, err := backend.PutMany(ctx, records, storage.PutOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, serverVersion, sv)
+			assert.Equal(t, records[0].GetVersion()+1, records[1].GetVersion())
+
+			_, err = backend.PutMany(ctx, []*databroker.Record{
+				{Type: "MANY", Id: "a", Version: records[0].GetVersion()},
+				{Type: "MANY", Id: "b", Version: records[0].GetVersion()},
+			}, storage.PutOptions{CheckVersions: true})
+			assert.ErrorIs(t, err, storage.ErrVersionConflict)
+
+			_, err = backend.PutMany(ctx, []*databroker.Record{
+				{Type: "MANY", Id: "a", Version: records[0].GetVersion()},
+				{Type: "MANY", Id: "b", Version: records[1].GetVersion()},
+			}, storage.PutOptions{CheckVersions: true})
+			assert.NoError(t, err)
+		})
+		t.Run("record expiry", func(t *testing.T) {
+			now := time.Now()
+			_, err := backend.PutMany(ctx, []*databroker.Record{
+				{Type: "EXPIRY", Id: "a", ExpiresAt: timestamppb.New(now.Add(-time.Minute))},
+				{Type: "EXPIRY", Id: "b", ExpiresAt: timestamppb.New(now.Add(time.Minute))},
+				{Type: "EXPIRY_TTL", Id: "c"},
+			}, storage.PutOptions{})
+			require.NoError(t, err)
+			require.NoError(t, backend.SetOptions(ctx, "EXPIRY_TTL", &databroker.Options{
+				Ttl: durationpb.New(time.Second),
+			}))
+
+			backend.removeExpiredRecords(ctx, now)
+			_, err = backend.Get(ctx, "EXPIRY", "a")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = backend.Get(ctx, "EXPIRY", "b")
+			assert.NoError(t, err)
+
+			backend.removeExpiredRecords(ctx, now.Add(time.Hour))
+			_, err = backend.Get(ctx, "EXPIRY", "b")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = backend.Get(ctx, "EXPIRY_TTL", "c")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		})
 		return nil
 	}
 
@@ -203,7 +273,7 @@ func TestExpiry(t *testing.T) {
 			})
 			assert.NoError(t, err)
 		}
-		stream, err := backend.Sync(ctx, serverVersion, 0)
+		stream, err := backend.Sync(ctx, serverVersion, 0, storage.SyncFilter{})
 		require.NoError(t, err)
 		var records []*databroker.Record
 		for stream.Next(false) {
@@ -214,7 +284,7 @@ func TestExpiry(t *testing.T) {
 
 		backend.removeChangesBefore(ctx, time.Now().Add(time.Second))
 
-		stream, err = backend.Sync(ctx, serverVersion, 0)
+		stream, err = backend.Sync(ctx, serverVersion, 0, storage.SyncFilter{})
 		require.NoError(t, err)
 		records = nil
 		for stream.Next(false) {
@@ -21,6 +21,7 @@ type recordStream struct {
 	changed       chan context.Context
 	serverVersion uint64
 	recordVersion uint64
+	filter        storage.SyncFilter
 	record        *databroker.Record
 	err           error
 
@@ -28,7 +29,7 @@ type recordStream struct {
 	closed    chan struct{}
 }
 
-func newRecordStream(ctx context.Context, backend *Backend, serverVersion, recordVersion uint64) *recordStream {
+func newRecordStream(ctx context.Context, backend *Backend, serverVersion, recordVersion uint64, filter storage.SyncFilter) *recordStream {
 	stream := &recordStream{
 		ctx:     ctx,
 		backend: backend,
@@ -36,6 +37,7 @@ func newRecordStream(ctx context.Context, backend *Backend, serverVersion, recor
 		changed:       backend.onChange.Bind(),
 		serverVersion: serverVersion,
 		recordVersion: recordVersion,
+		filter:        filter,
 
 		closed: make(chan struct{}),
 	}
@@ -94,12 +96,15 @@ func (stream *recordStream) Next(block bool) bool {
 			result := results[0]
 			var record databroker.Record
 			err = proto.Unmarshal([]byte(result), &record)
+			stream.recordVersion++
 			if err != nil {
 				log.Warn(changeCtx).Err(err).Msg("redis: invalid record detected")
+			} else if !stream.filter.Matches(&record) {
+				// skip changes which don't match the filter
+				continue
 			} else {
 				stream.record = &record
 			}
-			stream.recordVersion++
 			return true
 		}
 
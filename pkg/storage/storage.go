@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
@@ -21,6 +22,7 @@ var (
 	ErrNotFound             = errors.New("record not found")
 	ErrStreamClosed         = errors.New("record stream closed")
 	ErrInvalidServerVersion = status.Error(codes.Aborted, "invalid server version")
+	ErrVersionConflict      = status.Error(codes.FailedPrecondition, "record version conflict")
 )
 
 // A RecordStream is a stream of records.
@@ -46,16 +48,121 @@ type Backend interface {
 	Get(ctx context.Context, recordType, id string) (*databroker.Record, error)
 	// GetAll gets all the records.
 	GetAll(ctx context.Context) (records []*databroker.Record, version *databroker.Versions, err error)
+	// GetAllOfType gets all the records of the given type whose id starts with idPrefix.
+	GetAllOfType(ctx context.Context, recordType, idPrefix string) (records []*databroker.Record, err error)
 	// GetOptions gets the options for a type.
 	GetOptions(ctx context.Context, recordType string) (*databroker.Options, error)
+	// GetAllOptions gets the options of every type which has options set, keyed by type.
+	GetAllOptions(ctx context.Context) (map[string]*databroker.Options, error)
 	// Lease acquires a lease, or renews an existing one. If the lease is acquired true is returned.
 	Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error)
 	// Put is used to insert or update a record.
 	Put(ctx context.Context, record *databroker.Record) (serverVersion uint64, err error)
+	// PutMany is used to insert or update multiple records atomically. The records are assigned consecutive
+	// versions.
+	PutMany(ctx context.Context, records []*databroker.Record, options PutOptions) (serverVersion uint64, err error)
 	// SetOptions sets the options for a type.
 	SetOptions(ctx context.Context, recordType string, options *databroker.Options) error
-	// Sync syncs record changes after the specified version.
-	Sync(ctx context.Context, serverVersion, recordVersion uint64) (RecordStream, error)
+	// Sync syncs record changes after the specified version. Only changes matching the filter are returned.
+	Sync(ctx context.Context, serverVersion, recordVersion uint64, filter SyncFilter) (RecordStream, error)
+}
+
+// PutOptions customize how records are written by PutMany.
+type PutOptions struct {
+	// CheckVersions makes the write conditional: the version of every record must match the version currently
+	// stored, or 0 if the record doesn't exist. If any version doesn't match ErrVersionConflict is returned and no
+	// record is written.
+	CheckVersions bool
+	// KeepModifiedAt keeps the modified_at timestamp of the records instead of setting it to the current time. It's
+	// used when restoring records from an archive.
+	KeepModifiedAt bool
+}
+
+// GetModifiedAt returns the modified_at timestamp to store for a record written at now.
+func (options PutOptions) GetModifiedAt(record *databroker.Record, now *timestamppb.Timestamp) *timestamppb.Timestamp {
+	if options.KeepModifiedAt && record.GetModifiedAt() != nil {
+		return record.GetModifiedAt()
+	}
+	return now
+}
+
+// CheckVersions checks that the version of every record matches its stored version, as required by
+// PutOptions.CheckVersions. getVersion returns the stored version of a record, or 0 if it doesn't exist. Records
+// which appear more than once must match the version assigned to the previous write, where the first record is
+// assigned nextVersion.
+func CheckVersions(
+	records []*databroker.Record,
+	nextVersion uint64,
+	getVersion func(recordType, recordID string) (uint64, error),
+) error {
+	pending := map[[2]string]uint64{}
+	for _, record := range records {
+		key := [2]string{record.GetType(), record.GetId()}
+		current, ok := pending[key]
+		if !ok {
+			var err error
+			current, err = getVersion(record.GetType(), record.GetId())
+			if err != nil {
+				return err
+			}
+		}
+		if record.GetVersion() != current {
+			return ErrVersionConflict
+		}
+
+		if record.GetDeletedAt() != nil {
+			pending[key] = 0
+		} else {
+			pending[key] = nextVersion
+		}
+		nextVersion++
+	}
+	return nil
+}
+
+// GetRecordExpiry returns the time a record expires. The record's expires_at takes precedence over the ttl of its
+// type. If the record doesn't expire false is returned.
+func GetRecordExpiry(record *databroker.Record, options *databroker.Options) (expiresAt time.Time, ok bool) {
+	if record.GetExpiresAt() != nil {
+		return record.GetExpiresAt().AsTime(), true
+	}
+	if options.GetTtl() != nil {
+		return record.GetModifiedAt().AsTime().Add(options.GetTtl().AsDuration()), true
+	}
+	return time.Time{}, false
+}
+
+// A SyncFilter restricts the records returned by Sync. An empty filter matches every record.
+type SyncFilter struct {
+	// Types restricts the records to the given types.
+	Types []string
+	// IDPrefixes restricts the records to those with an id starting with one of the given prefixes.
+	IDPrefixes []string
+}
+
+// Matches returns true if the record matches the filter.
+func (filter SyncFilter) Matches(record *databroker.Record) bool {
+	if len(filter.Types) > 0 && !containsString(filter.Types, record.GetType()) {
+		return false
+	}
+	if len(filter.IDPrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range filter.IDPrefixes {
+		if strings.HasPrefix(record.GetId(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // MatchAny searches any data with a query.
@@ -3,8 +3,11 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
@@ -13,9 +16,11 @@ import (
 
 type mockBackend struct {
 	Backend
-	put    func(ctx context.Context, record *databroker.Record) (uint64, error)
-	get    func(ctx context.Context, recordType, id string) (*databroker.Record, error)
-	getAll func(ctx context.Context) ([]*databroker.Record, *databroker.Versions, error)
+	put     func(ctx context.Context, record *databroker.Record) (uint64, error)
+	putMany func(ctx context.Context, records []*databroker.Record, options PutOptions) (uint64, error)
+	get     func(ctx context.Context, recordType, id string) (*databroker.Record, error)
+	getAll  func(ctx context.Context) ([]*databroker.Record, *databroker.Versions, error)
+	lease   func(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error)
 }
 
 func (m *mockBackend) Close() error {
@@ -26,6 +31,14 @@ func (m *mockBackend) Put(ctx context.Context, record *databroker.Record) (uint6
 	return m.put(ctx, record)
 }
 
+func (m *mockBackend) PutMany(ctx context.Context, records []*databroker.Record, options PutOptions) (uint64, error) {
+	return m.putMany(ctx, records, options)
+}
+
+func (m *mockBackend) Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
+	return m.lease(ctx, leaseName, leaseID, ttl)
+}
+
 func (m *mockBackend) Get(ctx context.Context, recordType, id string) (*databroker.Record, error) {
 	return m.get(ctx, recordType, id)
 }
@@ -43,3 +56,57 @@ func TestMatchAny(t *testing.T) {
 	assert.True(t, MatchAny(data, "email"))
 	assert.False(t, MatchAny(data, "nope"))
 }
+
+func TestSyncFilter(t *testing.T) {
+	record := &databroker.Record{Type: "TYPE", Id: "abc"}
+	assert.True(t, SyncFilter{}.Matches(record))
+	assert.True(t, SyncFilter{Types: []string{"OTHER", "TYPE"}}.Matches(record))
+	assert.False(t, SyncFilter{Types: []string{"OTHER"}}.Matches(record))
+	assert.True(t, SyncFilter{IDPrefixes: []string{"x", "ab"}}.Matches(record))
+	assert.False(t, SyncFilter{IDPrefixes: []string{"b"}}.Matches(record))
+	assert.False(t, SyncFilter{Types: []string{"OTHER"}, IDPrefixes: []string{"ab"}}.Matches(record))
+}
+
+func TestCheckVersions(t *testing.T) {
+	stored := map[string]uint64{"a": 3}
+	getVersion := func(recordType, recordID string) (uint64, error) {
+		return stored[recordID], nil
+	}
+
+	assert.NoError(t, CheckVersions([]*databroker.Record{
+		{Id: "a", Version: 3},
+		{Id: "b", Version: 0},
+	}, 10, getVersion))
+	assert.ErrorIs(t, CheckVersions([]*databroker.Record{
+		{Id: "a", Version: 2},
+	}, 10, getVersion), ErrVersionConflict)
+	assert.ErrorIs(t, CheckVersions([]*databroker.Record{
+		{Id: "b", Version: 1},
+	}, 10, getVersion), ErrVersionConflict)
+	assert.NoError(t, CheckVersions([]*databroker.Record{
+		{Id: "a", Version: 3},
+		{Id: "a", Version: 10},
+		{Id: "a", Version: 11, DeletedAt: timestamppb.Now()},
+		{Id: "a", Version: 0},
+	}, 10, getVersion), "should check duplicates against the previous write")
+}
+
+func TestGetRecordExpiry(t *testing.T) {
+	modifiedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := modifiedAt.Add(time.Minute)
+	ttl := &databroker.Options{Ttl: durationpb.New(time.Hour)}
+
+	_, ok := GetRecordExpiry(&databroker.Record{ModifiedAt: timestamppb.New(modifiedAt)}, new(databroker.Options))
+	assert.False(t, ok)
+
+	actual, ok := GetRecordExpiry(&databroker.Record{ModifiedAt: timestamppb.New(modifiedAt)}, ttl)
+	assert.True(t, ok)
+	assert.Equal(t, modifiedAt.Add(time.Hour), actual)
+
+	actual, ok = GetRecordExpiry(&databroker.Record{
+		ModifiedAt: timestamppb.New(modifiedAt),
+		ExpiresAt:  timestamppb.New(expiresAt),
+	}, ttl)
+	assert.True(t, ok)
+	assert.Equal(t, expiresAt, actual, "should prefer the record's expiry")
+}